@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -21,6 +22,10 @@ func main() {
 	rootCmd.Version = fmt.Sprintf("%s-%s (built %s)", version, commit, date)
 	err := rootCmd.Execute()
 	if err != nil {
+		var exitErr *client.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
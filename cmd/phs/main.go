@@ -7,11 +7,20 @@ import (
 	"github.com/isometry/platform-health/pkg/commands/server"
 
 	// import providers to trigger registration
+	_ "github.com/isometry/platform-health/pkg/provider/argocd"
+	_ "github.com/isometry/platform-health/pkg/provider/dns"
+	_ "github.com/isometry/platform-health/pkg/provider/featureflag"
 	_ "github.com/isometry/platform-health/pkg/provider/grpc"
 	_ "github.com/isometry/platform-health/pkg/provider/helm"
 	_ "github.com/isometry/platform-health/pkg/provider/http"
 	_ "github.com/isometry/platform-health/pkg/provider/kubernetes"
+	_ "github.com/isometry/platform-health/pkg/provider/oidc"
+	_ "github.com/isometry/platform-health/pkg/provider/plugin"
+	_ "github.com/isometry/platform-health/pkg/provider/postgres"
+	_ "github.com/isometry/platform-health/pkg/provider/redis"
+	_ "github.com/isometry/platform-health/pkg/provider/s3"
 	_ "github.com/isometry/platform-health/pkg/provider/satellite"
+	_ "github.com/isometry/platform-health/pkg/provider/snmp"
 	_ "github.com/isometry/platform-health/pkg/provider/tcp"
 	_ "github.com/isometry/platform-health/pkg/provider/tls"
 	_ "github.com/isometry/platform-health/pkg/provider/vault"
@@ -8,7 +8,11 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -16,24 +20,40 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/isometry/platform-health/pkg/formatter"
 	ph "github.com/isometry/platform-health/pkg/platform_health"
 	_ "github.com/isometry/platform-health/pkg/platform_health/details"
 )
 
 var (
-	targetHost         string
+	targetHosts        []string
 	targetPort         int
 	tlsClient          bool
 	insecureSkipVerify bool
 	clientTimeout      time.Duration
 	flatOutput         bool
+	outputFormat       string
+	sortOrder          string
 	quietLevel         int
+	notifyWebhook      string
+	notifyTemplate     string
+
+	outputFormatter   formatter.Formatter
+	notifyPayloadTmpl *template.Template
 
 	log *slog.Logger
 )
 
+// ExitError requests a specific process exit code, as defined by an ExitCoder output format
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
 var ClientCmd = &cobra.Command{
 	Args:          cobra.MaximumNArgs(1),
 	Use:           fmt.Sprintf("%s [flags] [host:port]", filepath.Base(os.Args[0])),
@@ -45,13 +65,17 @@ var ClientCmd = &cobra.Command{
 
 func init() {
 	flagSet := ClientCmd.Flags()
-	flagSet.StringVarP(&targetHost, "server", "s", "localhost", "server host")
+	flagSet.StringSliceVarP(&targetHosts, "server", "s", []string{"localhost"}, "server host, or host:port; repeat to check multiple servers")
 	flagSet.IntVarP(&targetPort, "port", "p", 8080, "server port")
 	flagSet.BoolVar(&tlsClient, "tls", false, "enable tls")
 	flagSet.BoolVarP(&insecureSkipVerify, "insecure", "k", false, "disable certificate verification")
 	flagSet.DurationVarP(&clientTimeout, "timeout", "t", 10*time.Second, "timeout")
 	flagSet.BoolVarP(&flatOutput, "flat", "f", false, "flat output")
+	flagSet.StringVarP(&outputFormat, "output", "o", formatter.FormatJSON, fmt.Sprintf("output format (%s)", strings.Join(formatter.FormatterList(), "|")))
+	flagSet.StringVar(&sortOrder, "sort", formatter.SortDeclared, fmt.Sprintf("component order (%s)", strings.Join(formatter.SortOrders, "|")))
 	flagSet.CountVarP(&quietLevel, "quiet", "q", "quiet output")
+	flagSet.StringVar(&notifyWebhook, "notify-webhook", "", "post failing checks to webhook url when unhealthy")
+	flagSet.StringVar(&notifyTemplate, "notify-template", "", "text/template over the response for the webhook payload (default Slack-compatible)")
 	flagSet.SortFlags = false
 }
 
@@ -60,8 +84,20 @@ func setup(cmd *cobra.Command, args []string) (err error) {
 	slog.SetDefault(slog.New(handler))
 	log = slog.Default()
 
+	if outputFormatter, err = formatter.Get(outputFormat); err != nil {
+		return err
+	}
+
+	if !slices.Contains(formatter.SortOrders, sortOrder) {
+		return fmt.Errorf("unknown sort order %q (available: %s)", sortOrder, strings.Join(formatter.SortOrders, ", "))
+	}
+
+	if notifyPayloadTmpl, err = parseNotifyTemplate(notifyTemplate); err != nil {
+		return err
+	}
+
 	if len(args) == 1 {
-		var targetPortStr string
+		var targetHost, targetPortStr string
 		targetHost, targetPortStr, err = net.SplitHostPort(args[0])
 		if err != nil {
 			return err
@@ -70,28 +106,89 @@ func setup(cmd *cobra.Command, args []string) (err error) {
 		if err != nil {
 			return err
 		}
+		targetHosts = []string{targetHost}
+	}
+
+	if len(targetHosts) == 0 {
+		return fmt.Errorf("no server specified")
 	}
 
 	return nil
 }
 
 func query(cmd *cobra.Command, _ []string) (err error) {
-	address := net.JoinHostPort(targetHost, fmt.Sprint(targetPort))
+	ctx := slogctx.NewCtx(context.Background(), log)
+	cmd.SetContext(ctx)
 
-	ctx, cancel := context.WithTimeout(context.Background(), clientTimeout)
-	defer cancel()
+	var status *ph.HealthCheckResponse
+	if len(targetHosts) == 1 {
+		host, port := splitTarget(targetHosts[0])
+		if status, err = check(ctx, host, port); err != nil {
+			return err
+		}
+	} else {
+		status = checkAll(ctx, targetHosts)
+	}
 
-	ctx = slogctx.NewCtx(ctx, log)
-	cmd.SetContext(ctx)
+	if notifyWebhook != "" && status.GetStatus() != ph.Status_HEALTHY {
+		notifyCtx, cancel := context.WithTimeout(ctx, clientTimeout)
+		defer cancel()
+		if err := notify(notifyCtx, notifyWebhook, notifyPayloadTmpl, status); err != nil {
+			log.Error("failed to notify", slog.String("webhook", notifyWebhook), slog.Any("error", err))
+		}
+	}
+
+	switch {
+	case quietLevel > 1:
+		return status.IsHealthy()
+	case quietLevel > 0:
+		status.Components = nil
+	}
+
+	if flatOutput {
+		status.Components = status.Flatten(status.Name)
+	}
 
-	if targetPort == 443 || targetPort == 8443 {
-		tlsClient = true
+	if err := formatter.Sort(status, sortOrder); err != nil {
+		return err
+	}
+
+	if err := outputFormatter.Format(os.Stdout, status); err != nil {
+		return err
 	}
 
+	if coder, ok := outputFormatter.(formatter.ExitCoder); ok {
+		if code := coder.ExitCode(status); code != 0 {
+			return &ExitError{Code: code}
+		}
+		return nil
+	}
+
+	return status.IsHealthy()
+}
+
+// splitTarget returns the host and port of a server given as host or host:port,
+// defaulting to the --port flag.
+func splitTarget(target string) (string, int) {
+	if host, portStr, err := net.SplitHostPort(target); err == nil {
+		if port, err := strconv.Atoi(portStr); err == nil {
+			return host, port
+		}
+	}
+	return target, targetPort
+}
+
+// check queries the server at host:port, allowing it the full client timeout.
+func check(ctx context.Context, host string, port int) (*ph.HealthCheckResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, clientTimeout)
+	defer cancel()
+
+	address := net.JoinHostPort(host, fmt.Sprint(port))
+
 	dialOptions := []grpc.DialOption{}
-	if tlsClient {
+	if tlsClient || port == 443 || port == 8443 {
 		tlsConf := &tls.Config{
-			ServerName: targetHost,
+			ServerName: host,
 		}
 		if insecureSkipVerify {
 			tlsConf.InsecureSkipVerify = true
@@ -103,35 +200,55 @@ func query(cmd *cobra.Command, _ []string) (err error) {
 
 	conn, err := grpc.NewClient(address, dialOptions...)
 	if err != nil {
-		log.Error("failed to connect to server", slog.String("server", targetHost), slog.Any("error", err))
-		return err
+		log.Error("failed to connect to server", slog.String("server", host), slog.Any("error", err))
+		return nil, err
 	}
+	defer conn.Close()
 
 	health := ph.NewHealthClient(conn)
 
 	status, err := health.Check(ctx, &ph.HealthCheckRequest{})
 	if err != nil {
-		log.Info("failed to check", slog.Any("error", err))
-		return err
+		log.Info("failed to check", slog.String("server", address), slog.Any("error", err))
+		return nil, err
 	}
 
-	switch {
-	case quietLevel > 1:
-		return status.IsHealthy()
-	case quietLevel > 0:
-		status.Components = nil
-	}
+	return status, nil
+}
 
-	if flatOutput {
-		status.Components = status.Flatten(status.Name)
+// checkAll queries every server concurrently, merging their responses as
+// components of a synthetic system response with the worst of their statuses.
+// Servers that cannot be checked are reported as UNHEALTHY.
+func checkAll(ctx context.Context, targets []string) *ph.HealthCheckResponse {
+	system := &ph.HealthCheckResponse{
+		Type:       "system",
+		Name:       "system",
+		Status:     ph.Status_HEALTHY,
+		Components: make([]*ph.HealthCheckResponse, len(targets)),
 	}
 
-	pjson, err := protojson.Marshal(status)
-	if err != nil {
-		return err
+	var wg sync.WaitGroup
+	for n, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			host, port := splitTarget(target)
+			status, err := check(ctx, host, port)
+			if err != nil {
+				status = (&ph.HealthCheckResponse{}).Unhealthy(err.Error())
+			}
+			status.Type = "server"
+			status.Name = net.JoinHostPort(host, fmt.Sprint(port))
+			system.Components[n] = status
+		}()
 	}
+	wg.Wait()
 
-	fmt.Println(string(pjson))
+	for _, component := range system.Components {
+		if component.Status.Number() > system.Status.Number() {
+			system.Status = component.Status
+		}
+	}
 
-	return status.IsHealthy()
+	return system
 }
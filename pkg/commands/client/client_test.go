@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+type cannedHealthServer struct {
+	ph.UnimplementedHealthServer
+	response *ph.HealthCheckResponse
+}
+
+func (s *cannedHealthServer) Check(context.Context, *ph.HealthCheckRequest) (*ph.HealthCheckResponse, error) {
+	return s.response, nil
+}
+
+func serve(t *testing.T, response *ph.HealthCheckResponse) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	ph.RegisterHealthServer(grpcServer, &cannedHealthServer{response: response})
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	return listener.Addr().String()
+}
+
+func TestCheckAll(t *testing.T) {
+	log = slog.Default()
+	clientTimeout = time.Second
+
+	healthy := serve(t, &ph.HealthCheckResponse{
+		Status:     ph.Status_HEALTHY,
+		Components: []*ph.HealthCheckResponse{{Type: "tcp", Name: "ssh", Status: ph.Status_HEALTHY}},
+	})
+	unhealthy := serve(t, &ph.HealthCheckResponse{Status: ph.Status_UNHEALTHY})
+
+	closed, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	unreachable := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	tests := []struct {
+		name     string
+		targets  []string
+		expected ph.Status
+		children []ph.Status
+	}{
+		{
+			name:     "All healthy",
+			targets:  []string{healthy, healthy},
+			expected: ph.Status_HEALTHY,
+			children: []ph.Status{ph.Status_HEALTHY, ph.Status_HEALTHY},
+		},
+		{
+			name:     "One unhealthy",
+			targets:  []string{healthy, unhealthy},
+			expected: ph.Status_UNHEALTHY,
+			children: []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY},
+		},
+		{
+			name:     "One unreachable",
+			targets:  []string{unreachable, healthy},
+			expected: ph.Status_UNHEALTHY,
+			children: []ph.Status{ph.Status_UNHEALTHY, ph.Status_HEALTHY},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkAll(context.Background(), tt.targets)
+
+			assert.Equal(t, "system", result.GetType())
+			assert.Equal(t, tt.expected, result.GetStatus())
+			require.Len(t, result.Components, len(tt.targets))
+			for n, child := range result.Components {
+				assert.Equal(t, "server", child.GetType())
+				assert.Equal(t, tt.targets[n], child.GetName())
+				assert.Equal(t, tt.children[n], child.GetStatus())
+				if tt.targets[n] == unreachable {
+					assert.NotEmpty(t, child.GetMessage())
+				}
+				if tt.targets[n] == healthy {
+					assert.Len(t, child.Components, 1)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitTarget(t *testing.T) {
+	targetPort = 8080
+
+	host, port := splitTarget("example.com")
+	assert.Equal(t, "example.com", host)
+	assert.Equal(t, 8080, port)
+
+	host, port = splitTarget("example.com:443")
+	assert.Equal(t, "example.com", host)
+	assert.Equal(t, 443, port)
+}
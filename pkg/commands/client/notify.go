@@ -0,0 +1,147 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"text/template"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+// failure is a leaf component that is not healthy, with the labels of the
+// server that reported it
+type failure struct {
+	Path    string
+	Type    string
+	Name    string
+	Status  string
+	Message string
+	Labels  map[string]string `json:",omitempty"`
+}
+
+var notifyFuncs = template.FuncMap{
+	"failures": failures,
+	"labels":   labels,
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// parseNotifyTemplate parses and trial-renders text as a text/template over
+// the response, so that invalid templates are rejected before any check. An
+// empty text selects the default Slack-compatible payload.
+func parseNotifyTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("notify").Funcs(notifyFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notify template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, &ph.HealthCheckResponse{}); err != nil {
+		return nil, fmt.Errorf("invalid notify template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// notify posts a summary of the failing components of status to webhook. The
+// payload is rendered from tmpl, or is a Slack-compatible message if tmpl is nil.
+func notify(ctx context.Context, webhook string, tmpl *template.Template, status *ph.HealthCheckResponse) error {
+	payload, err := notifyPayload(tmpl, status)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+func notifyPayload(tmpl *template.Template, status *ph.HealthCheckResponse) ([]byte, error) {
+	if tmpl == nil {
+		lines := []string{fmt.Sprintf("platform-health is %s", status.GetStatus())}
+		for _, f := range failures(status) {
+			line := fmt.Sprintf("• %s: %s", f.Path, f.Status)
+			if f.Message != "" {
+				line = fmt.Sprintf("%s: %s", line, f.Message)
+			}
+			lines = append(lines, line)
+		}
+		return json.Marshal(map[string]string{"text": strings.Join(lines, "\n")})
+	}
+
+	var payload bytes.Buffer
+	if err := tmpl.Execute(&payload, status); err != nil {
+		return nil, fmt.Errorf("failed to render notify template: %w", err)
+	}
+
+	return payload.Bytes(), nil
+}
+
+// failures returns the leaf components of status that are not healthy, with
+// the first line of their message and the labels of the nearest enclosing
+// response carrying metadata.
+func failures(status *ph.HealthCheckResponse) (failed []failure) {
+	var walk func(parent string, inherited map[string]string, component *ph.HealthCheckResponse)
+	walk = func(parent string, inherited map[string]string, component *ph.HealthCheckResponse) {
+		if own := labels(component); own != nil {
+			inherited = own
+		}
+		if len(component.GetComponents()) == 0 {
+			if component.GetStatus() != ph.Status_HEALTHY {
+				message, _, _ := strings.Cut(component.GetMessage(), "\n")
+				failed = append(failed, failure{
+					Path:    parent,
+					Type:    component.GetType(),
+					Name:    component.GetName(),
+					Status:  component.GetStatus().String(),
+					Message: message,
+					Labels:  inherited,
+				})
+			}
+			return
+		}
+		for _, child := range component.GetComponents() {
+			walk(path.Join(parent, child.GetType(), child.GetName()), inherited, child)
+		}
+	}
+	if len(status.GetComponents()) > 0 {
+		walk("", nil, status)
+	}
+	return failed
+}
+
+// labels returns the metadata labels attached to component, if any
+func labels(component *ph.HealthCheckResponse) map[string]string {
+	for _, detail := range component.GetDetails() {
+		metadata := &details.Detail_Metadata{}
+		if detail.MessageIs(metadata) && detail.UnmarshalTo(metadata) == nil {
+			return metadata.GetLabels()
+		}
+	}
+	return nil
+}
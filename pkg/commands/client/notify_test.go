@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+var unhealthy = &ph.HealthCheckResponse{
+	Status: ph.Status_UNHEALTHY,
+	Components: []*ph.HealthCheckResponse{
+		{Type: "tcp", Name: "ssh", Status: ph.Status_HEALTHY},
+		{
+			Type:   "satellite",
+			Name:   "remote",
+			Status: ph.Status_UNHEALTHY,
+			Components: []*ph.HealthCheckResponse{
+				{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: "unexpected status code 503\nretry later"},
+			},
+		},
+	},
+}
+
+func TestNotify(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		status   int
+		expected string
+		err      bool
+	}{
+		{
+			name:     "Default payload",
+			status:   http.StatusOK,
+			expected: `{"text":"platform-health is UNHEALTHY\n• satellite/remote/http/api: UNHEALTHY: unexpected status code 503"}`,
+		},
+		{
+			name:     "Custom template",
+			template: `{"status":"{{ .Status }}","checks":{{ json (failures .) }}}`,
+			status:   http.StatusOK,
+			expected: `{"status":"UNHEALTHY","checks":[{"Path":"satellite/remote/http/api","Type":"http","Name":"api","Status":"UNHEALTHY","Message":"unexpected status code 503"}]}`,
+		},
+		{
+			name:   "Webhook failure",
+			status: http.StatusInternalServerError,
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				received, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			tmpl, err := parseNotifyTemplate(tt.template)
+			require.NoError(t, err)
+
+			err = notify(context.Background(), server.URL, tmpl, unhealthy)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, json.Valid(received))
+			assert.JSONEq(t, tt.expected, string(received))
+		})
+	}
+}
+
+func TestNotifyTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := notify(ctx, server.URL, nil, unhealthy)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestParseNotifyTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		err      string
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name:     "Valid",
+			template: `{{ range failures . }}{{ .Path }}{{ end }}`,
+		},
+		{
+			name:     "Syntax error",
+			template: `{{ range failures . }}`,
+			err:      "invalid notify template",
+		},
+		{
+			name:     "Unknown field",
+			template: `{{ .Unknown }}`,
+			err:      "invalid notify template",
+		},
+		{
+			name:     "Unknown function",
+			template: `{{ unknown . }}`,
+			err:      "invalid notify template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseNotifyTemplate(tt.template)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNotifyPayloadTemplate(t *testing.T) {
+	metadata, err := anypb.New(&details.Detail_Metadata{Labels: map[string]string{"env": "prod"}})
+	require.NoError(t, err)
+
+	status := &ph.HealthCheckResponse{
+		Status:  ph.Status_UNHEALTHY,
+		Details: []*anypb.Any{metadata},
+		Components: []*ph.HealthCheckResponse{
+			{Type: "tcp", Name: "ssh", Status: ph.Status_HEALTHY},
+			{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: "unexpected status code 503"},
+			{Type: "dns", Name: "www", Status: ph.Status_UNKNOWN},
+		},
+	}
+
+	tmpl, err := parseNotifyTemplate(`{"content":"{{ range $i, $f := failures . }}{{ if $i }}, {{ end }}[{{ $f.Labels.env }}] {{ $f.Path }} {{ $f.Status }}{{ with $f.Message }}: {{ . }}{{ end }}{{ end }}"}`)
+	require.NoError(t, err)
+
+	payload, err := notifyPayload(tmpl, status)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"content":"[prod] http/api UNHEALTHY: unexpected status code 503, [prod] dns/www UNKNOWN"}`, string(payload))
+}
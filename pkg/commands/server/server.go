@@ -1,13 +1,17 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -24,11 +28,21 @@ import (
 var (
 	listenHost     string
 	listenPort     int
+	httpPort       int
+	metricsAddr    string
 	configPaths    []string
 	configName     string
 	oneShot        bool
+	planOnly       bool
 	noGrpcHealthV1 bool
 	grpcReflection bool
+	extendTimeouts bool
+	deadline       time.Duration
+	flapWindow     time.Duration
+	flapThreshold  int
+	validFor       time.Duration
+	watchInterval  time.Duration
+	metadata       map[string]string
 	jsonOutput     bool
 	debugMode      bool
 	verbosity      int
@@ -43,6 +57,9 @@ var ServerCmd = &cobra.Command{
 	Use:     fmt.Sprintf("%s [flags] [host:port]", filepath.Base(os.Args[0])),
 	PreRunE: setup,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if planOnly {
+			return plan(cmd, args)
+		}
 		if oneShot {
 			return oneshot(cmd, args)
 		}
@@ -116,6 +133,25 @@ func serve(_ *cobra.Command, args []string) (err error) {
 	if grpcReflection {
 		opts = append(opts, server.WithReflection())
 	}
+	if extendTimeouts {
+		opts = append(opts, server.WithTimeoutPolicy(provider.TimeoutExtend))
+	}
+	if deadline > 0 {
+		opts = append(opts, server.WithDeadline(deadline))
+	}
+	if flapWindow > 0 {
+		opts = append(opts, server.WithFlapDetection(flapWindow, flapThreshold))
+	}
+	if validFor > 0 {
+		opts = append(opts, server.WithValidity(validFor))
+	}
+	if len(metadata) > 0 {
+		opts = append(opts, server.WithMetadata(metadata))
+	}
+	if metricsAddr != "" {
+		opts = append(opts, server.WithMetrics())
+	}
+	opts = append(opts, server.WithWatchInterval(watchInterval))
 
 	srv, err := server.NewPlatformHealthServer(&serverId, conf, opts...)
 	if err != nil {
@@ -123,15 +159,76 @@ func serve(_ *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	if httpPort > 0 {
+		httpAddress := net.JoinHostPort(listenHost, fmt.Sprint(httpPort))
+		httpListener, err := net.Listen("tcp", httpAddress)
+		if err != nil {
+			log.Error("failed to open http listener", slog.Any("error", err))
+			return err
+		}
+
+		log.Info("listening for http", "address", httpAddress)
+
+		go func() {
+			if err := srv.ServeHTTP(httpListener); err != nil {
+				log.Error("http server failed", slog.Any("error", err))
+			}
+		}()
+	}
+
+	if metricsAddr != "" {
+		metricsListener, err := net.Listen("tcp", metricsAddr)
+		if err != nil {
+			log.Error("failed to open metrics listener", slog.Any("error", err))
+			return err
+		}
+
+		log.Info("listening for metrics", "address", metricsAddr)
+
+		go func() {
+			if err := srv.ServeMetrics(metricsListener); err != nil {
+				log.Error("metrics server failed", slog.Any("error", err))
+			}
+		}()
+	}
+
+	if r, ok := conf.(reloader); ok {
+		reloadOnSignal(r)
+	}
+
 	return srv.Serve(listener)
 }
 
+// reloader is implemented by configurations that can be reloaded in place.
+type reloader interface {
+	Reload() error
+}
+
+// reloadOnSignal reloads the configuration on every SIGHUP. Failures are
+// logged by Reload, and leave the current configuration in place.
+func reloadOnSignal(r reloader) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		for range signals {
+			log.Info("reloading config", slog.String("signal", "SIGHUP"))
+			_ = r.Reload()
+		}
+	}()
+}
+
 func oneshot(cmd *cobra.Command, _ []string) error {
 	cmd.SilenceErrors = true
 	level.Set(slog.LevelError)
 
 	serverId := "oneshot"
-	srv, err := server.NewPlatformHealthServer(&serverId, conf)
+	opts := []server.Option{server.WithMetadata(metadata), server.WithDeadline(deadline)}
+	if extendTimeouts {
+		opts = append(opts, server.WithTimeoutPolicy(provider.TimeoutExtend))
+	}
+
+	srv, err := server.NewPlatformHealthServer(&serverId, conf, opts...)
 	if err != nil {
 		log.Error("failed to create server", "error", err)
 		return err
@@ -152,3 +249,20 @@ func oneshot(cmd *cobra.Command, _ []string) error {
 
 	return status.IsHealthy()
 }
+
+func plan(_ *cobra.Command, _ []string) error {
+	steps, err := provider.Plan(conf.GetInstances())
+	if err != nil {
+		log.Error("failed to plan", "error", err)
+		return err
+	}
+
+	pjson, err := json.Marshal(steps)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(pjson))
+
+	return nil
+}
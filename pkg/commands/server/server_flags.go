@@ -1,6 +1,8 @@
 package server
 
 import (
+	"time"
+
 	"github.com/isometry/platform-health/pkg/config"
 	"github.com/isometry/platform-health/pkg/utils"
 	"github.com/spf13/pflag"
@@ -34,6 +36,18 @@ var serverFlags = flagValues{
 		defaultValue: 8080,
 		usage:        "listen on port",
 	},
+	"http-port": {
+		kind:         "int",
+		variable:     &httpPort,
+		defaultValue: 0,
+		usage:        "serve HTTP endpoints (e.g. /badge) on port (default disabled)",
+	},
+	"metrics-addr": {
+		kind:         "string",
+		variable:     &metricsAddr,
+		defaultValue: "",
+		usage:        "serve Prometheus metrics at /metrics on address, e.g. :9090 (default disabled)",
+	},
 	"config-path": {
 		shorthand:    "C",
 		kind:         "stringSlice",
@@ -55,6 +69,12 @@ var serverFlags = flagValues{
 		defaultValue: false,
 		usage:        "one-shot mode",
 	},
+	"plan": {
+		kind:         "bool",
+		variable:     &planOnly,
+		defaultValue: false,
+		usage:        "print the components that would be checked, without checking them",
+	},
 	"no-grpc-health-v1": {
 		shorthand:    "H",
 		kind:         "bool",
@@ -69,6 +89,49 @@ var serverFlags = flagValues{
 		defaultValue: false,
 		usage:        "enable gRPC reflection",
 	},
+	"extend-timeouts": {
+		kind:         "bool",
+		variable:     &extendTimeouts,
+		defaultValue: false,
+		usage:        "honor instance timeouts exceeding the request deadline",
+	},
+	"deadline": {
+		kind:         "duration",
+		variable:     &deadline,
+		defaultValue: time.Duration(0),
+		usage:        "cap each check at deadline, reporting incomplete components as unknown (default disabled)",
+	},
+	"flap-window": {
+		kind:         "duration",
+		variable:     &flapWindow,
+		defaultValue: time.Duration(0),
+		usage:        "flag components flapping within window (default disabled)",
+	},
+	"flap-threshold": {
+		kind:         "int",
+		variable:     &flapThreshold,
+		defaultValue: 4,
+		usage:        "status transitions within flap-window considered flapping",
+	},
+	"valid-for": {
+		kind:         "duration",
+		variable:     &validFor,
+		defaultValue: time.Duration(0),
+		usage:        "annotate responses as valid for duration, or until the first cached component expires (default disabled)",
+	},
+	"watch-interval": {
+		kind:         "duration",
+		variable:     &watchInterval,
+		defaultValue: 10 * time.Second,
+		usage:        "interval at which components are rechecked for Watch streams",
+	},
+	"metadata": {
+		shorthand:    "m",
+		kind:         "stringToString",
+		variable:     &metadata,
+		defaultValue: map[string]string{},
+		usage:        "metadata attached to every response (e.g. commit=abc123,environment=production)",
+	},
 	"json": {
 		shorthand:    "j",
 		kind:         "bool",
@@ -106,10 +169,14 @@ func (f *flagValue) buildFlag(flagSet *pflag.FlagSet, flagName string) error {
 		flagSet.BoolVarP(f.variable.(*bool), flagName, f.shorthand, f.defaultValue.(bool), f.usage)
 	case "count":
 		flagSet.CountVarP(f.variable.(*int), flagName, f.shorthand, f.usage)
+	case "duration":
+		flagSet.DurationVarP(f.variable.(*time.Duration), flagName, f.shorthand, f.defaultValue.(time.Duration), f.usage)
 	case "int":
 		flagSet.IntVarP(f.variable.(*int), flagName, f.shorthand, f.defaultValue.(int), f.usage)
 	case "string":
 		flagSet.StringVarP(f.variable.(*string), flagName, f.shorthand, f.defaultValue.(string), f.usage)
+	case "stringToString":
+		flagSet.StringToStringVarP(f.variable.(*map[string]string), flagName, f.shorthand, f.defaultValue.(map[string]string), f.usage)
 	case "stringSlice":
 		flagSet.StringSliceVarP(f.variable.(*[]string), flagName, f.shorthand, f.defaultValue.([]string), f.usage)
 	}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/mitchellh/mapstructure"
@@ -27,6 +32,10 @@ var FlagPrefix = flagPrefix("server")
 
 var log *slog.Logger
 
+// mu guards the replacement of instances on reload, such that checks in
+// flight complete against the instances they started with.
+var mu sync.RWMutex
+
 func Load(ctx context.Context, configPaths []string, configName string) (*concreteConfig, error) {
 	log = utils.ContextLogger(ctx)
 
@@ -37,11 +46,16 @@ func Load(ctx context.Context, configPaths []string, configName string) (*concre
 	return conf, nil
 }
 
+// GetInstances returns all instances ordered by provider type, and then in
+// the order declared for each provider.
 func (c *concreteConfig) GetInstances() []provider.Instance {
+	mu.RLock()
+	defer mu.RUnlock()
+
 	flatInstances := make([]provider.Instance, 0, c.totalInstances())
 
-	for _, instances := range *c {
-		flatInstances = append(flatInstances, instances...)
+	for _, typeName := range slices.Sorted(maps.Keys(*c)) {
+		flatInstances = append(flatInstances, (*c)[typeName]...)
 	}
 
 	return flatInstances
@@ -72,15 +86,7 @@ func (c *concreteConfig) initialize(configPaths []string, configName string) (er
 			viper.WatchConfig()
 			viper.OnConfigChange(func(e fsnotify.Event) {
 				log.Debug("config change")
-				if err = viper.ReadInConfig(); err != nil {
-					log.Error("failed to read config", "error", err)
-					return
-				}
-				if err = c.update(); err != nil {
-					log.Error("failed to load config", "error", err)
-				}
-
-				log.Info("config reloaded", slog.Any("instances", c.countByProvider()))
+				_ = c.Reload()
 			})
 		}
 	}
@@ -96,6 +102,23 @@ func (c *concreteConfig) initialize(configPaths []string, configName string) (er
 	return nil
 }
 
+// Reload rereads the configuration file, replacing all instances once the new
+// configuration is valid. Invalid configurations are logged and returned,
+// keeping the current instances.
+func (c *concreteConfig) Reload() error {
+	if err := viper.ReadInConfig(); err != nil {
+		log.Error("failed to read config", "error", err)
+		return err
+	}
+	if err := c.update(); err != nil {
+		log.Error("failed to load config", "error", err)
+		return err
+	}
+
+	log.Info("config reloaded", slog.Any("instances", c.countByProvider()))
+	return nil
+}
+
 func (c *concreteConfig) update() error {
 	abstract := make(abstractConfig)
 
@@ -104,11 +127,106 @@ func (c *concreteConfig) update() error {
 		return err
 	}
 
-	*c = *abstract.harden()
+	concrete := abstract.harden()
+	if _, err := provider.SortByDependencies(concrete.GetInstances()); err != nil {
+		log.Error("invalid dependencies", "error", err)
+		return err
+	}
+
+	mu.Lock()
+	*c = *concrete
+	mu.Unlock()
 
 	return nil
 }
 
+// decode decodes the abstract input into output, parsing durations such as "5s"
+func decode(input, output any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
+		Result:     output,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
+}
+
+// decodeDependsOn extracts the provider-independent dependsOn attribute of an instance
+func decodeDependsOn(abstractInstance any) (dependsOn []string, err error) {
+	attributes, ok := abstractInstance.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	for key, value := range attributes {
+		if strings.EqualFold(key, "dependsOn") {
+			if err := decode(value, &dependsOn); err != nil {
+				return nil, fmt.Errorf("invalid dependsOn: %w", err)
+			}
+		}
+	}
+
+	return dependsOn, nil
+}
+
+// decodeRequires extracts the provider-independent requires attribute of an instance
+func decodeRequires(abstractInstance any) (requires *provider.Requirements, err error) {
+	attributes, ok := abstractInstance.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	for key, value := range attributes {
+		if strings.EqualFold(key, "requires") {
+			requires = &provider.Requirements{}
+			if err := decode(value, requires); err != nil {
+				return nil, fmt.Errorf("invalid requires: %w", err)
+			}
+		}
+	}
+
+	return requires, nil
+}
+
+// decodeCacheTTL extracts the provider-independent cacheTTL attribute of an instance
+func decodeCacheTTL(abstractInstance any) (ttl time.Duration, err error) {
+	attributes, ok := abstractInstance.(map[string]any)
+	if !ok {
+		return 0, nil
+	}
+
+	for key, value := range attributes {
+		if strings.EqualFold(key, "cacheTTL") {
+			if err := decode(value, &ttl); err != nil {
+				return 0, fmt.Errorf("invalid cacheTTL: %w", err)
+			}
+		}
+	}
+
+	return ttl, nil
+}
+
+// decodeRetry extracts the provider-independent retry attribute of an instance
+func decodeRetry(abstractInstance any) (retry *provider.RetryPolicy, err error) {
+	attributes, ok := abstractInstance.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	for key, value := range attributes {
+		if strings.EqualFold(key, "retry") {
+			retry = &provider.RetryPolicy{}
+			if err := decode(value, retry); err != nil {
+				return nil, fmt.Errorf("invalid retry: %w", err)
+			}
+			retry.SetDefaults()
+		}
+	}
+
+	return retry, nil
+}
+
 func (c *abstractConfig) harden() *concreteConfig {
 	concrete := concreteConfig{}
 
@@ -138,7 +256,7 @@ func (c *abstractConfig) harden() *concreteConfig {
 		for i, abstractInstance := range abstractInstances {
 			instance := reflect.New(providerType)
 
-			if err := mapstructure.Decode(abstractInstance, instance.Interface()); err != nil {
+			if err := decode(abstractInstance, instance.Interface()); err != nil {
 				log.Warn("failed to decode instance", slog.Int("index", i), slog.Any("error", err))
 				continue
 			}
@@ -146,6 +264,42 @@ func (c *abstractConfig) harden() *concreteConfig {
 			concreteInstance := instance.Elem().Interface().(provider.Instance)
 			concreteInstance.SetDefaults()
 
+			retry, err := decodeRetry(abstractInstance)
+			if err != nil {
+				log.Warn("failed to decode instance", slog.Int("index", i), slog.Any("error", err))
+				continue
+			}
+			if retry != nil {
+				concreteInstance = &provider.Retrying{Instance: concreteInstance, Retry: *retry}
+			}
+
+			cacheTTL, err := decodeCacheTTL(abstractInstance)
+			if err != nil {
+				log.Warn("failed to decode instance", slog.Int("index", i), slog.Any("error", err))
+				continue
+			}
+			if cacheTTL > 0 {
+				concreteInstance = &provider.Cached{Instance: concreteInstance, TTL: cacheTTL}
+			}
+
+			requires, err := decodeRequires(abstractInstance)
+			if err != nil {
+				log.Warn("failed to decode instance", slog.Int("index", i), slog.Any("error", err))
+				continue
+			}
+			if requires != nil {
+				concreteInstance = &provider.Conditional{Instance: concreteInstance, Requires: *requires}
+			}
+
+			dependsOn, err := decodeDependsOn(abstractInstance)
+			if err != nil {
+				log.Warn("failed to decode instance", slog.Int("index", i), slog.Any("error", err))
+				continue
+			}
+			if len(dependsOn) > 0 {
+				concreteInstance = &provider.Dependent{Instance: concreteInstance, DependsOn: dependsOn}
+			}
+
 			concrete[typeName] = append(concrete[typeName], concreteInstance)
 		}
 	}
@@ -162,6 +316,9 @@ func (c *concreteConfig) totalInstances() (count int) {
 }
 
 func (c *concreteConfig) countByProvider() map[string]int {
+	mu.RLock()
+	defer mu.RUnlock()
+
 	counts := make(map[string]int)
 
 	for provider, instances := range *c {
@@ -2,9 +2,14 @@ package config
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/isometry/platform-health/pkg/provider"
 	"github.com/isometry/platform-health/pkg/provider/mock"
@@ -104,3 +109,169 @@ func TestHarden(t *testing.T) {
 		})
 	}
 }
+
+func TestHardenDependsOn(t *testing.T) {
+	abstract := abstractConfig{
+		"mock": []any{
+			map[string]any{"name": "db"},
+			map[string]any{"name": "app", "dependson": []any{"db"}},
+		},
+	}
+
+	expected := concreteConfig{
+		"mock": []provider.Instance{
+			&mock.Mock{Name: "db", Health: 1, Sleep: 1},
+			&provider.Dependent{
+				Instance:  &mock.Mock{Name: "app", Health: 1, Sleep: 1},
+				DependsOn: []string{"db"},
+			},
+		},
+	}
+
+	result := abstract.harden()
+	assert.Equal(t, &expected, result)
+}
+
+func TestHardenRequires(t *testing.T) {
+	abstract := abstractConfig{
+		"mock": []any{
+			map[string]any{"name": "always"},
+			map[string]any{"name": "gated", "requires": map[string]any{"env": []any{"FOO"}, "incluster": true}},
+			map[string]any{"name": "both", "requires": map[string]any{"files": []any{"/etc/foo"}}, "dependson": []any{"always"}},
+		},
+	}
+
+	expected := concreteConfig{
+		"mock": []provider.Instance{
+			&mock.Mock{Name: "always", Health: 1, Sleep: 1},
+			&provider.Conditional{
+				Instance: &mock.Mock{Name: "gated", Health: 1, Sleep: 1},
+				Requires: provider.Requirements{Env: []string{"FOO"}, InCluster: true},
+			},
+			&provider.Dependent{
+				Instance: &provider.Conditional{
+					Instance: &mock.Mock{Name: "both", Health: 1, Sleep: 1},
+					Requires: provider.Requirements{Files: []string{"/etc/foo"}},
+				},
+				DependsOn: []string{"always"},
+			},
+		},
+	}
+
+	result := abstract.harden()
+	assert.Equal(t, &expected, result)
+}
+
+func TestHardenRetry(t *testing.T) {
+	abstract := abstractConfig{
+		"mock": []any{
+			map[string]any{"name": "defaults", "retry": map[string]any{}},
+			map[string]any{"name": "custom", "sleep": "5ms", "retry": map[string]any{"attempts": 5, "delay": "100ms", "backoff": 1.5, "maxDelay": "1s"}},
+			map[string]any{"name": "gated", "retry": map[string]any{"attempts": 2}, "requires": map[string]any{"env": []any{"FOO"}}},
+		},
+	}
+
+	expected := concreteConfig{
+		"mock": []provider.Instance{
+			&provider.Retrying{
+				Instance: &mock.Mock{Name: "defaults", Health: 1, Sleep: 1},
+				Retry:    provider.RetryPolicy{Attempts: 3, Delay: time.Second, Backoff: 2},
+			},
+			&provider.Retrying{
+				Instance: &mock.Mock{Name: "custom", Health: 1, Sleep: 5 * time.Millisecond},
+				Retry:    provider.RetryPolicy{Attempts: 5, Delay: 100 * time.Millisecond, Backoff: 1.5, MaxDelay: time.Second},
+			},
+			&provider.Conditional{
+				Instance: &provider.Retrying{
+					Instance: &mock.Mock{Name: "gated", Health: 1, Sleep: 1},
+					Retry:    provider.RetryPolicy{Attempts: 2, Delay: time.Second, Backoff: 2},
+				},
+				Requires: provider.Requirements{Env: []string{"FOO"}},
+			},
+		},
+	}
+
+	result := abstract.harden()
+	assert.Equal(t, &expected, result)
+}
+
+func TestHardenCacheTTL(t *testing.T) {
+	abstract := abstractConfig{
+		"mock": []any{
+			map[string]any{"name": "uncached"},
+			map[string]any{"name": "cached", "cacheTTL": "30s"},
+			map[string]any{"name": "retried", "cacheTTL": "1m", "retry": map[string]any{"attempts": 2}},
+			map[string]any{"name": "invalid", "cacheTTL": "soon"},
+		},
+	}
+
+	expected := concreteConfig{
+		"mock": []provider.Instance{
+			&mock.Mock{Name: "uncached", Health: 1, Sleep: 1},
+			&provider.Cached{
+				Instance: &mock.Mock{Name: "cached", Health: 1, Sleep: 1},
+				TTL:      30 * time.Second,
+			},
+			&provider.Cached{
+				Instance: &provider.Retrying{
+					Instance: &mock.Mock{Name: "retried", Health: 1, Sleep: 1},
+					Retry:    provider.RetryPolicy{Attempts: 2, Delay: time.Second, Backoff: 2},
+				},
+				TTL: time.Minute,
+			},
+		},
+	}
+
+	result := abstract.harden()
+	assert.Equal(t, &expected, result)
+}
+
+func TestUpdateDependencyCycle(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	viper.Set("mock", []any{
+		map[string]any{"name": "a", "dependsOn": []any{"b"}},
+		map[string]any{"name": "b", "dependsOn": []any{"a"}},
+	})
+
+	conf := &concreteConfig{}
+	err := conf.update()
+	assert.EqualError(t, err, "dependency cycle between mock/a, mock/b")
+	assert.Empty(t, *conf)
+}
+
+func TestReload(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	path := filepath.Join(t.TempDir(), "platform-health.yaml")
+	viper.SetConfigFile(path)
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	names := func(instances []provider.Instance) (names []string) {
+		for _, instance := range instances {
+			names = append(names, instance.GetName())
+		}
+		return names
+	}
+
+	conf := &concreteConfig{}
+	write("mock:\n  - name: a\n")
+	require.NoError(t, conf.Reload())
+	before := conf.GetInstances()
+	assert.Equal(t, []string{"a"}, names(before))
+
+	// Invalid configurations keep the current instances
+	write("mock:\n  - name: b\n    dependsOn: [c]\n  - name: c\n    dependsOn: [b]\n")
+	assert.EqualError(t, conf.Reload(), "dependency cycle between mock/b, mock/c")
+	assert.Equal(t, []string{"a"}, names(conf.GetInstances()))
+
+	write("mock:\n  - name: b\n  - name: c\n")
+	require.NoError(t, conf.Reload())
+	assert.Equal(t, []string{"b", "c"}, names(conf.GetInstances()))
+
+	// Instances retrieved before the reload are unaffected
+	assert.Equal(t, []string{"a"}, names(before))
+}
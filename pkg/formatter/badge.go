@@ -0,0 +1,60 @@
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const (
+	FormatBadge = "badge"
+
+	BadgeSchemaVersion = 1
+	BadgeLabel         = "health"
+)
+
+// Badge formats the overall status as a shields.io endpoint badge, for
+// embedding a live status badge in wikis and READMEs.
+type Badge struct {
+	// Label is the text of the left side of the badge, defaulting to BadgeLabel
+	Label string
+}
+
+type badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// badgeStatus maps each status to its badge message and color
+var badgeStatus = map[ph.Status]struct{ message, color string }{
+	ph.Status_UNKNOWN:       {"unknown", "lightgrey"},
+	ph.Status_HEALTHY:       {"healthy", "green"},
+	ph.Status_UNHEALTHY:     {"unhealthy", "red"},
+	ph.Status_LOOP_DETECTED: {"loop detected", "orange"},
+}
+
+func init() {
+	Register(FormatBadge, Badge{})
+}
+
+func (b Badge) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	label := b.Label
+	if label == "" {
+		label = BadgeLabel
+	}
+
+	appearance, ok := badgeStatus[status.GetStatus()]
+	if !ok {
+		appearance = badgeStatus[ph.Status_UNKNOWN]
+	}
+
+	return json.NewEncoder(w).Encode(badge{
+		SchemaVersion: BadgeSchemaVersion,
+		Label:         label,
+		Message:       appearance.message,
+		Color:         appearance.color,
+	})
+}
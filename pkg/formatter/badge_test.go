@@ -0,0 +1,56 @@
+package formatter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+func TestBadge(t *testing.T) {
+	tests := []struct {
+		name     string
+		badge    formatter.Badge
+		status   ph.Status
+		expected string
+	}{
+		{
+			name:     "Healthy",
+			status:   ph.Status_HEALTHY,
+			expected: `{"schemaVersion":1,"label":"health","message":"healthy","color":"green"}`,
+		},
+		{
+			name:     "Unhealthy",
+			status:   ph.Status_UNHEALTHY,
+			expected: `{"schemaVersion":1,"label":"health","message":"unhealthy","color":"red"}`,
+		},
+		{
+			name:     "Unknown",
+			status:   ph.Status_UNKNOWN,
+			expected: `{"schemaVersion":1,"label":"health","message":"unknown","color":"lightgrey"}`,
+		},
+		{
+			name:     "Loop detected",
+			status:   ph.Status_LOOP_DETECTED,
+			expected: `{"schemaVersion":1,"label":"health","message":"loop detected","color":"orange"}`,
+		},
+		{
+			name:     "Custom label",
+			badge:    formatter.Badge{Label: "production"},
+			status:   ph.Status_HEALTHY,
+			expected: `{"schemaVersion":1,"label":"production","message":"healthy","color":"green"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tt.badge.Format(&buf, &ph.HealthCheckResponse{Status: tt.status}))
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const (
+	FormatCloudEvents = "cloudevents"
+
+	CloudEventsSpecVersion = "1.0"
+	CloudEventsType        = "com.platform-health.result"
+	CloudEventsSource      = "/platform-health"
+)
+
+// CloudEvents formats the component tree as a CloudEvents 1.0 JSON batch,
+// with one event per component. Each event's data is the component's own
+// result; its child components are reported as events of their own.
+type CloudEvents struct {
+	// Source is the event source; defaults to CloudEventsSource
+	Source string
+}
+
+// cloudEvent is a CloudEvents 1.0 event in structured JSON mode
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+func init() {
+	Register(FormatCloudEvents, CloudEvents{})
+}
+
+func (c CloudEvents) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	source := c.Source
+	if source == "" {
+		source = CloudEventsSource
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+
+	events := []cloudEvent{}
+	var walk func(subject string, component *ph.HealthCheckResponse) error
+	walk = func(subject string, component *ph.HealthCheckResponse) error {
+		result := proto.Clone(component).(*ph.HealthCheckResponse)
+		result.Components = nil
+		data, err := protojson.Marshal(result)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, cloudEvent{
+			SpecVersion:     CloudEventsSpecVersion,
+			ID:              uuid.NewString(),
+			Source:          source,
+			Type:            CloudEventsType,
+			Subject:         subject,
+			Time:            now,
+			DataContentType: "application/json",
+			Data:            data,
+		})
+
+		for _, child := range component.GetComponents() {
+			if err := walk(path.Join(subject, displayName(child)), child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk("", status); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	return encoder.Encode(events)
+}
@@ -0,0 +1,94 @@
+package formatter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+func TestCloudEvents(t *testing.T) {
+	status := &ph.HealthCheckResponse{
+		Status: ph.Status_UNHEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+			{
+				Type:   "satellite",
+				Name:   "remote",
+				Status: ph.Status_UNHEALTHY,
+				Components: []*ph.HealthCheckResponse{
+					{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: "status code 503"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.CloudEvents{Source: "/test"}.Format(&buf, status))
+
+	var events []map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &events))
+	require.Len(t, events, 4)
+
+	expected := []struct {
+		subject string
+		result  *ph.HealthCheckResponse
+	}{
+		{subject: "", result: &ph.HealthCheckResponse{Status: ph.Status_UNHEALTHY}},
+		{subject: "tcp/database", result: &ph.HealthCheckResponse{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY}},
+		{subject: "satellite/remote", result: &ph.HealthCheckResponse{Type: "satellite", Name: "remote", Status: ph.Status_UNHEALTHY}},
+		{subject: "satellite/remote/http/api", result: &ph.HealthCheckResponse{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: "status code 503"}},
+	}
+
+	ids := map[string]bool{}
+	for n, event := range events {
+		attribute := func(name string) string {
+			var value string
+			require.NoError(t, json.Unmarshal(event[name], &value), name)
+			return value
+		}
+
+		for _, required := range []string{"id", "source", "type", "specversion"} {
+			require.Contains(t, event, required)
+		}
+		assert.Equal(t, formatter.CloudEventsSpecVersion, attribute("specversion"))
+		assert.Equal(t, formatter.CloudEventsType, attribute("type"))
+		assert.Equal(t, "/test", attribute("source"))
+		assert.Equal(t, "application/json", attribute("datacontenttype"))
+		assert.NotEmpty(t, attribute("time"))
+
+		id := attribute("id")
+		assert.NotEmpty(t, id)
+		assert.False(t, ids[id], "duplicate id %s", id)
+		ids[id] = true
+
+		if expected[n].subject == "" {
+			assert.NotContains(t, event, "subject")
+		} else {
+			assert.Equal(t, expected[n].subject, attribute("subject"))
+		}
+
+		actual := &ph.HealthCheckResponse{}
+		require.NoError(t, protojson.Unmarshal(event["data"], actual))
+		assert.True(t, proto.Equal(expected[n].result, actual), "event %d data: %v", n, actual)
+	}
+}
+
+func TestCloudEventsDefaultSource(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, formatter.CloudEvents{}.Format(&buf, &ph.HealthCheckResponse{Status: ph.Status_HEALTHY}))
+
+	var events []struct {
+		Source string `json:"source"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &events))
+	require.Len(t, events, 1)
+	assert.Equal(t, formatter.CloudEventsSource, events[0].Source)
+}
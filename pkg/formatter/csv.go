@@ -0,0 +1,65 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const FormatCSV = "csv"
+
+var csvHeader = []string{"path", "name", "type", "status", "duration_seconds", "message", "warnings"}
+
+// CSV formats the response as comma-separated values, with one row per leaf
+// component of the tree.
+type CSV struct{}
+
+func init() {
+	Register(FormatCSV, CSV{})
+}
+
+func (CSV) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	var walk func(parent string, component *ph.HealthCheckResponse) error
+	walk = func(parent string, component *ph.HealthCheckResponse) error {
+		if len(component.GetComponents()) > 0 {
+			for _, child := range component.GetComponents() {
+				if err := walk(path.Join(parent, displayName(child)), child); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		duration := ""
+		if component.GetDuration() != nil {
+			duration = strconv.FormatFloat(component.GetDuration().AsDuration().Seconds(), 'f', -1, 64)
+		}
+
+		return writer.Write([]string{
+			parent,
+			component.GetName(),
+			component.GetType(),
+			component.GetStatus().String(),
+			duration,
+			component.GetMessage(),
+			strings.Join(component.GetWarnings(), "; "),
+		})
+	}
+	if len(status.GetComponents()) > 0 {
+		if err := walk("", status); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,78 @@
+package formatter_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+func TestCSV(t *testing.T) {
+	status := &ph.HealthCheckResponse{
+		Status: ph.Status_UNHEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY, Duration: durationpb.New(1500 * time.Microsecond), Warnings: []string{"single replica", "deprecated"}},
+			{
+				Type:   "satellite",
+				Name:   "remote",
+				Status: ph.Status_UNHEALTHY,
+				Components: []*ph.HealthCheckResponse{
+					{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: `status code 503, "Service Unavailable"`},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		status   *ph.HealthCheckResponse
+		expected [][]string
+	}{
+		{
+			name:   "Nested tree",
+			status: status,
+			expected: [][]string{
+				{"path", "name", "type", "status", "duration_seconds", "message", "warnings"},
+				{"tcp/database", "database", "tcp", "HEALTHY", "0.0015", "", "single replica; deprecated"},
+				{"satellite/remote/http/api", "api", "http", "UNHEALTHY", "", `status code 503, "Service Unavailable"`, ""},
+			},
+		},
+		{
+			name: "Flattened tree",
+			status: &ph.HealthCheckResponse{
+				Status:     ph.Status_UNHEALTHY,
+				Components: status.Flatten(""),
+			},
+			expected: [][]string{
+				{"path", "name", "type", "status", "duration_seconds", "message", "warnings"},
+				{"tcp/database", "tcp/database", "", "HEALTHY", "0.0015", "", "single replica; deprecated"},
+				{"remote/http/api", "remote/http/api", "", "UNHEALTHY", "", `status code 503, "Service Unavailable"`, ""},
+			},
+		},
+		{
+			name:   "No components",
+			status: &ph.HealthCheckResponse{Status: ph.Status_HEALTHY},
+			expected: [][]string{
+				{"path", "name", "type", "status", "duration_seconds", "message", "warnings"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, formatter.CSV{}.Format(&buf, tt.status))
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, records)
+		})
+	}
+}
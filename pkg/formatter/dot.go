@@ -0,0 +1,139 @@
+package formatter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+const FormatDOT = "dot"
+
+// DOT formats the component tree as a Graphviz digraph, with nodes colored by
+// status, and dashed edges from components to the siblings they depend on.
+type DOT struct{}
+
+var statusColors = map[ph.Status]string{
+	ph.Status_UNKNOWN:       "gray",
+	ph.Status_HEALTHY:       "palegreen",
+	ph.Status_UNHEALTHY:     "lightcoral",
+	ph.Status_LOOP_DETECTED: "orange",
+}
+
+func init() {
+	Register(FormatDOT, DOT{})
+}
+
+func (DOT) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	buf := bufio.NewWriter(w)
+
+	fmt.Fprintln(buf, "digraph platform_health {")
+	fmt.Fprintln(buf, "  node [shape=box, style=filled];")
+
+	id := 0
+	var walk func(component *ph.HealthCheckResponse) string
+	walk = func(component *ph.HealthCheckResponse) string {
+		node := fmt.Sprintf("n%d", id)
+		id++
+
+		fmt.Fprintf(buf, "  %s [label=%s, fillcolor=%s];\n", node, strconv.Quote(nodeLabel(component)), statusColor(component.GetStatus()))
+		children := make([]string, len(component.GetComponents()))
+		byName := make(map[string][]string)
+		for n, child := range component.GetComponents() {
+			children[n] = walk(child)
+			byName[child.GetName()] = append(byName[child.GetName()], children[n])
+			fmt.Fprintf(buf, "  %s -> %s;\n", node, children[n])
+		}
+		for n, child := range component.GetComponents() {
+			for _, dependency := range Dependencies(child) {
+				for _, target := range byName[dependency] {
+					fmt.Fprintf(buf, "  %s -> %s [style=dashed];\n", children[n], target)
+				}
+			}
+		}
+		return node
+	}
+	walk(status)
+
+	fmt.Fprintln(buf, "}")
+
+	return buf.Flush()
+}
+
+// maxMessageLabel is the maximum length, in characters, of a message included in a node label
+const maxMessageLabel = 40
+
+// nodeLabel returns the label of a node: the display label of the component,
+// followed on a second line by the (truncated) message of a failed leaf.
+func nodeLabel(component *ph.HealthCheckResponse) string {
+	name := label(component)
+	if len(component.GetComponents()) > 0 || component.GetStatus() == ph.Status_HEALTHY {
+		return name
+	}
+
+	message := []rune(strings.Join(strings.Fields(component.GetMessage()), " "))
+	if len(message) == 0 {
+		return name
+	}
+	if len(message) > maxMessageLabel {
+		message = append(message[:maxMessageLabel-1], '…')
+	}
+	return name + "\n" + string(message)
+}
+
+// label returns the display label of a component: its type and name where
+// set, annotated if the component is flapping.
+func label(component *ph.HealthCheckResponse) string {
+	name := displayName(component)
+	if flapping := Flapping(component); flapping != nil {
+		name = fmt.Sprintf("%s (flapping: %d transitions)", name, flapping.GetTransitions())
+	}
+	return name
+}
+
+func displayName(component *ph.HealthCheckResponse) string {
+	switch {
+	case component.GetType() != "" && component.GetName() != "":
+		return fmt.Sprintf("%s/%s", component.GetType(), component.GetName())
+	case component.GetName() != "":
+		return component.GetName()
+	case component.GetType() != "":
+		return component.GetType()
+	default:
+		return "platform-health"
+	}
+}
+
+// statusColor returns the Graphviz color representing status.
+func statusColor(status ph.Status) string {
+	if color, ok := statusColors[status]; ok {
+		return color
+	}
+	return statusColors[ph.Status_UNKNOWN]
+}
+
+// Flapping returns the flapping annotation of a component, or nil if the component is not flapping.
+func Flapping(component *ph.HealthCheckResponse) *details.Detail_Flapping {
+	for _, detail := range component.GetDetails() {
+		flapping := &details.Detail_Flapping{}
+		if detail.MessageIs(flapping) && detail.UnmarshalTo(flapping) == nil {
+			return flapping
+		}
+	}
+	return nil
+}
+
+// Dependencies returns the names of the sibling components a component depends on.
+func Dependencies(component *ph.HealthCheckResponse) []string {
+	for _, detail := range component.GetDetails() {
+		dependency := &details.Detail_Dependency{}
+		if detail.MessageIs(dependency) && detail.UnmarshalTo(dependency) == nil {
+			return dependency.GetDependsOn()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package formatter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+func TestDOT(t *testing.T) {
+	status := &ph.HealthCheckResponse{
+		Status: ph.Status_UNHEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+			{
+				Type:   "satellite",
+				Name:   "remote",
+				Status: ph.Status_UNHEALTHY,
+				Components: []*ph.HealthCheckResponse{
+					{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: "timeout"},
+					{Type: "satellite", Name: "loop", Status: ph.Status_LOOP_DETECTED},
+				},
+			},
+			{Type: "http", Name: "app", Status: ph.Status_UNKNOWN},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.DOT{}.Format(&buf, status))
+
+	expected := `digraph platform_health {
+  node [shape=box, style=filled];
+  n0 [label="platform-health", fillcolor=lightcoral];
+  n1 [label="tcp/database", fillcolor=palegreen];
+  n0 -> n1;
+  n2 [label="satellite/remote", fillcolor=lightcoral];
+  n3 [label="http/api\ntimeout", fillcolor=lightcoral];
+  n2 -> n3;
+  n4 [label="satellite/loop", fillcolor=orange];
+  n2 -> n4;
+  n0 -> n2;
+  n5 [label="http/app", fillcolor=gray];
+  n0 -> n5;
+}
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestDOTQuotesLabels(t *testing.T) {
+	status := &ph.HealthCheckResponse{
+		Status: ph.Status_HEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "kubernetes", Name: `deployment/"quoted"`, Status: ph.Status_HEALTHY},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.DOT{}.Format(&buf, status))
+	assert.Contains(t, buf.String(), `n1 [label="kubernetes/deployment/\"quoted\"", fillcolor=palegreen];`)
+}
+
+func TestDOTFailedLeafMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		component *ph.HealthCheckResponse
+		expected  string
+	}{
+		{
+			name:      "Healthy leaf message omitted",
+			component: &ph.HealthCheckResponse{Type: "tcp", Name: "db", Status: ph.Status_HEALTHY, Message: "ok"},
+			expected:  `n1 [label="tcp/db", fillcolor=palegreen];`,
+		},
+		{
+			name:      "Failed leaf without message",
+			component: &ph.HealthCheckResponse{Type: "tcp", Name: "db", Status: ph.Status_UNHEALTHY},
+			expected:  `n1 [label="tcp/db", fillcolor=lightcoral];`,
+		},
+		{
+			name:      "Failed leaf message escaped",
+			component: &ph.HealthCheckResponse{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: "unexpected \"body\"\nfound"},
+			expected:  `n1 [label="http/api\nunexpected \"body\" found", fillcolor=lightcoral];`,
+		},
+		{
+			name:      "Failed leaf message truncated",
+			component: &ph.HealthCheckResponse{Type: "tls", Name: "edge", Status: ph.Status_UNHEALTHY, Message: "x509: certificate signed by unknown authority (possibly because of an expired root)"},
+			expected:  `n1 [label="tls/edge\nx509: certificate signed by unknown aut…", fillcolor=lightcoral];`,
+		},
+		{
+			name: "Failed system message omitted",
+			component: &ph.HealthCheckResponse{
+				Type: "satellite", Name: "remote", Status: ph.Status_UNHEALTHY, Message: "degraded",
+				Components: []*ph.HealthCheckResponse{{Type: "tcp", Name: "db", Status: ph.Status_UNHEALTHY}},
+			},
+			expected: `n1 [label="satellite/remote", fillcolor=lightcoral];`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &ph.HealthCheckResponse{
+				Status:     tt.component.GetStatus(),
+				Components: []*ph.HealthCheckResponse{tt.component},
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, formatter.DOT{}.Format(&buf, status))
+			assert.Contains(t, buf.String(), tt.expected)
+		})
+	}
+}
+
+func TestDOTFlapping(t *testing.T) {
+	flapping, err := anypb.New(&details.Detail_Flapping{Transitions: 5, Window: durationpb.New(time.Minute)})
+	require.NoError(t, err)
+
+	status := &ph.HealthCheckResponse{
+		Status: ph.Status_UNHEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "http", Name: "app", Status: ph.Status_UNHEALTHY, Details: []*anypb.Any{flapping}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.DOT{}.Format(&buf, status))
+	assert.Contains(t, buf.String(), `n1 [label="http/app (flapping: 5 transitions)", fillcolor=lightcoral];`)
+}
+
+func TestDOTDependencies(t *testing.T) {
+	dependsOn := func(names ...string) []*anypb.Any {
+		detail, err := anypb.New(&details.Detail_Dependency{DependsOn: names})
+		require.NoError(t, err)
+		return []*anypb.Any{detail}
+	}
+
+	status := &ph.HealthCheckResponse{
+		Status: ph.Status_HEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+			{Type: "http", Name: "api", Status: ph.Status_HEALTHY, Details: dependsOn("database")},
+			{Type: "http", Name: "app", Status: ph.Status_HEALTHY, Details: dependsOn("api", "database")},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.DOT{}.Format(&buf, status))
+
+	expected := `digraph platform_health {
+  node [shape=box, style=filled];
+  n0 [label="platform-health", fillcolor=palegreen];
+  n1 [label="tcp/database", fillcolor=palegreen];
+  n0 -> n1;
+  n2 [label="http/api", fillcolor=palegreen];
+  n0 -> n2;
+  n3 [label="http/app", fillcolor=palegreen];
+  n0 -> n3;
+  n2 -> n1 [style=dashed];
+  n3 -> n2 [style=dashed];
+  n3 -> n1 [style=dashed];
+}
+`
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,62 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+// Formatter is the interface that must be implemented by all output formats.
+type Formatter interface {
+	// Format writes the health check response to w
+	Format(w io.Writer, status *ph.HealthCheckResponse) error
+}
+
+type FormatterRegistry map[string]Formatter
+
+// Formatters is a map of output format names to their formatters.
+var (
+	Formatters = FormatterRegistry{}
+	mu         sync.RWMutex
+)
+
+// Register adds a formatter to the registry.
+func Register(name string, formatter Formatter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	Formatters[name] = formatter
+}
+
+// Get returns the named formatter.
+func Get(name string) (Formatter, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	formatter, ok := Formatters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (available: %s)", name, strings.Join(formatterList(), ", "))
+	}
+	return formatter, nil
+}
+
+// FormatterList returns a sorted list of registered formatters.
+func FormatterList() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return formatterList()
+}
+
+func formatterList() []string {
+	formatters := make([]string, 0, len(Formatters))
+	for formatter := range Formatters {
+		formatters = append(formatters, formatter)
+	}
+	slices.Sort(formatters)
+	return formatters
+}
@@ -0,0 +1,95 @@
+package formatter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+)
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		expected formatter.Formatter
+		wantErr  bool
+	}{
+		{
+			name:     "JSON",
+			format:   formatter.FormatJSON,
+			expected: formatter.JSON{},
+		},
+		{
+			name:     "DOT",
+			format:   formatter.FormatDOT,
+			expected: formatter.DOT{},
+		},
+		{
+			name:     "CloudEvents",
+			format:   formatter.FormatCloudEvents,
+			expected: formatter.CloudEvents{},
+		},
+		{
+			name:     "OneLine",
+			format:   formatter.FormatOneLine,
+			expected: formatter.OneLine{Colorize: true},
+		},
+		{
+			name:     "CSV",
+			format:   formatter.FormatCSV,
+			expected: formatter.CSV{},
+		},
+		{
+			name:     "HTML",
+			format:   formatter.FormatHTML,
+			expected: formatter.HTML{},
+		},
+		{
+			name:     "SARIF",
+			format:   formatter.FormatSARIF,
+			expected: formatter.SARIF{},
+		},
+		{
+			name:     "Nagios",
+			format:   formatter.FormatNagios,
+			expected: formatter.Nagios{},
+		},
+		{
+			name:     "Badge",
+			format:   formatter.FormatBadge,
+			expected: formatter.Badge{},
+		},
+		{
+			name:    "Unknown",
+			format:  "unknown",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := formatter.Get(tt.format)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, f)
+		})
+	}
+}
+
+func TestFormatterList(t *testing.T) {
+	formats := formatter.FormatterList()
+	assert.Contains(t, formats, formatter.FormatJSON)
+	assert.Contains(t, formats, formatter.FormatDOT)
+	assert.Contains(t, formats, formatter.FormatCloudEvents)
+	assert.Contains(t, formats, formatter.FormatOneLine)
+	assert.Contains(t, formats, formatter.FormatCSV)
+	assert.Contains(t, formats, formatter.FormatHTML)
+	assert.Contains(t, formats, formatter.FormatSARIF)
+	assert.Contains(t, formats, formatter.FormatNagios)
+	assert.Contains(t, formats, formatter.FormatBadge)
+	assert.IsNonDecreasing(t, formats)
+}
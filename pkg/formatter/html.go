@@ -0,0 +1,111 @@
+package formatter
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const FormatHTML = "html"
+
+// HTML formats the component tree as a standalone HTML document, with each
+// component a collapsible section colored by status, listing its message
+// and any warnings. Components that are not
+// healthy are expanded by default.
+type HTML struct{}
+
+// htmlDetail is a decoded component detail
+type htmlDetail struct {
+	Name string
+	Body string
+}
+
+const htmlDocument = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Platform Health: {{ .Status.GetStatus }}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; }
+details { margin: 0.25em 0 0.25em 1.5em; border-left: 4px solid {{ color 0 }}; padding-left: 0.5em; }
+summary { cursor: pointer; padding: 0.25em; }
+.status { font-weight: bold; }
+.duration { color: #666; font-size: 0.9em; }
+.message { margin: 0.25em 0 0.25em 1.5em; font-family: monospace; }
+.warning { margin: 0.25em 0 0.25em 1.5em; font-family: monospace; color: #8a5a00; }
+.detail { margin: 0.25em 0 0.25em 1.5em; }
+.detail pre { background: #f6f6f6; padding: 0.5em; margin: 0.25em 0; }
+.unknown { border-left-color: {{ color 0 }}; }
+.unknown > summary { background: {{ color 0 }}; }
+.healthy { border-left-color: {{ color 1 }}; }
+.healthy > summary { background: {{ color 1 }}; }
+.unhealthy { border-left-color: {{ color 2 }}; }
+.unhealthy > summary { background: {{ color 2 }}; }
+.loop_detected { border-left-color: {{ color 3 }}; }
+.loop_detected > summary { background: {{ color 3 }}; }
+</style>
+</head>
+<body>
+{{ template "component" .Status }}
+</body>
+</html>
+{{ define "component" -}}
+<details class="{{ class . }}"{{ if open . }} open{{ end }}>
+<summary>{{ label . }} <span class="status">{{ .GetStatus }}</span>{{ with .GetDuration }} <span class="duration">{{ .AsDuration }}</span>{{ end }}</summary>
+{{ with .GetMessage }}<div class="message">{{ . }}</div>
+{{ end -}}
+{{ range .GetWarnings }}<div class="warning">⚠ {{ . }}</div>
+{{ end -}}
+{{ range details . }}<div class="detail"><strong>{{ .Name }}</strong><pre>{{ .Body }}</pre></div>
+{{ end -}}
+{{ range .GetComponents }}{{ template "component" . }}{{ end -}}
+</details>
+{{ end }}`
+
+var htmlTemplate = template.Must(template.New(FormatHTML).Funcs(template.FuncMap{
+	"class":   func(c *ph.HealthCheckResponse) string { return strings.ToLower(c.GetStatus().String()) },
+	"color":   func(status int32) string { return statusColor(ph.Status(status)) },
+	"details": htmlDetails,
+	"label":   label,
+	"open":    func(c *ph.HealthCheckResponse) bool { return c.GetStatus() != ph.Status_HEALTHY },
+}).Parse(htmlDocument))
+
+func init() {
+	Register(FormatHTML, HTML{})
+}
+
+func (HTML) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	return htmlTemplate.Execute(w, struct{ Status *ph.HealthCheckResponse }{status})
+}
+
+// htmlDetails decodes the details of a component into readable sections,
+// falling back to the type URL for unregistered detail types.
+func htmlDetails(component *ph.HealthCheckResponse) []htmlDetail {
+	var decoded []htmlDetail
+	for _, detail := range component.GetDetails() {
+		name := detailName(detail)
+		message, err := detail.UnmarshalNew()
+		if err != nil {
+			decoded = append(decoded, htmlDetail{Name: name, Body: detail.GetTypeUrl()})
+			continue
+		}
+
+		body, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(message)
+		if err != nil {
+			body = []byte(err.Error())
+		}
+		decoded = append(decoded, htmlDetail{Name: name, Body: string(body)})
+	}
+	return decoded
+}
+
+// detailName returns the short name of a detail type, e.g. "TLS" for Detail_TLS
+func detailName(detail *anypb.Any) string {
+	name := string(detail.MessageName().Name())
+	return strings.TrimPrefix(name, "Detail_")
+}
@@ -0,0 +1,128 @@
+package formatter_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+// element finds the first element satisfying match in a depth-first walk
+func element(node *html.Node, match func(*html.Node) bool) *html.Node {
+	if node.Type == html.ElementNode && match(node) {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := element(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func attr(node *html.Node, key string) (string, bool) {
+	for _, a := range node.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func count(node *html.Node, tag string) (n int) {
+	if node.Type == html.ElementNode && node.Data == tag {
+		n++
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		n += count(child, tag)
+	}
+	return n
+}
+
+func TestHTML(t *testing.T) {
+	dnsDetail, err := anypb.New(&details.Detail_DNS{Host: "www.example.com", Type: "A", Propagated: true})
+	require.NoError(t, err)
+
+	status := &ph.HealthCheckResponse{
+		Status:   ph.Status_UNHEALTHY,
+		Duration: durationpb.New(1500 * time.Millisecond),
+		Components: []*ph.HealthCheckResponse{
+			{Type: "dns", Name: "www", Status: ph.Status_HEALTHY, Details: []*anypb.Any{dnsDetail}, Warnings: []string{"single resolver"}},
+			{
+				Type:   "satellite",
+				Name:   "remote",
+				Status: ph.Status_UNHEALTHY,
+				Components: []*ph.HealthCheckResponse{
+					{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: "status code 503 <Service Unavailable>"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.HTML{}.Format(&buf, status))
+	output := buf.String()
+
+	assert.True(t, strings.HasPrefix(output, "<!DOCTYPE html>"))
+	assert.NotContains(t, output, "<Service Unavailable>", "messages must be escaped")
+	assert.NotContains(t, output, "<link", "CSS must be inlined")
+
+	document, err := html.Parse(strings.NewReader(output))
+	require.NoError(t, err)
+
+	require.NotNil(t, element(document, func(n *html.Node) bool { return n.Data == "style" }))
+	assert.Equal(t, 4, count(document, "details"))
+	assert.Equal(t, 4, count(document, "summary"))
+
+	tests := []struct {
+		name   string
+		label  string
+		class  string
+		open   bool
+		detail string
+	}{
+		{name: "Root", label: "platform-health", class: "unhealthy", open: true},
+		{name: "Healthy leaf", label: "dns/www", class: "healthy", open: false, detail: "DNS"},
+		{name: "Unhealthy system", label: "satellite/remote", class: "unhealthy", open: true},
+		{name: "Unhealthy leaf", label: "http/api", class: "unhealthy", open: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := element(document, func(n *html.Node) bool {
+				return n.Data == "summary" && n.FirstChild != nil && strings.TrimSpace(n.FirstChild.Data) == tt.label
+			})
+			require.NotNil(t, summary, "summary for %s", tt.label)
+
+			section := summary.Parent
+			class, _ := attr(section, "class")
+			assert.Equal(t, tt.class, class)
+			_, open := attr(section, "open")
+			assert.Equal(t, tt.open, open)
+
+			if tt.detail != "" {
+				detail := element(section, func(n *html.Node) bool {
+					class, _ := attr(n, "class")
+					return class == "detail"
+				})
+				require.NotNil(t, detail)
+				assert.Equal(t, tt.detail, detail.FirstChild.FirstChild.Data)
+			}
+		})
+	}
+
+	assert.Contains(t, output, "1.5s")
+	assert.Contains(t, output, "status code 503 &lt;Service Unavailable&gt;")
+	assert.Contains(t, output, "www.example.com")
+	assert.Contains(t, output, `<div class="warning">⚠ single resolver</div>`)
+}
@@ -0,0 +1,29 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const FormatJSON = "json"
+
+// JSON formats the response as protobuf JSON.
+type JSON struct{}
+
+func init() {
+	Register(FormatJSON, JSON{})
+}
+
+func (JSON) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	pjson, err := protojson.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(pjson))
+	return err
+}
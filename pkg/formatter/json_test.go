@@ -0,0 +1,30 @@
+package formatter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+func TestJSON(t *testing.T) {
+	status := &ph.HealthCheckResponse{
+		Status: ph.Status_HEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.JSON{}.Format(&buf, status))
+
+	actual := &ph.HealthCheckResponse{}
+	require.NoError(t, protojson.Unmarshal(buf.Bytes(), actual))
+	assert.True(t, proto.Equal(status, actual))
+}
@@ -0,0 +1,86 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const FormatNagios = "nagios"
+
+// Nagios plugin exit codes
+const (
+	NagiosOK       = 0
+	NagiosCritical = 2
+	NagiosUnknown  = 3
+)
+
+// ExitCoder is implemented by formatters that define the process exit code
+// for a response, overriding the default of 0 when healthy and 1 otherwise.
+type ExitCoder interface {
+	ExitCode(status *ph.HealthCheckResponse) int
+}
+
+// Nagios formats the response as Nagios/Icinga plugin output: a single
+// summary line with performance data, e.g.
+// "HEALTHY - 12 checks OK | duration=1.2s;;;0 healthy=12;;;0 unhealthy=0;;;0 unknown=0;;;0".
+type Nagios struct{}
+
+func init() {
+	Register(FormatNagios, Nagios{})
+}
+
+func (Nagios) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	healthy, unhealthy, unknown := countLeaves(status)
+	total := healthy + unhealthy + unknown
+
+	summary := fmt.Sprintf("%d checks OK", total)
+	if status.GetStatus() != ph.Status_HEALTHY {
+		summary = fmt.Sprintf("%d of %d checks not OK", total-healthy, total)
+		if failing := failingPaths(status); len(failing) > 0 {
+			summary = fmt.Sprintf("%s: %s", summary, strings.Join(failing, ", "))
+		}
+	}
+
+	duration := strconv.FormatFloat(status.GetDuration().AsDuration().Seconds(), 'f', -1, 64)
+
+	_, err := fmt.Fprintf(w, "%s - %s | duration=%ss;;;0 healthy=%d;;;0 unhealthy=%d;;;0 unknown=%d;;;0\n",
+		status.GetStatus(), summary, duration, healthy, unhealthy, unknown)
+	return err
+}
+
+// ExitCode maps the worst status to a Nagios plugin exit code.
+func (Nagios) ExitCode(status *ph.HealthCheckResponse) int {
+	switch status.GetStatus() {
+	case ph.Status_HEALTHY:
+		return NagiosOK
+	case ph.Status_UNHEALTHY:
+		return NagiosCritical
+	default:
+		return NagiosUnknown
+	}
+}
+
+// failingPaths returns the paths of the leaf components that are not healthy.
+func failingPaths(status *ph.HealthCheckResponse) (paths []string) {
+	var walk func(parent string, component *ph.HealthCheckResponse)
+	walk = func(parent string, component *ph.HealthCheckResponse) {
+		if len(component.GetComponents()) == 0 {
+			if component.GetStatus() != ph.Status_HEALTHY {
+				paths = append(paths, parent)
+			}
+			return
+		}
+		for _, child := range component.GetComponents() {
+			walk(path.Join(parent, displayName(child)), child)
+		}
+	}
+	for _, child := range status.GetComponents() {
+		walk(displayName(child), child)
+	}
+	return paths
+}
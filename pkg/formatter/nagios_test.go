@@ -0,0 +1,89 @@
+package formatter_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+func TestNagios(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   *ph.HealthCheckResponse
+		expected string
+		exitCode int
+	}{
+		{
+			name: "Healthy",
+			status: &ph.HealthCheckResponse{
+				Status:   ph.Status_HEALTHY,
+				Duration: durationpb.New(1200 * time.Millisecond),
+				Components: []*ph.HealthCheckResponse{
+					{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+					{Type: "http", Name: "app", Status: ph.Status_HEALTHY},
+				},
+			},
+			expected: "HEALTHY - 2 checks OK | duration=1.2s;;;0 healthy=2;;;0 unhealthy=0;;;0 unknown=0;;;0\n",
+			exitCode: formatter.NagiosOK,
+		},
+		{
+			name: "Unhealthy",
+			status: &ph.HealthCheckResponse{
+				Status:   ph.Status_UNHEALTHY,
+				Duration: durationpb.New(500 * time.Millisecond),
+				Components: []*ph.HealthCheckResponse{
+					{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+					{
+						Type:   "satellite",
+						Name:   "remote",
+						Status: ph.Status_UNHEALTHY,
+						Components: []*ph.HealthCheckResponse{
+							{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY},
+							{Type: "dns", Name: "apex", Status: ph.Status_UNKNOWN},
+						},
+					},
+				},
+			},
+			expected: "UNHEALTHY - 2 of 3 checks not OK: satellite/remote/http/api, satellite/remote/dns/apex | duration=0.5s;;;0 healthy=1;;;0 unhealthy=1;;;0 unknown=1;;;0\n",
+			exitCode: formatter.NagiosCritical,
+		},
+		{
+			name: "Unknown",
+			status: &ph.HealthCheckResponse{
+				Status:     ph.Status_UNKNOWN,
+				Components: []*ph.HealthCheckResponse{{Type: "tcp", Name: "database", Status: ph.Status_UNKNOWN}},
+			},
+			expected: "UNKNOWN - 1 of 1 checks not OK: tcp/database | duration=0s;;;0 healthy=0;;;0 unhealthy=0;;;0 unknown=1;;;0\n",
+			exitCode: formatter.NagiosUnknown,
+		},
+		{
+			name: "Loop detected",
+			status: &ph.HealthCheckResponse{
+				Status:     ph.Status_LOOP_DETECTED,
+				Components: []*ph.HealthCheckResponse{{Type: "satellite", Name: "self", Status: ph.Status_LOOP_DETECTED}},
+			},
+			expected: "LOOP_DETECTED - 1 of 1 checks not OK: satellite/self | duration=0s;;;0 healthy=0;;;0 unhealthy=1;;;0 unknown=0;;;0\n",
+			exitCode: formatter.NagiosUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, formatter.Nagios{}.Format(&buf, tt.status))
+			assert.Equal(t, tt.expected, buf.String())
+
+			var f formatter.Formatter = formatter.Nagios{}
+			coder, ok := f.(formatter.ExitCoder)
+			require.True(t, ok)
+			assert.Equal(t, tt.exitCode, coder.ExitCode(tt.status))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const FormatOneLine = "oneline"
+
+const (
+	glyphHealthy   = "✔"
+	glyphUnhealthy = "✖"
+	glyphUnknown   = "?"
+
+	ansiReset = "\033[0m"
+	ansiBold  = "\033[1m"
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiGray  = "\033[90m"
+)
+
+// OneLine formats the response as a terse single-line summary of the number
+// of healthy, unhealthy and unknown components, prefixed with a glyph for
+// the overall status, suitable for shell prompts and status bars.
+type OneLine struct {
+	// Colorize enables ANSI colors, unless NO_COLOR is set in the environment
+	Colorize bool
+}
+
+func init() {
+	Register(FormatOneLine, OneLine{Colorize: true})
+}
+
+func (o OneLine) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	healthy, unhealthy, unknown := countLeaves(status)
+
+	colorize := o.Colorize && os.Getenv("NO_COLOR") == ""
+	paint := func(color, text string) string {
+		if !colorize {
+			return text
+		}
+		return color + text + ansiReset
+	}
+
+	glyph, color := glyphUnknown, ansiGray
+	switch status.GetStatus() {
+	case ph.Status_HEALTHY:
+		glyph, color = glyphHealthy, ansiGreen
+	case ph.Status_UNHEALTHY, ph.Status_LOOP_DETECTED:
+		glyph, color = glyphUnhealthy, ansiRed
+	}
+
+	_, err := fmt.Fprintf(w, "%s  %s  %s  %s\n",
+		paint(ansiBold+color, glyph),
+		paint(ansiGreen, fmt.Sprintf("%s %d", glyphHealthy, healthy)),
+		paint(ansiRed, fmt.Sprintf("%s %d", glyphUnhealthy, unhealthy)),
+		paint(ansiGray, fmt.Sprintf("%s %d", glyphUnknown, unknown)),
+	)
+	return err
+}
+
+// countLeaves counts the leaf components of the tree by status, with loops
+// counted as unhealthy.
+func countLeaves(component *ph.HealthCheckResponse) (healthy, unhealthy, unknown int) {
+	if len(component.GetComponents()) == 0 {
+		switch component.GetStatus() {
+		case ph.Status_HEALTHY:
+			return 1, 0, 0
+		case ph.Status_UNHEALTHY, ph.Status_LOOP_DETECTED:
+			return 0, 1, 0
+		default:
+			return 0, 0, 1
+		}
+	}
+
+	for _, child := range component.GetComponents() {
+		h, u, k := countLeaves(child)
+		healthy, unhealthy, unknown = healthy+h, unhealthy+u, unknown+k
+	}
+	return healthy, unhealthy, unknown
+}
@@ -0,0 +1,83 @@
+package formatter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+func TestOneLine(t *testing.T) {
+	mixed := &ph.HealthCheckResponse{
+		Status: ph.Status_UNHEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+			{Type: "http", Name: "frontend", Status: ph.Status_HEALTHY},
+			{Type: "dns", Name: "apex", Status: ph.Status_UNKNOWN},
+			{
+				Type:   "satellite",
+				Name:   "remote",
+				Status: ph.Status_UNHEALTHY,
+				Components: []*ph.HealthCheckResponse{
+					{Type: "grpc", Name: "api", Status: ph.Status_HEALTHY},
+					{Type: "tls", Name: "edge", Status: ph.Status_UNHEALTHY},
+					{Type: "satellite", Name: "self", Status: ph.Status_LOOP_DETECTED},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		status   *ph.HealthCheckResponse
+		colorize bool
+		noColor  string
+		expected string
+	}{
+		{
+			name:     "Mixed tree",
+			status:   mixed,
+			expected: "✖  ✔ 3  ✖ 2  ? 1\n",
+		},
+		{
+			name: "Healthy tree",
+			status: &ph.HealthCheckResponse{
+				Status:     ph.Status_HEALTHY,
+				Components: []*ph.HealthCheckResponse{{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY}},
+			},
+			expected: "✔  ✔ 1  ✖ 0  ? 0\n",
+		},
+		{
+			name:     "Unknown without components",
+			status:   &ph.HealthCheckResponse{},
+			expected: "?  ✔ 0  ✖ 0  ? 1\n",
+		},
+		{
+			name:     "Colorized",
+			status:   mixed,
+			colorize: true,
+			expected: "\033[1m\033[31m✖\033[0m  \033[32m✔ 3\033[0m  \033[31m✖ 2\033[0m  \033[90m? 1\033[0m\n",
+		},
+		{
+			name:     "Colorized with NO_COLOR",
+			status:   mixed,
+			colorize: true,
+			noColor:  "1",
+			expected: "✖  ✔ 3  ✖ 2  ? 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			var buf bytes.Buffer
+			require.NoError(t, formatter.OneLine{Colorize: tt.colorize}.Format(&buf, tt.status))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
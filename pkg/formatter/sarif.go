@@ -0,0 +1,167 @@
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+	"slices"
+	"time"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+const (
+	FormatSARIF = "sarif"
+
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIF formats the response as a SARIF 2.1.0 log, with each unhealthy leaf
+// component reported as an error result of the rule named for its type, and
+// each warning of a leaf component as a warning result of the same rule.
+type SARIF struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties map[string]any  `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+func init() {
+	Register(FormatSARIF, SARIF{})
+}
+
+func (SARIF) Format(w io.Writer, status *ph.HealthCheckResponse) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "platform-health",
+			InformationURI: "https://github.com/isometry/platform-health",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	var ruleIDs []string
+	var walk func(parent string, component *ph.HealthCheckResponse)
+	walk = func(parent string, component *ph.HealthCheckResponse) {
+		if len(component.GetComponents()) > 0 {
+			for _, child := range component.GetComponents() {
+				walk(path.Join(parent, displayName(child)), child)
+			}
+			return
+		}
+
+		unhealthy := component.GetStatus() == ph.Status_UNHEALTHY
+		if !unhealthy && len(component.GetWarnings()) == 0 {
+			return
+		}
+
+		ruleID := component.GetType()
+		if ruleID == "" {
+			ruleID = "unknown"
+		}
+		if !slices.Contains(ruleIDs, ruleID) {
+			ruleIDs = append(ruleIDs, ruleID)
+		}
+
+		result := func(level, message string) {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  ruleID,
+				Level:   level,
+				Message: sarifMessage{Text: message},
+				Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{
+					Name:               component.GetName(),
+					FullyQualifiedName: parent,
+				}}}},
+				Properties: sarifProperties(component),
+			})
+		}
+
+		if unhealthy {
+			message := component.GetMessage()
+			if message == "" {
+				message = component.GetStatus().String()
+			}
+			result("error", message)
+		}
+		for _, warning := range component.GetWarnings() {
+			result("warning", warning)
+		}
+	}
+	if len(status.GetComponents()) > 0 {
+		walk("", status)
+	}
+
+	slices.Sort(ruleIDs)
+	for _, ruleID := range ruleIDs {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: ruleID})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  SARIFSchema,
+		Version: SARIFVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifProperties surfaces actionable detail, such as certificate expiry, as result properties
+func sarifProperties(component *ph.HealthCheckResponse) map[string]any {
+	for _, detail := range component.GetDetails() {
+		tls := &details.Detail_TLS{}
+		if detail.MessageIs(tls) && detail.UnmarshalTo(tls) == nil {
+			properties := map[string]any{
+				"commonName":      tls.GetCommonName(),
+				"subjectAltNames": tls.GetSubjectAltNames(),
+			}
+			if tls.GetValidUntil() != nil {
+				properties["validUntil"] = tls.GetValidUntil().AsTime().Format(time.RFC3339)
+			}
+			return properties
+		}
+	}
+	return nil
+}
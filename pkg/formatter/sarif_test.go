@@ -0,0 +1,145 @@
+package formatter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+type sarifLog struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID  string `json:"ruleId"`
+			Level   string `json:"level"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				LogicalLocations []struct {
+					Name               string `json:"name"`
+					FullyQualifiedName string `json:"fullyQualifiedName"`
+				} `json:"logicalLocations"`
+			} `json:"locations"`
+			Properties map[string]any `json:"properties"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func TestSARIF(t *testing.T) {
+	validUntil := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	tlsDetail, err := anypb.New(&details.Detail_TLS{
+		CommonName:      "www.example.com",
+		SubjectAltNames: []string{"www.example.com"},
+		ValidUntil:      timestamppb.New(validUntil),
+	})
+	require.NoError(t, err)
+
+	status := &ph.HealthCheckResponse{
+		Status: ph.Status_UNHEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+			{Type: "tls", Name: "www", Status: ph.Status_UNHEALTHY, Message: "certificate expires in 3 days", Details: []*anypb.Any{tlsDetail}},
+			{
+				Type:   "satellite",
+				Name:   "remote",
+				Status: ph.Status_UNHEALTHY,
+				Components: []*ph.HealthCheckResponse{
+					{Type: "http", Name: "api", Status: ph.Status_UNHEALTHY, Message: "status code 503"},
+					{Type: "http", Name: "app", Status: ph.Status_UNKNOWN},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.SARIF{}.Format(&buf, status))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+
+	assert.Equal(t, formatter.SARIFSchema, log.Schema)
+	assert.Equal(t, formatter.SARIFVersion, log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+
+	assert.Equal(t, "platform-health", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "http", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "tls", run.Tool.Driver.Rules[1].ID)
+
+	require.Len(t, run.Results, 2)
+
+	tlsResult := run.Results[0]
+	assert.Equal(t, "tls", tlsResult.RuleID)
+	assert.Equal(t, "error", tlsResult.Level)
+	assert.Equal(t, "certificate expires in 3 days", tlsResult.Message.Text)
+	require.Len(t, tlsResult.Locations, 1)
+	assert.Equal(t, "tls/www", tlsResult.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "2030-01-02T03:04:05Z", tlsResult.Properties["validUntil"])
+	assert.Equal(t, "www.example.com", tlsResult.Properties["commonName"])
+
+	httpResult := run.Results[1]
+	assert.Equal(t, "http", httpResult.RuleID)
+	assert.Equal(t, "error", httpResult.Level)
+	assert.Equal(t, "status code 503", httpResult.Message.Text)
+	assert.Equal(t, "satellite/remote/http/api", httpResult.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "api", httpResult.Locations[0].LogicalLocations[0].Name)
+	assert.Nil(t, httpResult.Properties)
+}
+
+func TestSARIFWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, formatter.SARIF{}.Format(&buf, &ph.HealthCheckResponse{
+		Status: ph.Status_HEALTHY,
+		Components: []*ph.HealthCheckResponse{
+			{Type: "helm", Name: "example", Status: ph.Status_HEALTHY, Warnings: []string{"chart example 1.0.0 is deprecated"}},
+		},
+	}))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+
+	require.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, "helm", run.Tool.Driver.Rules[0].ID)
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, "warning", run.Results[0].Level)
+	assert.Equal(t, "chart example 1.0.0 is deprecated", run.Results[0].Message.Text)
+	assert.Equal(t, "helm/example", run.Results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
+
+func TestSARIFHealthy(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, formatter.SARIF{}.Format(&buf, &ph.HealthCheckResponse{
+		Status:     ph.Status_HEALTHY,
+		Components: []*ph.HealthCheckResponse{{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY}},
+	}))
+
+	// an empty run must still serialize results and rules as arrays
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+	run := raw["runs"].([]any)[0].(map[string]any)
+	assert.Equal(t, []any{}, run["results"])
+	assert.Equal(t, []any{}, run["tool"].(map[string]any)["driver"].(map[string]any)["rules"])
+}
@@ -0,0 +1,75 @@
+package formatter
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+// Component orderings for Sort
+const (
+	SortDeclared = "declared"
+	SortName     = "name"
+	SortStatus   = "status"
+)
+
+// SortOrders lists the supported component orderings.
+var SortOrders = []string{SortDeclared, SortName, SortStatus}
+
+// Sort orders the components at every level of the tree. Components are
+// either left in declared order, sorted by type and name, or sorted worst
+// status first and then by type and name.
+func Sort(status *ph.HealthCheckResponse, by string) error {
+	var compare func(a, b *ph.HealthCheckResponse) int
+	switch by {
+	case SortDeclared:
+		return nil
+	case SortName:
+		compare = compareNames
+	case SortStatus:
+		compare = func(a, b *ph.HealthCheckResponse) int {
+			return cmp.Or(
+				cmp.Compare(severity(b.GetStatus()), severity(a.GetStatus())),
+				compareNames(a, b),
+			)
+		}
+	default:
+		return fmt.Errorf("unknown sort order %q (available: %s)", by, strings.Join(SortOrders, ", "))
+	}
+
+	sortComponents(status, compare)
+	return nil
+}
+
+func sortComponents(component *ph.HealthCheckResponse, compare func(a, b *ph.HealthCheckResponse) int) {
+	slices.SortStableFunc(component.GetComponents(), compare)
+	for _, child := range component.GetComponents() {
+		sortComponents(child, compare)
+	}
+}
+
+// severity ranks statuses from best to worst. UNKNOWN, reported by components
+// that did not complete or could not be checked, ranks between HEALTHY and
+// UNHEALTHY, as it does for the nagios format.
+func severity(status ph.Status) int {
+	switch status {
+	case ph.Status_HEALTHY:
+		return 0
+	case ph.Status_UNKNOWN:
+		return 1
+	case ph.Status_UNHEALTHY:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func compareNames(a, b *ph.HealthCheckResponse) int {
+	return cmp.Or(
+		cmp.Compare(a.GetType(), b.GetType()),
+		cmp.Compare(a.GetName(), b.GetName()),
+	)
+}
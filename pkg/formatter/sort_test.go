@@ -0,0 +1,97 @@
+package formatter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+func TestSort(t *testing.T) {
+	tree := func() *ph.HealthCheckResponse {
+		return &ph.HealthCheckResponse{
+			Status: ph.Status_UNHEALTHY,
+			Components: []*ph.HealthCheckResponse{
+				{Type: "tcp", Name: "database", Status: ph.Status_HEALTHY},
+				{Type: "http", Name: "frontend", Status: ph.Status_UNKNOWN},
+				{
+					Type:   "satellite",
+					Name:   "remote",
+					Status: ph.Status_UNHEALTHY,
+					Components: []*ph.HealthCheckResponse{
+						{Type: "tls", Name: "edge", Status: ph.Status_HEALTHY},
+						{Type: "grpc", Name: "api", Status: ph.Status_UNHEALTHY},
+					},
+				},
+				{Type: "http", Name: "backend", Status: ph.Status_UNHEALTHY},
+			},
+		}
+	}
+
+	names := func(components []*ph.HealthCheckResponse) (names []string) {
+		for _, component := range components {
+			names = append(names, component.GetName())
+		}
+		return names
+	}
+
+	tests := []struct {
+		name     string
+		by       string
+		expected []string
+		nested   []string
+	}{
+		{
+			name:     "Declared",
+			by:       formatter.SortDeclared,
+			expected: []string{"database", "frontend", "remote", "backend"},
+			nested:   []string{"edge", "api"},
+		},
+		{
+			name:     "Name",
+			by:       formatter.SortName,
+			expected: []string{"backend", "frontend", "remote", "database"},
+			nested:   []string{"api", "edge"},
+		},
+		{
+			name:     "Status",
+			by:       formatter.SortStatus,
+			expected: []string{"backend", "remote", "frontend", "database"},
+			nested:   []string{"api", "edge"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tree()
+			require.NoError(t, formatter.Sort(status, tt.by))
+			assert.Equal(t, tt.expected, names(status.GetComponents()))
+
+			for _, component := range status.GetComponents() {
+				if component.GetName() == "remote" {
+					assert.Equal(t, tt.nested, names(component.GetComponents()))
+				}
+			}
+		})
+	}
+
+	t.Run("Severity", func(t *testing.T) {
+		status := &ph.HealthCheckResponse{
+			Components: []*ph.HealthCheckResponse{
+				{Type: "tcp", Name: "healthy", Status: ph.Status_HEALTHY},
+				{Type: "tcp", Name: "unknown", Status: ph.Status_UNKNOWN},
+				{Type: "tcp", Name: "loop", Status: ph.Status_LOOP_DETECTED},
+				{Type: "tcp", Name: "unhealthy", Status: ph.Status_UNHEALTHY},
+			},
+		}
+		require.NoError(t, formatter.Sort(status, formatter.SortStatus))
+		assert.Equal(t, []string{"loop", "unhealthy", "unknown", "healthy"}, names(status.GetComponents()))
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		assert.ErrorContains(t, formatter.Sort(tree(), "random"), `unknown sort order "random"`)
+	})
+}
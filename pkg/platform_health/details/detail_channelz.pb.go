@@ -0,0 +1,244 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_channelz.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_Channelz struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Servers []*Detail_Channelz_Server `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+}
+
+func (x *Detail_Channelz) Reset() {
+	*x = Detail_Channelz{}
+	mi := &file_proto_detail_channelz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Channelz) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Channelz) ProtoMessage() {}
+
+func (x *Detail_Channelz) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_channelz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Channelz.ProtoReflect.Descriptor instead.
+func (*Detail_Channelz) Descriptor() ([]byte, []int) {
+	return file_proto_detail_channelz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_Channelz) GetServers() []*Detail_Channelz_Server {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type Detail_Channelz_Server struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CallsStarted   int64  `protobuf:"varint,3,opt,name=calls_started,json=callsStarted,proto3" json:"calls_started,omitempty"`
+	CallsSucceeded int64  `protobuf:"varint,4,opt,name=calls_succeeded,json=callsSucceeded,proto3" json:"calls_succeeded,omitempty"`
+	CallsFailed    int64  `protobuf:"varint,5,opt,name=calls_failed,json=callsFailed,proto3" json:"calls_failed,omitempty"`
+	ActiveCalls    int64  `protobuf:"varint,6,opt,name=active_calls,json=activeCalls,proto3" json:"active_calls,omitempty"`
+	Sockets        int64  `protobuf:"varint,7,opt,name=sockets,proto3" json:"sockets,omitempty"`
+}
+
+func (x *Detail_Channelz_Server) Reset() {
+	*x = Detail_Channelz_Server{}
+	mi := &file_proto_detail_channelz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Channelz_Server) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Channelz_Server) ProtoMessage() {}
+
+func (x *Detail_Channelz_Server) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_channelz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Channelz_Server.ProtoReflect.Descriptor instead.
+func (*Detail_Channelz_Server) Descriptor() ([]byte, []int) {
+	return file_proto_detail_channelz_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Detail_Channelz_Server) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Detail_Channelz_Server) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Detail_Channelz_Server) GetCallsStarted() int64 {
+	if x != nil {
+		return x.CallsStarted
+	}
+	return 0
+}
+
+func (x *Detail_Channelz_Server) GetCallsSucceeded() int64 {
+	if x != nil {
+		return x.CallsSucceeded
+	}
+	return 0
+}
+
+func (x *Detail_Channelz_Server) GetCallsFailed() int64 {
+	if x != nil {
+		return x.CallsFailed
+	}
+	return 0
+}
+
+func (x *Detail_Channelz_Server) GetActiveCalls() int64 {
+	if x != nil {
+		return x.ActiveCalls
+	}
+	return 0
+}
+
+func (x *Detail_Channelz_Server) GetSockets() int64 {
+	if x != nil {
+		return x.Sockets
+	}
+	return 0
+}
+
+var File_proto_detail_channelz_proto protoreflect.FileDescriptor
+
+var file_proto_detail_channelz_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x70,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x22, 0xbb, 0x02, 0x0a, 0x0f, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x5f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x7a, 0x12, 0x4b, 0x0a, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e,
+	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x5f, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x7a, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x1a, 0xda, 0x01, 0x0a, 0x06, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x6c,
+	0x73, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x53, 0x75, 0x63,
+	0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x5f,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x61,
+	0x6c, 0x6c, 0x73, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2f, 0x70, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_proto_detail_channelz_proto_rawDescOnce sync.Once
+	file_proto_detail_channelz_proto_rawDescData = file_proto_detail_channelz_proto_rawDesc
+)
+
+func file_proto_detail_channelz_proto_rawDescGZIP() []byte {
+	file_proto_detail_channelz_proto_rawDescOnce.Do(func() {
+		file_proto_detail_channelz_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_channelz_proto_rawDescData)
+	})
+	return file_proto_detail_channelz_proto_rawDescData
+}
+
+var file_proto_detail_channelz_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_detail_channelz_proto_goTypes = []any{
+	(*Detail_Channelz)(nil),        // 0: platform_health.detail.v1.Detail_Channelz
+	(*Detail_Channelz_Server)(nil), // 1: platform_health.detail.v1.Detail_Channelz.Server
+}
+var file_proto_detail_channelz_proto_depIdxs = []int32{
+	1, // 0: platform_health.detail.v1.Detail_Channelz.servers:type_name -> platform_health.detail.v1.Detail_Channelz.Server
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_channelz_proto_init() }
+func file_proto_detail_channelz_proto_init() {
+	if File_proto_detail_channelz_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_channelz_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_channelz_proto_goTypes,
+		DependencyIndexes: file_proto_detail_channelz_proto_depIdxs,
+		MessageInfos:      file_proto_detail_channelz_proto_msgTypes,
+	}.Build()
+	File_proto_detail_channelz_proto = out.File
+	file_proto_detail_channelz_proto_rawDesc = nil
+	file_proto_detail_channelz_proto_goTypes = nil
+	file_proto_detail_channelz_proto_depIdxs = nil
+}
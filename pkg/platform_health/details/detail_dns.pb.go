@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_dns.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_DNS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host       string               `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Type       string               `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Answers    []*Detail_DNS_Answer `protobuf:"bytes,3,rep,name=answers,proto3" json:"answers,omitempty"`
+	Propagated bool                 `protobuf:"varint,4,opt,name=propagated,proto3" json:"propagated,omitempty"`
+}
+
+func (x *Detail_DNS) Reset() {
+	*x = Detail_DNS{}
+	mi := &file_proto_detail_dns_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_DNS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_DNS) ProtoMessage() {}
+
+func (x *Detail_DNS) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_dns_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_DNS.ProtoReflect.Descriptor instead.
+func (*Detail_DNS) Descriptor() ([]byte, []int) {
+	return file_proto_detail_dns_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_DNS) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Detail_DNS) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Detail_DNS) GetAnswers() []*Detail_DNS_Answer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *Detail_DNS) GetPropagated() bool {
+	if x != nil {
+		return x.Propagated
+	}
+	return false
+}
+
+type Detail_DNS_Answer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server  string   `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Records []string `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	Error   string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *Detail_DNS_Answer) Reset() {
+	*x = Detail_DNS_Answer{}
+	mi := &file_proto_detail_dns_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_DNS_Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_DNS_Answer) ProtoMessage() {}
+
+func (x *Detail_DNS_Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_dns_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_DNS_Answer.ProtoReflect.Descriptor instead.
+func (*Detail_DNS_Answer) Descriptor() ([]byte, []int) {
+	return file_proto_detail_dns_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Detail_DNS_Answer) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *Detail_DNS_Answer) GetRecords() []string {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *Detail_DNS_Answer) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_proto_detail_dns_proto protoreflect.FileDescriptor
+
+var file_proto_detail_dns_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x64,
+	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
+	0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x2e, 0x76, 0x31, 0x22, 0xee, 0x01, 0x0a, 0x0a, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x44,
+	0x4e, 0x53, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x46, 0x0a, 0x07, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x70, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x44,
+	0x4e, 0x53, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x07, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74,
+	0x65, 0x64, 0x1a, 0x50, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2f, 0x70, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_detail_dns_proto_rawDescOnce sync.Once
+	file_proto_detail_dns_proto_rawDescData = file_proto_detail_dns_proto_rawDesc
+)
+
+func file_proto_detail_dns_proto_rawDescGZIP() []byte {
+	file_proto_detail_dns_proto_rawDescOnce.Do(func() {
+		file_proto_detail_dns_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_dns_proto_rawDescData)
+	})
+	return file_proto_detail_dns_proto_rawDescData
+}
+
+var file_proto_detail_dns_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_detail_dns_proto_goTypes = []any{
+	(*Detail_DNS)(nil),        // 0: platform_health.detail.v1.Detail_DNS
+	(*Detail_DNS_Answer)(nil), // 1: platform_health.detail.v1.Detail_DNS.Answer
+}
+var file_proto_detail_dns_proto_depIdxs = []int32{
+	1, // 0: platform_health.detail.v1.Detail_DNS.answers:type_name -> platform_health.detail.v1.Detail_DNS.Answer
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_dns_proto_init() }
+func file_proto_detail_dns_proto_init() {
+	if File_proto_detail_dns_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_dns_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_dns_proto_goTypes,
+		DependencyIndexes: file_proto_detail_dns_proto_depIdxs,
+		MessageInfos:      file_proto_detail_dns_proto_msgTypes,
+	}.Build()
+	File_proto_detail_dns_proto = out.File
+	file_proto_detail_dns_proto_rawDesc = nil
+	file_proto_detail_dns_proto_goTypes = nil
+	file_proto_detail_dns_proto_depIdxs = nil
+}
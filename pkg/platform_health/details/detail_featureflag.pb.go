@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_featureflag.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_FeatureFlag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format string                     `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // API spoken by the flag service
+	Flags  []*Detail_FeatureFlag_Flag `protobuf:"bytes,2,rep,name=flags,proto3" json:"flags,omitempty"`   // state of every flag reported by the service, by name
+}
+
+func (x *Detail_FeatureFlag) Reset() {
+	*x = Detail_FeatureFlag{}
+	mi := &file_proto_detail_featureflag_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_FeatureFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_FeatureFlag) ProtoMessage() {}
+
+func (x *Detail_FeatureFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_featureflag_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_FeatureFlag.ProtoReflect.Descriptor instead.
+func (*Detail_FeatureFlag) Descriptor() ([]byte, []int) {
+	return file_proto_detail_featureflag_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_FeatureFlag) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Detail_FeatureFlag) GetFlags() []*Detail_FeatureFlag_Flag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type Detail_FeatureFlag_Flag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled bool   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *Detail_FeatureFlag_Flag) Reset() {
+	*x = Detail_FeatureFlag_Flag{}
+	mi := &file_proto_detail_featureflag_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_FeatureFlag_Flag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_FeatureFlag_Flag) ProtoMessage() {}
+
+func (x *Detail_FeatureFlag_Flag) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_featureflag_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_FeatureFlag_Flag.ProtoReflect.Descriptor instead.
+func (*Detail_FeatureFlag_Flag) Descriptor() ([]byte, []int) {
+	return file_proto_detail_featureflag_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Detail_FeatureFlag_Flag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Detail_FeatureFlag_Flag) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+var File_proto_detail_featureflag_proto protoreflect.FileDescriptor
+
+var file_proto_detail_featureflag_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x66,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x66, 0x6c, 0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x19, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x22, 0xac, 0x01, 0x0a, 0x12,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c,
+	0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x48, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x46, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x2e, 0x46, 0x6c, 0x61, 0x67, 0x52, 0x05, 0x66,
+	0x6c, 0x61, 0x67, 0x73, 0x1a, 0x34, 0x0a, 0x04, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_detail_featureflag_proto_rawDescOnce sync.Once
+	file_proto_detail_featureflag_proto_rawDescData = file_proto_detail_featureflag_proto_rawDesc
+)
+
+func file_proto_detail_featureflag_proto_rawDescGZIP() []byte {
+	file_proto_detail_featureflag_proto_rawDescOnce.Do(func() {
+		file_proto_detail_featureflag_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_featureflag_proto_rawDescData)
+	})
+	return file_proto_detail_featureflag_proto_rawDescData
+}
+
+var file_proto_detail_featureflag_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_detail_featureflag_proto_goTypes = []any{
+	(*Detail_FeatureFlag)(nil),      // 0: platform_health.detail.v1.Detail_FeatureFlag
+	(*Detail_FeatureFlag_Flag)(nil), // 1: platform_health.detail.v1.Detail_FeatureFlag.Flag
+}
+var file_proto_detail_featureflag_proto_depIdxs = []int32{
+	1, // 0: platform_health.detail.v1.Detail_FeatureFlag.flags:type_name -> platform_health.detail.v1.Detail_FeatureFlag.Flag
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_featureflag_proto_init() }
+func file_proto_detail_featureflag_proto_init() {
+	if File_proto_detail_featureflag_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_featureflag_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_featureflag_proto_goTypes,
+		DependencyIndexes: file_proto_detail_featureflag_proto_depIdxs,
+		MessageInfos:      file_proto_detail_featureflag_proto_msgTypes,
+	}.Build()
+	File_proto_detail_featureflag_proto = out.File
+	file_proto_detail_featureflag_proto_rawDesc = nil
+	file_proto_detail_featureflag_proto_goTypes = nil
+	file_proto_detail_featureflag_proto_depIdxs = nil
+}
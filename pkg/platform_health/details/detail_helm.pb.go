@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_helm.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_Helm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chart            string                  `protobuf:"bytes,1,opt,name=chart,proto3" json:"chart,omitempty"`                        // name of the deployed chart
+	Version          string                  `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                    // version of the deployed chart
+	LatestVersion    string                  `protobuf:"bytes,3,opt,name=latestVersion,proto3" json:"latestVersion,omitempty"`        // latest stable version of the chart in the repository
+	UpgradeAvailable bool                    `protobuf:"varint,4,opt,name=upgradeAvailable,proto3" json:"upgradeAvailable,omitempty"` // whether latestVersion is newer than version
+	Resources        []*Detail_Helm_Resource `protobuf:"bytes,5,rep,name=resources,proto3" json:"resources,omitempty"`                // live status of the resources in the release manifest
+}
+
+func (x *Detail_Helm) Reset() {
+	*x = Detail_Helm{}
+	mi := &file_proto_detail_helm_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Helm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Helm) ProtoMessage() {}
+
+func (x *Detail_Helm) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_helm_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Helm.ProtoReflect.Descriptor instead.
+func (*Detail_Helm) Descriptor() ([]byte, []int) {
+	return file_proto_detail_helm_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_Helm) GetChart() string {
+	if x != nil {
+		return x.Chart
+	}
+	return ""
+}
+
+func (x *Detail_Helm) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Detail_Helm) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+func (x *Detail_Helm) GetUpgradeAvailable() bool {
+	if x != nil {
+		return x.UpgradeAvailable
+	}
+	return false
+}
+
+func (x *Detail_Helm) GetResources() []*Detail_Helm_Resource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type Detail_Helm_Resource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind      string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Ready     bool   `protobuf:"varint,4,opt,name=ready,proto3" json:"ready,omitempty"`
+}
+
+func (x *Detail_Helm_Resource) Reset() {
+	*x = Detail_Helm_Resource{}
+	mi := &file_proto_detail_helm_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Helm_Resource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Helm_Resource) ProtoMessage() {}
+
+func (x *Detail_Helm_Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_helm_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Helm_Resource.ProtoReflect.Descriptor instead.
+func (*Detail_Helm_Resource) Descriptor() ([]byte, []int) {
+	return file_proto_detail_helm_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Detail_Helm_Resource) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Detail_Helm_Resource) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Detail_Helm_Resource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Detail_Helm_Resource) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+var File_proto_detail_helm_proto protoreflect.FileDescriptor
+
+var file_proto_detail_helm_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x68,
+	0x65, 0x6c, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x2e, 0x76, 0x31, 0x22, 0xc6, 0x02, 0x0a, 0x0b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f,
+	0x48, 0x65, 0x6c, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68, 0x61, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x74,
+	0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x10, 0x75, 0x70,
+	0x67, 0x72, 0x61, 0x64, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x4d, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x48, 0x65, 0x6c,
+	0x6d, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x66, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x42, 0x41, 0x5a,
+	0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_detail_helm_proto_rawDescOnce sync.Once
+	file_proto_detail_helm_proto_rawDescData = file_proto_detail_helm_proto_rawDesc
+)
+
+func file_proto_detail_helm_proto_rawDescGZIP() []byte {
+	file_proto_detail_helm_proto_rawDescOnce.Do(func() {
+		file_proto_detail_helm_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_helm_proto_rawDescData)
+	})
+	return file_proto_detail_helm_proto_rawDescData
+}
+
+var file_proto_detail_helm_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_detail_helm_proto_goTypes = []any{
+	(*Detail_Helm)(nil),          // 0: platform_health.detail.v1.Detail_Helm
+	(*Detail_Helm_Resource)(nil), // 1: platform_health.detail.v1.Detail_Helm.Resource
+}
+var file_proto_detail_helm_proto_depIdxs = []int32{
+	1, // 0: platform_health.detail.v1.Detail_Helm.resources:type_name -> platform_health.detail.v1.Detail_Helm.Resource
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_helm_proto_init() }
+func file_proto_detail_helm_proto_init() {
+	if File_proto_detail_helm_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_helm_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_helm_proto_goTypes,
+		DependencyIndexes: file_proto_detail_helm_proto_depIdxs,
+		MessageInfos:      file_proto_detail_helm_proto_msgTypes,
+	}.Build()
+	File_proto_detail_helm_proto = out.File
+	file_proto_detail_helm_proto_rawDesc = nil
+	file_proto_detail_helm_proto_goTypes = nil
+	file_proto_detail_helm_proto_depIdxs = nil
+}
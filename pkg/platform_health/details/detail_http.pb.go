@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_http.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_HTTP struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url       string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`             // final URL, after any redirects
+	Redirects []string `protobuf:"bytes,2,rep,name=redirects,proto3" json:"redirects,omitempty"` // URLs redirected to, in order
+	Encoding  string   `protobuf:"bytes,3,opt,name=encoding,proto3" json:"encoding,omitempty"`   // content encoding of the response, e.g. "gzip"; empty if not encoded
+}
+
+func (x *Detail_HTTP) Reset() {
+	*x = Detail_HTTP{}
+	mi := &file_proto_detail_http_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_HTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_HTTP) ProtoMessage() {}
+
+func (x *Detail_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_http_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_HTTP.ProtoReflect.Descriptor instead.
+func (*Detail_HTTP) Descriptor() ([]byte, []int) {
+	return file_proto_detail_http_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_HTTP) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Detail_HTTP) GetRedirects() []string {
+	if x != nil {
+		return x.Redirects
+	}
+	return nil
+}
+
+func (x *Detail_HTTP) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+var File_proto_detail_http_proto protoreflect.FileDescriptor
+
+var file_proto_detail_http_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x68,
+	0x74, 0x74, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x2e, 0x76, 0x31, 0x22, 0x59, 0x0a, 0x0b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x48,
+	0x54, 0x54, 0x50, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x42,
+	0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73,
+	0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d,
+	0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_detail_http_proto_rawDescOnce sync.Once
+	file_proto_detail_http_proto_rawDescData = file_proto_detail_http_proto_rawDesc
+)
+
+func file_proto_detail_http_proto_rawDescGZIP() []byte {
+	file_proto_detail_http_proto_rawDescOnce.Do(func() {
+		file_proto_detail_http_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_http_proto_rawDescData)
+	})
+	return file_proto_detail_http_proto_rawDescData
+}
+
+var file_proto_detail_http_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_detail_http_proto_goTypes = []any{
+	(*Detail_HTTP)(nil), // 0: platform_health.detail.v1.Detail_HTTP
+}
+var file_proto_detail_http_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_http_proto_init() }
+func file_proto_detail_http_proto_init() {
+	if File_proto_detail_http_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_http_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_http_proto_goTypes,
+		DependencyIndexes: file_proto_detail_http_proto_depIdxs,
+		MessageInfos:      file_proto_detail_http_proto_msgTypes,
+	}.Build()
+	File_proto_detail_http_proto = out.File
+	file_proto_detail_http_proto_rawDesc = nil
+	file_proto_detail_http_proto_goTypes = nil
+	file_proto_detail_http_proto_depIdxs = nil
+}
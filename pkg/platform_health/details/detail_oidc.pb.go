@@ -0,0 +1,160 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_oidc.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_OIDC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Issuer    string            `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	Endpoints map[string]string `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	JwksOk    bool              `protobuf:"varint,3,opt,name=jwks_ok,json=jwksOk,proto3" json:"jwks_ok,omitempty"`
+}
+
+func (x *Detail_OIDC) Reset() {
+	*x = Detail_OIDC{}
+	mi := &file_proto_detail_oidc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_OIDC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_OIDC) ProtoMessage() {}
+
+func (x *Detail_OIDC) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_oidc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_OIDC.ProtoReflect.Descriptor instead.
+func (*Detail_OIDC) Descriptor() ([]byte, []int) {
+	return file_proto_detail_oidc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_OIDC) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Detail_OIDC) GetEndpoints() map[string]string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *Detail_OIDC) GetJwksOk() bool {
+	if x != nil {
+		return x.JwksOk
+	}
+	return false
+}
+
+var File_proto_detail_oidc_proto protoreflect.FileDescriptor
+
+var file_proto_detail_oidc_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x6f,
+	0x69, 0x64, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x2e, 0x76, 0x31, 0x22, 0xd1, 0x01, 0x0a, 0x0b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f,
+	0x4f, 0x49, 0x44, 0x43, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x53, 0x0a, 0x09,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x35, 0x2e, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x5f, 0x4f, 0x49, 0x44, 0x43, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x12, 0x17, 0x0a, 0x07, 0x6a, 0x77, 0x6b, 0x73, 0x5f, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x6a, 0x77, 0x6b, 0x73, 0x4f, 0x6b, 0x1a, 0x3c, 0x0a, 0x0e, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2f,
+	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_detail_oidc_proto_rawDescOnce sync.Once
+	file_proto_detail_oidc_proto_rawDescData = file_proto_detail_oidc_proto_rawDesc
+)
+
+func file_proto_detail_oidc_proto_rawDescGZIP() []byte {
+	file_proto_detail_oidc_proto_rawDescOnce.Do(func() {
+		file_proto_detail_oidc_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_oidc_proto_rawDescData)
+	})
+	return file_proto_detail_oidc_proto_rawDescData
+}
+
+var file_proto_detail_oidc_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_detail_oidc_proto_goTypes = []any{
+	(*Detail_OIDC)(nil), // 0: platform_health.detail.v1.Detail_OIDC
+	nil,                 // 1: platform_health.detail.v1.Detail_OIDC.EndpointsEntry
+}
+var file_proto_detail_oidc_proto_depIdxs = []int32{
+	1, // 0: platform_health.detail.v1.Detail_OIDC.endpoints:type_name -> platform_health.detail.v1.Detail_OIDC.EndpointsEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_oidc_proto_init() }
+func file_proto_detail_oidc_proto_init() {
+	if File_proto_detail_oidc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_oidc_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_oidc_proto_goTypes,
+		DependencyIndexes: file_proto_detail_oidc_proto_depIdxs,
+		MessageInfos:      file_proto_detail_oidc_proto_msgTypes,
+	}.Build()
+	File_proto_detail_oidc_proto = out.File
+	file_proto_detail_oidc_proto_rawDesc = nil
+	file_proto_detail_oidc_proto_goTypes = nil
+	file_proto_detail_oidc_proto_depIdxs = nil
+}
@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_postgres.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_Postgres struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ping *durationpb.Duration   `protobuf:"bytes,1,opt,name=ping,proto3" json:"ping,omitempty"`
+	Rows []*Detail_Postgres_Row `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *Detail_Postgres) Reset() {
+	*x = Detail_Postgres{}
+	mi := &file_proto_detail_postgres_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Postgres) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Postgres) ProtoMessage() {}
+
+func (x *Detail_Postgres) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_postgres_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Postgres.ProtoReflect.Descriptor instead.
+func (*Detail_Postgres) Descriptor() ([]byte, []int) {
+	return file_proto_detail_postgres_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_Postgres) GetPing() *durationpb.Duration {
+	if x != nil {
+		return x.Ping
+	}
+	return nil
+}
+
+func (x *Detail_Postgres) GetRows() []*Detail_Postgres_Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type Detail_Postgres_Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns map[string]string `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Detail_Postgres_Row) Reset() {
+	*x = Detail_Postgres_Row{}
+	mi := &file_proto_detail_postgres_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Postgres_Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Postgres_Row) ProtoMessage() {}
+
+func (x *Detail_Postgres_Row) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_postgres_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Postgres_Row.ProtoReflect.Descriptor instead.
+func (*Detail_Postgres_Row) Descriptor() ([]byte, []int) {
+	return file_proto_detail_postgres_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Detail_Postgres_Row) GetColumns() map[string]string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+var File_proto_detail_postgres_proto protoreflect.FileDescriptor
+
+var file_proto_detail_postgres_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x70,
+	0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x70,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9f, 0x02, 0x0a, 0x0f, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x5f, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x04,
+	0x70, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x42, 0x0a, 0x04, 0x72,
+	0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x70, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x50, 0x6f, 0x73,
+	0x74, 0x67, 0x72, 0x65, 0x73, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x1a,
+	0x98, 0x01, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x55, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x50, 0x6f, 0x73, 0x74,
+	0x67, 0x72, 0x65, 0x73, 0x2e, 0x52, 0x6f, 0x77, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x1a, 0x3a,
+	0x0a, 0x0c, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_detail_postgres_proto_rawDescOnce sync.Once
+	file_proto_detail_postgres_proto_rawDescData = file_proto_detail_postgres_proto_rawDesc
+)
+
+func file_proto_detail_postgres_proto_rawDescGZIP() []byte {
+	file_proto_detail_postgres_proto_rawDescOnce.Do(func() {
+		file_proto_detail_postgres_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_postgres_proto_rawDescData)
+	})
+	return file_proto_detail_postgres_proto_rawDescData
+}
+
+var file_proto_detail_postgres_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_detail_postgres_proto_goTypes = []any{
+	(*Detail_Postgres)(nil),     // 0: platform_health.detail.v1.Detail_Postgres
+	(*Detail_Postgres_Row)(nil), // 1: platform_health.detail.v1.Detail_Postgres.Row
+	nil,                         // 2: platform_health.detail.v1.Detail_Postgres.Row.ColumnsEntry
+	(*durationpb.Duration)(nil), // 3: google.protobuf.Duration
+}
+var file_proto_detail_postgres_proto_depIdxs = []int32{
+	3, // 0: platform_health.detail.v1.Detail_Postgres.ping:type_name -> google.protobuf.Duration
+	1, // 1: platform_health.detail.v1.Detail_Postgres.rows:type_name -> platform_health.detail.v1.Detail_Postgres.Row
+	2, // 2: platform_health.detail.v1.Detail_Postgres.Row.columns:type_name -> platform_health.detail.v1.Detail_Postgres.Row.ColumnsEntry
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_postgres_proto_init() }
+func file_proto_detail_postgres_proto_init() {
+	if File_proto_detail_postgres_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_postgres_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_postgres_proto_goTypes,
+		DependencyIndexes: file_proto_detail_postgres_proto_depIdxs,
+		MessageInfos:      file_proto_detail_postgres_proto_msgTypes,
+	}.Build()
+	File_proto_detail_postgres_proto = out.File
+	file_proto_detail_postgres_proto_rawDesc = nil
+	file_proto_detail_postgres_proto_goTypes = nil
+	file_proto_detail_postgres_proto_depIdxs = nil
+}
@@ -0,0 +1,324 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_redis.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_Redis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Role             string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	ConnectedClients int64                  `protobuf:"varint,2,opt,name=connected_clients,json=connectedClients,proto3" json:"connected_clients,omitempty"`
+	UsedMemory       int64                  `protobuf:"varint,3,opt,name=used_memory,json=usedMemory,proto3" json:"used_memory,omitempty"`
+	Sentinel         *Detail_Redis_Sentinel `protobuf:"bytes,4,opt,name=sentinel,proto3" json:"sentinel,omitempty"` // topology reported by the sentinel, in sentinel mode
+	Cluster          *Detail_Redis_Cluster  `protobuf:"bytes,5,opt,name=cluster,proto3" json:"cluster,omitempty"`   // state reported by the cluster node, in cluster mode
+}
+
+func (x *Detail_Redis) Reset() {
+	*x = Detail_Redis{}
+	mi := &file_proto_detail_redis_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Redis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Redis) ProtoMessage() {}
+
+func (x *Detail_Redis) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_redis_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Redis.ProtoReflect.Descriptor instead.
+func (*Detail_Redis) Descriptor() ([]byte, []int) {
+	return file_proto_detail_redis_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_Redis) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Detail_Redis) GetConnectedClients() int64 {
+	if x != nil {
+		return x.ConnectedClients
+	}
+	return 0
+}
+
+func (x *Detail_Redis) GetUsedMemory() int64 {
+	if x != nil {
+		return x.UsedMemory
+	}
+	return 0
+}
+
+func (x *Detail_Redis) GetSentinel() *Detail_Redis_Sentinel {
+	if x != nil {
+		return x.Sentinel
+	}
+	return nil
+}
+
+func (x *Detail_Redis) GetCluster() *Detail_Redis_Cluster {
+	if x != nil {
+		return x.Cluster
+	}
+	return nil
+}
+
+type Detail_Redis_Sentinel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Master     string `protobuf:"bytes,1,opt,name=master,proto3" json:"master,omitempty"`                           // name of the monitored master
+	MasterAddr string `protobuf:"bytes,2,opt,name=master_addr,json=masterAddr,proto3" json:"master_addr,omitempty"` // address of the current master
+	Quorum     bool   `protobuf:"varint,3,opt,name=quorum,proto3" json:"quorum,omitempty"`                          // whether the sentinels can reach the quorum to fail over the master
+}
+
+func (x *Detail_Redis_Sentinel) Reset() {
+	*x = Detail_Redis_Sentinel{}
+	mi := &file_proto_detail_redis_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Redis_Sentinel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Redis_Sentinel) ProtoMessage() {}
+
+func (x *Detail_Redis_Sentinel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_redis_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Redis_Sentinel.ProtoReflect.Descriptor instead.
+func (*Detail_Redis_Sentinel) Descriptor() ([]byte, []int) {
+	return file_proto_detail_redis_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Detail_Redis_Sentinel) GetMaster() string {
+	if x != nil {
+		return x.Master
+	}
+	return ""
+}
+
+func (x *Detail_Redis_Sentinel) GetMasterAddr() string {
+	if x != nil {
+		return x.MasterAddr
+	}
+	return ""
+}
+
+func (x *Detail_Redis_Sentinel) GetQuorum() bool {
+	if x != nil {
+		return x.Quorum
+	}
+	return false
+}
+
+type Detail_Redis_Cluster struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State      string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	SlotsOk    int64  `protobuf:"varint,2,opt,name=slots_ok,json=slotsOk,proto3" json:"slots_ok,omitempty"` // slots assigned to nodes that are neither failing nor suspected to be failing
+	KnownNodes int64  `protobuf:"varint,3,opt,name=known_nodes,json=knownNodes,proto3" json:"known_nodes,omitempty"`
+	Size       int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"` // number of masters serving at least one slot
+}
+
+func (x *Detail_Redis_Cluster) Reset() {
+	*x = Detail_Redis_Cluster{}
+	mi := &file_proto_detail_redis_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_Redis_Cluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_Redis_Cluster) ProtoMessage() {}
+
+func (x *Detail_Redis_Cluster) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_redis_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_Redis_Cluster.ProtoReflect.Descriptor instead.
+func (*Detail_Redis_Cluster) Descriptor() ([]byte, []int) {
+	return file_proto_detail_redis_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Detail_Redis_Cluster) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Detail_Redis_Cluster) GetSlotsOk() int64 {
+	if x != nil {
+		return x.SlotsOk
+	}
+	return 0
+}
+
+func (x *Detail_Redis_Cluster) GetKnownNodes() int64 {
+	if x != nil {
+		return x.KnownNodes
+	}
+	return 0
+}
+
+func (x *Detail_Redis_Cluster) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+var File_proto_detail_redis_proto protoreflect.FileDescriptor
+
+var file_proto_detail_redis_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x72,
+	0x65, 0x64, 0x69, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x70, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x2e, 0x76, 0x31, 0x22, 0xd7, 0x03, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x5f, 0x52, 0x65, 0x64, 0x69, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x64, 0x5f,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x73,
+	0x65, 0x64, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x4c, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x74,
+	0x69, 0x6e, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x52, 0x65,
+	0x64, 0x69, 0x73, 0x2e, 0x53, 0x65, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x73, 0x65,
+	0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6c, 0x12, 0x49, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
+	0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x52, 0x65, 0x64, 0x69, 0x73,
+	0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x1a, 0x5b, 0x0a, 0x08, 0x53, 0x65, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6c, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x73, 0x74,
+	0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x1a, 0x6f,
+	0x0a, 0x07, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x5f, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x4f, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x42,
+	0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73,
+	0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d,
+	0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_detail_redis_proto_rawDescOnce sync.Once
+	file_proto_detail_redis_proto_rawDescData = file_proto_detail_redis_proto_rawDesc
+)
+
+func file_proto_detail_redis_proto_rawDescGZIP() []byte {
+	file_proto_detail_redis_proto_rawDescOnce.Do(func() {
+		file_proto_detail_redis_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_redis_proto_rawDescData)
+	})
+	return file_proto_detail_redis_proto_rawDescData
+}
+
+var file_proto_detail_redis_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_detail_redis_proto_goTypes = []any{
+	(*Detail_Redis)(nil),          // 0: platform_health.detail.v1.Detail_Redis
+	(*Detail_Redis_Sentinel)(nil), // 1: platform_health.detail.v1.Detail_Redis.Sentinel
+	(*Detail_Redis_Cluster)(nil),  // 2: platform_health.detail.v1.Detail_Redis.Cluster
+}
+var file_proto_detail_redis_proto_depIdxs = []int32{
+	1, // 0: platform_health.detail.v1.Detail_Redis.sentinel:type_name -> platform_health.detail.v1.Detail_Redis.Sentinel
+	2, // 1: platform_health.detail.v1.Detail_Redis.cluster:type_name -> platform_health.detail.v1.Detail_Redis.Cluster
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_redis_proto_init() }
+func file_proto_detail_redis_proto_init() {
+	if File_proto_detail_redis_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_redis_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_redis_proto_goTypes,
+		DependencyIndexes: file_proto_detail_redis_proto_depIdxs,
+		MessageInfos:      file_proto_detail_redis_proto_msgTypes,
+	}.Build()
+	File_proto_detail_redis_proto = out.File
+	file_proto_detail_redis_proto_rawDesc = nil
+	file_proto_detail_redis_proto_goTypes = nil
+	file_proto_detail_redis_proto_depIdxs = nil
+}
@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: proto/detail_tcp.proto
+
+package details
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Detail_TCP struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accepted       int32                `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Refused        int32                `protobuf:"varint,2,opt,name=refused,proto3" json:"refused,omitempty"`
+	MaxConnectTime *durationpb.Duration `protobuf:"bytes,3,opt,name=max_connect_time,json=maxConnectTime,proto3" json:"max_connect_time,omitempty"`
+	Banner         string               `protobuf:"bytes,4,opt,name=banner,proto3" json:"banner,omitempty"` // first line of the response, if send or expect is configured
+}
+
+func (x *Detail_TCP) Reset() {
+	*x = Detail_TCP{}
+	mi := &file_proto_detail_tcp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_TCP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_TCP) ProtoMessage() {}
+
+func (x *Detail_TCP) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_tcp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_TCP.ProtoReflect.Descriptor instead.
+func (*Detail_TCP) Descriptor() ([]byte, []int) {
+	return file_proto_detail_tcp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Detail_TCP) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *Detail_TCP) GetRefused() int32 {
+	if x != nil {
+		return x.Refused
+	}
+	return 0
+}
+
+func (x *Detail_TCP) GetMaxConnectTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxConnectTime
+	}
+	return nil
+}
+
+func (x *Detail_TCP) GetBanner() string {
+	if x != nil {
+		return x.Banner
+	}
+	return ""
+}
+
+var File_proto_detail_tcp_proto protoreflect.FileDescriptor
+
+var file_proto_detail_tcp_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x74,
+	0x63, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
+	0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x9f, 0x01, 0x0a, 0x0a, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x54,
+	0x43, 0x50, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x66, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x72, 0x65, 0x66, 0x75, 0x73, 0x65, 0x64, 0x12, 0x43, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x6d,
+	0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62,
+	0x61, 0x6e, 0x6e, 0x65, 0x72, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2f, 0x70, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_detail_tcp_proto_rawDescOnce sync.Once
+	file_proto_detail_tcp_proto_rawDescData = file_proto_detail_tcp_proto_rawDesc
+)
+
+func file_proto_detail_tcp_proto_rawDescGZIP() []byte {
+	file_proto_detail_tcp_proto_rawDescOnce.Do(func() {
+		file_proto_detail_tcp_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_detail_tcp_proto_rawDescData)
+	})
+	return file_proto_detail_tcp_proto_rawDescData
+}
+
+var file_proto_detail_tcp_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_detail_tcp_proto_goTypes = []any{
+	(*Detail_TCP)(nil),          // 0: platform_health.detail.v1.Detail_TCP
+	(*durationpb.Duration)(nil), // 1: google.protobuf.Duration
+}
+var file_proto_detail_tcp_proto_depIdxs = []int32{
+	1, // 0: platform_health.detail.v1.Detail_TCP.max_connect_time:type_name -> google.protobuf.Duration
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_detail_tcp_proto_init() }
+func file_proto_detail_tcp_proto_init() {
+	if File_proto_detail_tcp_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_detail_tcp_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_detail_tcp_proto_goTypes,
+		DependencyIndexes: file_proto_detail_tcp_proto_depIdxs,
+		MessageInfos:      file_proto_detail_tcp_proto_msgTypes,
+	}.Build()
+	File_proto_detail_tcp_proto = out.File
+	file_proto_detail_tcp_proto_rawDesc = nil
+	file_proto_detail_tcp_proto_goTypes = nil
+	file_proto_detail_tcp_proto_depIdxs = nil
+}
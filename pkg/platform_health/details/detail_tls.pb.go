@@ -26,15 +26,22 @@ type Detail_TLS struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CommonName         string                 `protobuf:"bytes,1,opt,name=commonName,proto3" json:"commonName,omitempty"`
-	SubjectAltNames    []string               `protobuf:"bytes,2,rep,name=subjectAltNames,proto3" json:"subjectAltNames,omitempty"`
-	Chain              []string               `protobuf:"bytes,3,rep,name=chain,proto3" json:"chain,omitempty"`
-	ValidUntil         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
-	SignatureAlgorithm string                 `protobuf:"bytes,5,opt,name=signatureAlgorithm,proto3" json:"signatureAlgorithm,omitempty"`
-	PublicKeyAlgorithm string                 `protobuf:"bytes,6,opt,name=publicKeyAlgorithm,proto3" json:"publicKeyAlgorithm,omitempty"`
-	Version            string                 `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
-	CipherSuite        string                 `protobuf:"bytes,8,opt,name=cipherSuite,proto3" json:"cipherSuite,omitempty"`
-	Protocol           string                 `protobuf:"bytes,9,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	CommonName         string                    `protobuf:"bytes,1,opt,name=commonName,proto3" json:"commonName,omitempty"`
+	SubjectAltNames    []string                  `protobuf:"bytes,2,rep,name=subjectAltNames,proto3" json:"subjectAltNames,omitempty"`
+	Chain              []string                  `protobuf:"bytes,3,rep,name=chain,proto3" json:"chain,omitempty"`
+	ValidUntil         *timestamppb.Timestamp    `protobuf:"bytes,4,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	SignatureAlgorithm string                    `protobuf:"bytes,5,opt,name=signatureAlgorithm,proto3" json:"signatureAlgorithm,omitempty"`
+	PublicKeyAlgorithm string                    `protobuf:"bytes,6,opt,name=publicKeyAlgorithm,proto3" json:"publicKeyAlgorithm,omitempty"`
+	Version            string                    `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
+	CipherSuite        string                    `protobuf:"bytes,8,opt,name=cipherSuite,proto3" json:"cipherSuite,omitempty"`
+	Protocol           string                    `protobuf:"bytes,9,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Certificates       []*Detail_TLS_Certificate `protobuf:"bytes,10,rep,name=certificates,proto3" json:"certificates,omitempty"`        // presented chain, leaf first
+	OcspStatus         string                    `protobuf:"bytes,11,opt,name=ocspStatus,proto3" json:"ocspStatus,omitempty"`            // "good", "revoked" or "unknown"; empty unless checked
+	DaysUntilExpiry    int32                     `protobuf:"varint,12,opt,name=daysUntilExpiry,proto3" json:"daysUntilExpiry,omitempty"` // whole days until the leaf certificate expires; negative once expired
+	Alpn               []string                  `protobuf:"bytes,13,rep,name=alpn,proto3" json:"alpn,omitempty"`                        // application protocols offered during the handshake
+	PinMatched         bool                      `protobuf:"varint,14,opt,name=pinMatched,proto3" json:"pinMatched,omitempty"`           // whether the certificate matched a pinned fingerprint; false unless pins are configured
+	KeyBits            int32                     `protobuf:"varint,15,opt,name=keyBits,proto3" json:"keyBits,omitempty"`                 // size of the public key of the leaf certificate
+	PolicyOk           bool                      `protobuf:"varint,16,opt,name=policyOk,proto3" json:"policyOk,omitempty"`               // whether the leaf certificate satisfied the policy; false unless a policy is configured
 }
 
 func (x *Detail_TLS) Reset() {
@@ -130,6 +137,132 @@ func (x *Detail_TLS) GetProtocol() string {
 	return ""
 }
 
+func (x *Detail_TLS) GetCertificates() []*Detail_TLS_Certificate {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+func (x *Detail_TLS) GetOcspStatus() string {
+	if x != nil {
+		return x.OcspStatus
+	}
+	return ""
+}
+
+func (x *Detail_TLS) GetDaysUntilExpiry() int32 {
+	if x != nil {
+		return x.DaysUntilExpiry
+	}
+	return 0
+}
+
+func (x *Detail_TLS) GetAlpn() []string {
+	if x != nil {
+		return x.Alpn
+	}
+	return nil
+}
+
+func (x *Detail_TLS) GetPinMatched() bool {
+	if x != nil {
+		return x.PinMatched
+	}
+	return false
+}
+
+func (x *Detail_TLS) GetKeyBits() int32 {
+	if x != nil {
+		return x.KeyBits
+	}
+	return 0
+}
+
+func (x *Detail_TLS) GetPolicyOk() bool {
+	if x != nil {
+		return x.PolicyOk
+	}
+	return false
+}
+
+type Detail_TLS_Certificate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subject   string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Issuer    string                 `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	NotBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=notBefore,proto3" json:"notBefore,omitempty"`
+	NotAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=notAfter,proto3" json:"notAfter,omitempty"`
+	IsCA      bool                   `protobuf:"varint,5,opt,name=isCA,proto3" json:"isCA,omitempty"`
+}
+
+func (x *Detail_TLS_Certificate) Reset() {
+	*x = Detail_TLS_Certificate{}
+	mi := &file_proto_detail_tls_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Detail_TLS_Certificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Detail_TLS_Certificate) ProtoMessage() {}
+
+func (x *Detail_TLS_Certificate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_detail_tls_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Detail_TLS_Certificate.ProtoReflect.Descriptor instead.
+func (*Detail_TLS_Certificate) Descriptor() ([]byte, []int) {
+	return file_proto_detail_tls_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Detail_TLS_Certificate) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Detail_TLS_Certificate) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Detail_TLS_Certificate) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+func (x *Detail_TLS_Certificate) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+func (x *Detail_TLS_Certificate) GetIsCA() bool {
+	if x != nil {
+		return x.IsCA
+	}
+	return false
+}
+
 var File_proto_detail_tls_proto protoreflect.FileDescriptor
 
 var file_proto_detail_tls_proto_rawDesc = []byte{
@@ -138,7 +271,7 @@ var file_proto_detail_tls_proto_rawDesc = []byte{
 	0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
 	0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe0, 0x02, 0x0a, 0x0a, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb3, 0x06, 0x0a, 0x0a, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f,
 	0x54, 0x4c, 0x53, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x4e,
 	0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x41, 0x6c,
@@ -160,12 +293,41 @@ var file_proto_detail_tls_proto_rawDesc = []byte{
 	0x68, 0x65, 0x72, 0x53, 0x75, 0x69, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
 	0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x53, 0x75, 0x69, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x2f, 0x70,
-	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x70,
-	0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x55, 0x0a, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e,
+	0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x2e,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x5f, 0x54, 0x4c, 0x53, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x52, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x6f, 0x63, 0x73, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6f, 0x63, 0x73, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28,
+	0x0a, 0x0f, 0x64, 0x61, 0x79, 0x73, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x45, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x64, 0x61, 0x79, 0x73, 0x55, 0x6e, 0x74,
+	0x69, 0x6c, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x6c, 0x70, 0x6e,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x6c, 0x70, 0x6e, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x70, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6b, 0x65, 0x79, 0x42, 0x69, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6b,
+	0x65, 0x79, 0x42, 0x69, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x4f, 0x6b, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x4f, 0x6b, 0x1a, 0xc5, 0x01, 0x0a, 0x0b, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73,
+	0x73, 0x75, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x6e, 0x6f, 0x74, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x36,
+	0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6e, 0x6f,
+	0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x73, 0x43, 0x41, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x69, 0x73, 0x43, 0x41, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x73, 0x6f, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2d, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x68,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x2f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -180,18 +342,22 @@ func file_proto_detail_tls_proto_rawDescGZIP() []byte {
 	return file_proto_detail_tls_proto_rawDescData
 }
 
-var file_proto_detail_tls_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_detail_tls_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_proto_detail_tls_proto_goTypes = []any{
-	(*Detail_TLS)(nil),            // 0: platform_health.detail.v1.Detail_TLS
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*Detail_TLS)(nil),             // 0: platform_health.detail.v1.Detail_TLS
+	(*Detail_TLS_Certificate)(nil), // 1: platform_health.detail.v1.Detail_TLS.Certificate
+	(*timestamppb.Timestamp)(nil),  // 2: google.protobuf.Timestamp
 }
 var file_proto_detail_tls_proto_depIdxs = []int32{
-	1, // 0: platform_health.detail.v1.Detail_TLS.validUntil:type_name -> google.protobuf.Timestamp
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 0: platform_health.detail.v1.Detail_TLS.validUntil:type_name -> google.protobuf.Timestamp
+	1, // 1: platform_health.detail.v1.Detail_TLS.certificates:type_name -> platform_health.detail.v1.Detail_TLS.Certificate
+	2, // 2: platform_health.detail.v1.Detail_TLS.Certificate.notBefore:type_name -> google.protobuf.Timestamp
+	2, // 3: platform_health.detail.v1.Detail_TLS.Certificate.notAfter:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_proto_detail_tls_proto_init() }
@@ -205,7 +371,7 @@ func file_proto_detail_tls_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_detail_tls_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -31,6 +31,12 @@ func (s *HealthCheckResponse) Unhealthy(msg string) *HealthCheckResponse {
 	return s
 }
 
+// Warn records a non-fatal anomaly on the component without affecting its status.
+func (s *HealthCheckResponse) Warn(msg string) *HealthCheckResponse {
+	s.Warnings = append(s.Warnings, msg)
+	return s
+}
+
 func (s *HealthCheckResponse) IsHealthy() error {
 	if s.Status != Status_HEALTHY {
 		return &UnhealthyError{}
@@ -57,6 +63,7 @@ func (s *HealthCheckResponse) Flatten(parent string) (components []*HealthCheckR
 				Message:  s.Message,
 				Details:  s.Details,
 				Duration: s.Duration,
+				Warnings: s.Warnings,
 			})
 		}
 	}
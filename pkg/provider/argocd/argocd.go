@@ -0,0 +1,178 @@
+package argocd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypeArgoCD = "argocd"
+
+type ArgoCD struct {
+	Name         string        `mapstructure:"name"`
+	URL          string        `mapstructure:"url"`
+	Application  string        `mapstructure:"application"`
+	Project      string        `mapstructure:"project"`
+	Token        string        `mapstructure:"token"`
+	SyncStatus   string        `mapstructure:"syncStatus" default:"Synced"`
+	HealthStatus string        `mapstructure:"healthStatus" default:"Healthy"`
+	Timeout      time.Duration `mapstructure:"timeout" default:"5s"`
+	Insecure     bool          `mapstructure:"insecure"`
+}
+
+// application is the subset of the Argo CD Application resource returned by /api/v1/applications/{name}
+type application struct {
+	Status struct {
+		Sync struct {
+			Status   string `json:"status"`
+			Revision string `json:"revision"`
+		} `json:"sync"`
+		Health struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"health"`
+	} `json:"status"`
+}
+
+var certPool *x509.CertPool = nil
+
+func init() {
+	provider.Register(TypeArgoCD, new(ArgoCD))
+	if systemCertPool, err := x509.SystemCertPool(); err == nil {
+		certPool = systemCertPool
+	}
+}
+
+func (i *ArgoCD) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("url", i.URL),
+		slog.String("application", i.Application),
+		slog.String("syncStatus", i.SyncStatus),
+		slog.String("healthStatus", i.HealthStatus),
+		slog.Any("timeout", i.Timeout),
+		slog.Bool("insecure", i.Insecure),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *ArgoCD) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *ArgoCD) GetType() string {
+	return TypeArgoCD
+}
+
+func (i *ArgoCD) GetName() string {
+	return i.Name
+}
+
+func (i *ArgoCD) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *ArgoCD) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypeArgoCD), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypeArgoCD,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	app, err := i.fetchApplication(ctx)
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	if i.SyncStatus != "" && app.Status.Sync.Status != i.SyncStatus {
+		return component.Unhealthy(fmt.Sprintf("sync status is %s; expected %s", app.Status.Sync.Status, i.SyncStatus))
+	}
+
+	if i.HealthStatus != "" && app.Status.Health.Status != i.HealthStatus {
+		msg := fmt.Sprintf("health status is %s; expected %s", app.Status.Health.Status, i.HealthStatus)
+		if app.Status.Health.Message != "" {
+			msg = fmt.Sprintf("%s: %s", msg, app.Status.Health.Message)
+		}
+		return component.Unhealthy(msg)
+	}
+
+	return component.Healthy()
+}
+
+// fetchApplication queries the Argo CD API for the configured application
+func (i *ArgoCD) fetchApplication(ctx context.Context) (*application, error) {
+	endpoint, err := url.JoinPath(i.URL, "api/v1/applications", url.PathEscape(i.Application))
+	if err != nil {
+		return nil, err
+	}
+	if i.Project != "" {
+		endpoint += "?" + url.Values{"project": {i.Project}}.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if i.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+i.Token)
+	}
+
+	tlsConf := &tls.Config{
+		ServerName: request.URL.Hostname(),
+		RootCAs:    certPool,
+	}
+	if i.Insecure {
+		tlsConf.InsecureSkipVerify = true
+	}
+	client := &http.Client{
+		Timeout:   i.Timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConf},
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("argocd returned status %d", response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	app := &application{}
+	if err := json.Unmarshal(body, app); err != nil {
+		return nil, fmt.Errorf("invalid argocd response: %w", err)
+	}
+	if strings.TrimSpace(app.Status.Sync.Status) == "" {
+		return nil, fmt.Errorf("application %q has no sync status", i.Application)
+	}
+
+	return app, nil
+}
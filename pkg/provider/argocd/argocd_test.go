@@ -0,0 +1,133 @@
+package argocd_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider/argocd"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+func TestArgoCD(t *testing.T) {
+	applications := map[string]string{
+		"synced":      `{"metadata":{"name":"synced"},"status":{"sync":{"status":"Synced","revision":"abc123"},"health":{"status":"Healthy"}}}`,
+		"out-of-sync": `{"metadata":{"name":"out-of-sync"},"status":{"sync":{"status":"OutOfSync","revision":"abc123"},"health":{"status":"Healthy"}}}`,
+		"degraded":    `{"metadata":{"name":"degraded"},"status":{"sync":{"status":"Synced","revision":"abc123"},"health":{"status":"Degraded","message":"Deployment exceeded its progress deadline"}}}`,
+		"malformed":   `{"status":`,
+	}
+
+	tests := []struct {
+		name         string
+		application  string
+		token        string
+		syncStatus   string
+		healthStatus string
+		expected     ph.Status
+		message      string
+	}{
+		{
+			name:        "Synced and healthy",
+			application: "synced",
+			expected:    ph.Status_HEALTHY,
+		},
+		{
+			name:        "Out of sync",
+			application: "out-of-sync",
+			expected:    ph.Status_UNHEALTHY,
+			message:     "sync status is OutOfSync; expected Synced",
+		},
+		{
+			name:        "Degraded",
+			application: "degraded",
+			expected:    ph.Status_UNHEALTHY,
+			message:     "health status is Degraded; expected Healthy: Deployment exceeded its progress deadline",
+		},
+		{
+			name:         "Expected out of sync",
+			application:  "out-of-sync",
+			syncStatus:   "OutOfSync",
+			healthStatus: "Healthy",
+			expected:     ph.Status_HEALTHY,
+		},
+		{
+			name:        "Missing application",
+			application: "missing",
+			expected:    ph.Status_UNHEALTHY,
+			message:     "argocd returned status 404",
+		},
+		{
+			name:        "Unauthorized",
+			application: "synced",
+			token:       "wrong-token",
+			expected:    ph.Status_UNHEALTHY,
+			message:     "argocd returned status 401",
+		},
+		{
+			name:        "Malformed response",
+			application: "malformed",
+			expected:    ph.Status_UNHEALTHY,
+		},
+	}
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer test-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				var name string
+				if _, err := fmt.Sscanf(r.URL.Path, "/api/v1/applications/%s", &name); err != nil {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				app, ok := applications[name]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(app))
+			}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := "test-token"
+			if tt.token != "" {
+				token = tt.token
+			}
+
+			instance := &argocd.ArgoCD{
+				Name:         "TestArgoCD",
+				URL:          server.URL,
+				Application:  tt.application,
+				Token:        token,
+				SyncStatus:   tt.syncStatus,
+				HealthStatus: tt.healthStatus,
+				Timeout:      time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, argocd.TypeArgoCD, result.GetType())
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+// Cached wraps an instance with the duration for which its last response is
+// served instead of rechecking it. The cache belongs to the wrapper, so it is
+// discarded along with the instance when the configuration is reloaded.
+type Cached struct {
+	Instance
+	TTL time.Duration
+
+	mu        sync.Mutex
+	response  *ph.HealthCheckResponse
+	checkedAt time.Time
+	inflight  chan struct{}
+}
+
+// GetHealth returns a copy of the cached response, annotated with when it was
+// checked, while it is younger than TTL, and otherwise checks the wrapped
+// instance. Concurrent callers share a single check, each waiting only until
+// its own ctx is done. Responses of checks interrupted by ctx are not cached.
+func (c *Cached) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	for {
+		c.mu.Lock()
+		if c.response != nil && time.Since(c.checkedAt) < c.TTL {
+			response := c.hit(ctx)
+			c.mu.Unlock()
+			return response
+		}
+		inflight := c.inflight
+		if inflight == nil {
+			break
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-inflight:
+		case <-ctx.Done():
+			return &ph.HealthCheckResponse{
+				Type:    c.GetType(),
+				Name:    c.GetName(),
+				Status:  ph.Status_UNKNOWN,
+				Message: "timeout waiting for cached check",
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	c.inflight = done
+	c.mu.Unlock()
+
+	checkedAt := time.Now()
+	response := c.Instance.GetHealth(ctx)
+
+	c.mu.Lock()
+	if response != nil && ctx.Err() == nil {
+		c.response = proto.Clone(response).(*ph.HealthCheckResponse)
+		c.checkedAt = checkedAt
+	}
+	c.inflight = nil
+	c.mu.Unlock()
+	close(done)
+
+	return response
+}
+
+// hit returns a copy of the cached response with cache details, such that
+// callers cannot modify the cached response. The caller must hold c.mu.
+func (c *Cached) hit(ctx context.Context) *ph.HealthCheckResponse {
+	utils.ContextLogger(ctx).Debug("serving cached response",
+		slog.String("provider", c.GetType()),
+		slog.String("name", c.GetName()),
+		slog.Any("age", time.Since(c.checkedAt).Round(time.Millisecond)),
+	)
+
+	response := proto.Clone(c.response).(*ph.HealthCheckResponse)
+	detail := &details.Detail_Cache{
+		CheckedAt: timestamppb.New(c.checkedAt),
+		Ttl:       durationpb.New(c.TTL),
+	}
+	if anyDetail, err := anypb.New(detail); err == nil {
+		response.Details = append(response.Details, anyDetail)
+	}
+	return response
+}
+
+// CacheTTL returns the duration for which the responses of instance are
+// cached, or 0 if they are not.
+func CacheTTL(instance Instance) time.Duration {
+	for ; instance != nil; instance = unwrap(instance) {
+		if c, ok := instance.(*Cached); ok {
+			return c.TTL
+		}
+	}
+	return 0
+}
+
+func (c *Cached) GetTimeout() time.Duration {
+	if i, ok := c.Instance.(InstanceWithTimeout); ok {
+		return i.GetTimeout()
+	}
+	return 0
+}
+
+func (c *Cached) LogValue() slog.Value {
+	if v, ok := c.Instance.(slog.LogValuer); ok {
+		return v.LogValue()
+	}
+	return slog.AnyValue(c.Instance)
+}
@@ -0,0 +1,106 @@
+package provider_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+)
+
+func TestCached(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		wait    time.Duration
+		cancel  bool
+		checked int32
+		cached  bool
+	}{
+		{
+			name:    "Fresh",
+			ttl:     time.Minute,
+			checked: 1,
+			cached:  true,
+		},
+		{
+			name:    "Expired",
+			ttl:     10 * time.Millisecond,
+			wait:    20 * time.Millisecond,
+			checked: 2,
+		},
+		{
+			name:    "CancelledNotCached",
+			ttl:     time.Minute,
+			cancel:  true,
+			checked: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &flakyInstance{Mock: mock.Mock{Name: tt.name, Health: ph.Status_HEALTHY}}
+			cached := &provider.Cached{Instance: instance, TTL: tt.ttl}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancel {
+				cancel()
+			}
+			first := cached.GetHealth(ctx)
+			cancel()
+			require.NotNil(t, first)
+			first.Message = "modified"
+
+			time.Sleep(tt.wait)
+
+			second := cached.GetHealth(context.Background())
+			require.NotNil(t, second)
+			assert.Equal(t, tt.checked, instance.checks.Load())
+			assert.Equal(t, ph.Status_HEALTHY, second.GetStatus())
+			assert.Empty(t, second.GetMessage())
+
+			if !tt.cached {
+				assert.Empty(t, second.Details)
+				return
+			}
+			require.Len(t, second.Details, 1)
+			detail := &details.Detail_Cache{}
+			require.NoError(t, second.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.ttl, detail.Ttl.AsDuration())
+			assert.WithinDuration(t, time.Now(), detail.CheckedAt.AsTime(), time.Second)
+		})
+	}
+}
+
+func TestCachedConcurrent(t *testing.T) {
+	instance := &flakyInstance{Mock: mock.Mock{Name: "slow", Health: ph.Status_HEALTHY, Sleep: 50 * time.Millisecond}}
+	cached := &provider.Cached{Instance: instance, TTL: time.Minute}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, ph.Status_HEALTHY, cached.GetHealth(context.Background()).GetStatus())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), instance.checks.Load())
+
+	// Callers waiting on a shared check give up once their own context is done
+	expired := &provider.Cached{Instance: &flakyInstance{Mock: mock.Mock{Name: "slow", Health: ph.Status_HEALTHY, Sleep: 50 * time.Millisecond}}, TTL: time.Minute}
+	go expired.GetHealth(context.Background())
+	time.Sleep(10 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	response := expired.GetHealth(ctx)
+	assert.Equal(t, ph.Status_UNKNOWN, response.GetStatus())
+	assert.Equal(t, "timeout waiting for cached check", response.GetMessage())
+}
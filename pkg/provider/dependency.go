@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+// InstanceWithDependencies is implemented by instances that depend on sibling instances.
+type InstanceWithDependencies interface {
+	// GetDependencies returns the names of the instances that must be healthy before the instance is checked
+	GetDependencies() []string
+}
+
+// Dependent wraps an instance with the names of the sibling instances it depends on.
+type Dependent struct {
+	Instance
+	DependsOn []string
+}
+
+func (d *Dependent) GetDependencies() []string {
+	return d.DependsOn
+}
+
+func (d *Dependent) GetTimeout() time.Duration {
+	if i, ok := d.Instance.(InstanceWithTimeout); ok {
+		return i.GetTimeout()
+	}
+	return 0
+}
+
+func (d *Dependent) LogValue() slog.Value {
+	if v, ok := d.Instance.(slog.LogValuer); ok {
+		return v.LogValue()
+	}
+	return slog.AnyValue(d.Instance)
+}
+
+func dependencies(instance Instance) []string {
+	if i, ok := instance.(InstanceWithDependencies); ok {
+		return i.GetDependencies()
+	}
+	return nil
+}
+
+// annotateDependencies appends the dependencies of instance, if any, to the
+// details of its response, such that clients can draw the dependency graph.
+func annotateDependencies(instance Instance, response *ph.HealthCheckResponse) {
+	dependsOn := dependencies(instance)
+	if response == nil || len(dependsOn) == 0 {
+		return
+	}
+	if detail, err := anypb.New(&details.Detail_Dependency{DependsOn: dependsOn}); err == nil {
+		response.Details = append(response.Details, detail)
+	}
+}
+
+// SortByDependencies returns instances ordered such that every instance follows
+// the instances it depends on, preserving the original order where possible.
+// An error is returned if a dependency is unknown or dependencies form a cycle.
+func SortByDependencies(instances []Instance) ([]Instance, error) {
+	byName := make(map[string][]int)
+	for n, instance := range instances {
+		byName[instance.GetName()] = append(byName[instance.GetName()], n)
+	}
+
+	pending := make([]int, len(instances))
+	dependents := make([][]int, len(instances))
+	for n, instance := range instances {
+		for _, dependency := range dependencies(instance) {
+			indices, ok := byName[dependency]
+			if !ok {
+				return nil, fmt.Errorf("%s/%s depends on unknown instance %q", instance.GetType(), instance.GetName(), dependency)
+			}
+			for _, index := range indices {
+				pending[n]++
+				dependents[index] = append(dependents[index], n)
+			}
+		}
+	}
+
+	sorted := make([]Instance, 0, len(instances))
+	done := make([]bool, len(instances))
+	for len(sorted) < len(instances) {
+		progress := false
+		for n, instance := range instances {
+			if done[n] || pending[n] > 0 {
+				continue
+			}
+			done[n] = true
+			progress = true
+			sorted = append(sorted, instance)
+			for _, dependent := range dependents[n] {
+				pending[dependent]--
+			}
+		}
+		if !progress {
+			var cycle []string
+			for n, instance := range instances {
+				if !done[n] {
+					cycle = append(cycle, fmt.Sprintf("%s/%s", instance.GetType(), instance.GetName()))
+				}
+			}
+			slices.Sort(cycle)
+			return nil, fmt.Errorf("dependency cycle between %s", strings.Join(cycle, ", "))
+		}
+	}
+
+	return sorted, nil
+}
+
+// Select returns the instances identified by component, either by name or as
+// type/name, together with the instances they transitively depend on, in their
+// original order. An error is returned if no instance matches.
+func Select(instances []Instance, component string) ([]Instance, error) {
+	selected := make([]bool, len(instances))
+	var queue []int
+	for n, instance := range instances {
+		if component == instance.GetName() || component == instance.GetType()+"/"+instance.GetName() {
+			selected[n] = true
+			queue = append(queue, n)
+		}
+	}
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("unknown component %q", component)
+	}
+
+	for len(queue) > 0 {
+		instance := instances[queue[0]]
+		queue = queue[1:]
+		for _, dependency := range dependencies(instance) {
+			for n, candidate := range instances {
+				if !selected[n] && candidate.GetName() == dependency {
+					selected[n] = true
+					queue = append(queue, n)
+				}
+			}
+		}
+	}
+
+	result := make([]Instance, 0, len(instances))
+	for n, instance := range instances {
+		if selected[n] {
+			result = append(result, instance)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,242 @@
+package provider_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+)
+
+// countingInstance records how many times it has been checked
+type countingInstance struct {
+	mock.Mock
+	checks atomic.Int32
+}
+
+func (i *countingInstance) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	i.checks.Add(1)
+	return i.Mock.GetHealth(ctx)
+}
+
+func dependent(instance provider.Instance, dependsOn ...string) provider.Instance {
+	return &provider.Dependent{Instance: instance, DependsOn: dependsOn}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		db       ph.Status
+		app      ph.Status
+		message  string
+		checked  int32
+		expected ph.Status
+	}{
+		{
+			name:     "DependencyHealthy",
+			db:       ph.Status_HEALTHY,
+			app:      ph.Status_HEALTHY,
+			checked:  1,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "DependencyUnhealthy",
+			db:       ph.Status_UNHEALTHY,
+			app:      ph.Status_UNKNOWN,
+			message:  "skipped: dependency db unhealthy",
+			checked:  0,
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &countingInstance{Mock: mock.Mock{Name: "app", Health: ph.Status_HEALTHY, Sleep: 1}}
+			instances := []provider.Instance{
+				dependent(app, "db"),
+				&mock.Mock{Name: "db", Health: tt.db, Sleep: 1},
+			}
+
+			response, status := provider.Check(context.Background(), instances)
+			assert.Equal(t, tt.expected, status)
+			assert.Equal(t, tt.checked, app.checks.Load())
+
+			require.Len(t, response, 2)
+			for _, component := range response {
+				if component.GetName() == "app" {
+					assert.Equal(t, tt.app, component.GetStatus())
+					assert.Equal(t, tt.message, component.GetMessage())
+
+					require.Len(t, component.Details, 1)
+					detail := &details.Detail_Dependency{}
+					require.NoError(t, component.Details[0].UnmarshalTo(detail))
+					assert.Equal(t, []string{"db"}, detail.DependsOn)
+				} else {
+					assert.Empty(t, component.Details)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckTransitiveDependencies(t *testing.T) {
+	instances := []provider.Instance{
+		dependent(&mock.Mock{Name: "frontend", Health: ph.Status_HEALTHY, Sleep: 1}, "app"),
+		dependent(&mock.Mock{Name: "app", Health: ph.Status_HEALTHY, Sleep: 1}, "db"),
+		&mock.Mock{Name: "db", Health: ph.Status_UNHEALTHY, Sleep: 1},
+	}
+
+	response, _ := provider.Check(context.Background(), instances)
+	messages := make(map[string]string)
+	for _, component := range response {
+		messages[component.GetName()] = component.GetMessage()
+	}
+
+	assert.Equal(t, "skipped: dependency db unhealthy", messages["app"])
+	assert.Equal(t, "skipped: dependency app unknown", messages["frontend"])
+}
+
+func TestSortByDependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		instances []provider.Instance
+		expected  []string
+		err       string
+	}{
+		{
+			name: "NoDependencies",
+			instances: []provider.Instance{
+				&mock.Mock{Name: "a"},
+				&mock.Mock{Name: "b"},
+			},
+			expected: []string{"a", "b"},
+		},
+		{
+			name: "Chain",
+			instances: []provider.Instance{
+				dependent(&mock.Mock{Name: "frontend"}, "app"),
+				dependent(&mock.Mock{Name: "app"}, "db"),
+				&mock.Mock{Name: "db"},
+			},
+			expected: []string{"db", "app", "frontend"},
+		},
+		{
+			name: "UnknownDependency",
+			instances: []provider.Instance{
+				dependent(&mock.Mock{Name: "app"}, "db"),
+			},
+			err: `mock/app depends on unknown instance "db"`,
+		},
+		{
+			name: "Cycle",
+			instances: []provider.Instance{
+				dependent(&mock.Mock{Name: "a"}, "b"),
+				dependent(&mock.Mock{Name: "b"}, "a"),
+				&mock.Mock{Name: "c"},
+			},
+			err: "dependency cycle between mock/a, mock/b",
+		},
+		{
+			name: "SelfDependency",
+			instances: []provider.Instance{
+				dependent(&mock.Mock{Name: "a"}, "a"),
+			},
+			err: "dependency cycle between mock/a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := provider.SortByDependencies(tt.instances)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(sorted))
+			for _, instance := range sorted {
+				names = append(names, instance.GetName())
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestCheckCycle(t *testing.T) {
+	instances := []provider.Instance{
+		dependent(&mock.Mock{Name: "a", Health: ph.Status_HEALTHY, Sleep: 1}, "b"),
+		dependent(&mock.Mock{Name: "b", Health: ph.Status_HEALTHY, Sleep: 1}, "a"),
+		&mock.Mock{Name: "c", Health: ph.Status_HEALTHY, Sleep: 1},
+	}
+
+	response, status := provider.Check(context.Background(), instances)
+	assert.Len(t, response, 3)
+	assert.Equal(t, ph.Status_HEALTHY, status)
+	for _, component := range response {
+		if component.GetName() != "c" {
+			assert.Equal(t, ph.Status_UNKNOWN, component.GetStatus())
+			assert.Equal(t, "dependency cycle between mock/a, mock/b", component.GetMessage())
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	instances := []provider.Instance{
+		dependent(&mock.Mock{Name: "frontend"}, "app"),
+		dependent(&mock.Mock{Name: "app"}, "db"),
+		&mock.Mock{Name: "db"},
+		&mock.Mock{Name: "cache"},
+	}
+
+	tests := []struct {
+		name      string
+		component string
+		expected  []string
+		err       string
+	}{
+		{
+			name:      "ByName",
+			component: "cache",
+			expected:  []string{"cache"},
+		},
+		{
+			name:      "ByTypeAndName",
+			component: "mock/db",
+			expected:  []string{"db"},
+		},
+		{
+			name:      "WithDependencies",
+			component: "frontend",
+			expected:  []string{"frontend", "app", "db"},
+		},
+		{
+			name:      "Unknown",
+			component: "tcp/db",
+			err:       `unknown component "tcp/db"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := provider.Select(instances, tt.component)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(selected))
+			for _, instance := range selected {
+				names = append(names, instance.GetName())
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypeDNS = "dns"
+
+type DNS struct {
+	Name           string        `mapstructure:"name"`
+	Host           string        `mapstructure:"host"`
+	Type           string        `mapstructure:"type" default:"A"`
+	Expected       []string      `mapstructure:"expected"`
+	Resolver       string        `mapstructure:"resolver"`
+	Propagation    bool          `mapstructure:"propagation"`
+	Zone           string        `mapstructure:"zone"`
+	Port           int           `mapstructure:"port" default:"53"`
+	MaxConcurrency int           `mapstructure:"maxConcurrency" default:"8"`
+	Timeout        time.Duration `mapstructure:"timeout" default:"5s"`
+	Detail         bool          `mapstructure:"detail"`
+}
+
+func init() {
+	provider.Register(TypeDNS, new(DNS))
+}
+
+func (i *DNS) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("host", i.Host),
+		slog.String("type", i.Type),
+		slog.Any("expected", i.Expected),
+		slog.String("resolver", i.Resolver),
+		slog.Bool("propagation", i.Propagation),
+		slog.Int("maxConcurrency", i.MaxConcurrency),
+		slog.Any("timeout", i.Timeout),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *DNS) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *DNS) GetType() string {
+	return TypeDNS
+}
+
+func (i *DNS) GetName() string {
+	return i.Name
+}
+
+func (i *DNS) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *DNS) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypeDNS), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypeDNS,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	detail := &details.Detail_DNS{
+		Host: i.Host,
+		Type: strings.ToUpper(i.Type),
+	}
+
+	if i.Propagation {
+		servers, err := i.authoritativeServers(ctx)
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		detail.Answers = i.queryAll(ctx, servers)
+		detail.Propagated = propagated(detail.Answers, i.Expected)
+	} else {
+		answer := &details.Detail_DNS_Answer{Server: i.Resolver}
+		if records, err := lookup(ctx, newResolver(i.Resolver), detail.Type, i.Host); err != nil {
+			answer.Error = err.Error()
+		} else {
+			answer.Records = records
+		}
+		detail.Answers = []*details.Detail_DNS_Answer{answer}
+	}
+
+	if i.Detail {
+		if anyDetail, err := anypb.New(detail); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, anyDetail)
+		}
+	}
+
+	if i.Propagation {
+		if !detail.Propagated {
+			return component.Unhealthy(fmt.Sprintf("not propagated to all of %d authoritative servers", len(detail.Answers)))
+		}
+		return component.Healthy()
+	}
+
+	answer := detail.Answers[0]
+	switch {
+	case answer.Error != "":
+		return component.Unhealthy(answer.Error)
+	case len(answer.Records) == 0:
+		return component.Unhealthy("no records found")
+	case len(i.Expected) > 0 && !sameRecords(answer.Records, i.Expected):
+		return component.Unhealthy(fmt.Sprintf("expected records %v; actual records %v", i.Expected, answer.Records))
+	}
+
+	return component.Healthy()
+}
+
+// authoritativeServers discovers the nameservers for the configured zone, or,
+// when no zone is configured, for the closest enclosing zone of the host.
+func (i *DNS) authoritativeServers(ctx context.Context) ([]string, error) {
+	resolver := newResolver(i.Resolver)
+
+	zones := []string{i.Zone}
+	if i.Zone == "" {
+		zones = parentZones(i.Host)
+	}
+
+	var nameservers []*net.NS
+	var err error
+	for _, zone := range zones {
+		nameservers, err = resolver.LookupNS(ctx, fqdn(zone))
+		if err == nil && len(nameservers) > 0 {
+			break
+		}
+	}
+	if len(nameservers) == 0 {
+		if err == nil {
+			err = errors.New("no authoritative nameservers found")
+		}
+		return nil, err
+	}
+
+	servers := make([]string, 0, len(nameservers))
+	for _, ns := range nameservers {
+		addrs, err := resolver.LookupHost(ctx, ns.Host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve nameserver %s: %w", ns.Host, err)
+		}
+		for _, addr := range addrs {
+			servers = append(servers, net.JoinHostPort(addr, strconv.Itoa(i.Port)))
+		}
+	}
+	slices.Sort(servers)
+
+	return slices.Compact(servers), nil
+}
+
+// queryAll queries each server directly, with at most MaxConcurrency queries
+// in flight, returning answers in server order. Queries still waiting for a
+// slot when the check times out are answered with the context error.
+func (i *DNS) queryAll(ctx context.Context, servers []string) []*details.Detail_DNS_Answer {
+	recordType := strings.ToUpper(i.Type)
+	answers := make([]*details.Detail_DNS_Answer, len(servers))
+	slots := make(chan struct{}, max(i.MaxConcurrency, 1))
+
+	var wg sync.WaitGroup
+	for n, server := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			answer := &details.Detail_DNS_Answer{Server: server}
+			answers[n] = answer
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				answer.Error = ctx.Err().Error()
+				return
+			}
+
+			if records, err := lookup(ctx, newResolver(server), recordType, i.Host); err != nil {
+				answer.Error = err.Error()
+			} else {
+				answer.Records = records
+			}
+		}()
+	}
+	wg.Wait()
+
+	return answers
+}
+
+// propagated reports whether every server answered with the same records,
+// which must match the expected records if any are configured.
+func propagated(answers []*details.Detail_DNS_Answer, expected []string) bool {
+	if len(answers) == 0 {
+		return false
+	}
+
+	reference := expected
+	if len(reference) == 0 {
+		reference = answers[0].Records
+	}
+
+	for _, answer := range answers {
+		if answer.Error != "" || len(answer.Records) == 0 || !sameRecords(answer.Records, reference) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newResolver returns a resolver that sends all queries to server, or the
+// system resolver if server is empty.
+func newResolver(server string) *net.Resolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := &net.Dialer{}
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
+
+func lookup(ctx context.Context, resolver *net.Resolver, recordType, host string) (records []string, err error) {
+	host = fqdn(host)
+
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			records = append(records, ip.String())
+		}
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, cname)
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			records = append(records, fmt.Sprintf("%d %s", mx.Pref, mx.Host))
+		}
+	case "NS":
+		nss, err := resolver.LookupNS(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			records = append(records, ns.Host)
+		}
+	case "TXT":
+		if records, err = resolver.LookupTXT(ctx, host); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+
+	slices.Sort(records)
+	return records, nil
+}
+
+func sameRecords(actual, expected []string) bool {
+	normalize := func(records []string) []string {
+		normalized := make([]string, 0, len(records))
+		for _, record := range records {
+			normalized = append(normalized, strings.ToLower(strings.TrimSuffix(record, ".")))
+		}
+		slices.Sort(normalized)
+		return slices.Compact(normalized)
+	}
+
+	return slices.Equal(normalize(actual), normalize(expected))
+}
+
+// parentZones returns the candidate zones enclosing host, closest first.
+func parentZones(host string) (zones []string) {
+	labels := strings.Split(strings.TrimSuffix(host, "."), ".")
+	for n := range labels {
+		zones = append(zones, strings.Join(labels[n:], "."))
+	}
+	return zones
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
@@ -0,0 +1,358 @@
+package dns_test
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/dns"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+type zone map[string][]dnsmessage.ResourceBody
+
+func key(name string, recordType dnsmessage.Type) string {
+	return name + " " + recordType.String()
+}
+
+func a(ip string) *dnsmessage.AResource {
+	return &dnsmessage.AResource{A: [4]byte(net.ParseIP(ip).To4())}
+}
+
+func ns(host string) *dnsmessage.NSResource {
+	return &dnsmessage.NSResource{NS: dnsmessage.MustNewName(host)}
+}
+
+// inflight tracks the number of queries being answered concurrently across
+// servers, each answer being delayed to let concurrent queries overlap.
+type inflight struct {
+	mu      sync.Mutex
+	delay   time.Duration
+	current int
+	peak    int
+}
+
+func (f *inflight) answer(respond func()) {
+	f.mu.Lock()
+	f.current++
+	f.peak = max(f.peak, f.current)
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+	respond()
+
+	f.mu.Lock()
+	f.current--
+	f.mu.Unlock()
+}
+
+// serve runs a minimal authoritative DNS server on addr, answering from z.
+func serve(t *testing.T, addr string, z zone) string {
+	return serveTracked(t, addr, z, nil)
+}
+
+func serveTracked(t *testing.T, addr string, z zone, tracker *inflight) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, peer, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var parser dnsmessage.Parser
+			header, err := parser.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			question, err := parser.Question()
+			if err != nil {
+				continue
+			}
+
+			builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true})
+			_ = builder.StartQuestions()
+			_ = builder.Question(question)
+			_ = builder.StartAnswers()
+			for _, body := range z[key(question.Name.String(), question.Type)] {
+				rh := dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}
+				switch body := body.(type) {
+				case *dnsmessage.AResource:
+					_ = builder.AResource(rh, *body)
+				case *dnsmessage.NSResource:
+					_ = builder.NSResource(rh, *body)
+				}
+			}
+			msg, err := builder.Finish()
+			if err != nil {
+				continue
+			}
+			if tracker != nil {
+				tracker.answer(func() { _, _ = conn.WriteTo(msg, peer) })
+			} else {
+				_, _ = conn.WriteTo(msg, peer)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// serveAuthoritative starts one authoritative server per answer on distinct
+// loopback addresses sharing a port, returning the port and a discovery
+// resolver delegating example.test to them.
+func serveAuthoritative(t *testing.T, answers []string) (port int, resolver string) {
+	return serveAuthoritativeTracked(t, answers, nil)
+}
+
+func serveAuthoritativeTracked(t *testing.T, answers []string, tracker *inflight) (port int, resolver string) {
+	t.Helper()
+
+	discovery := zone{}
+	for n, answer := range answers {
+		host := "ns" + strconv.Itoa(n+1) + ".example.test."
+		ip := "127.0.0." + strconv.Itoa(n+1)
+
+		addr := serveTracked(t, net.JoinHostPort(ip, strconv.Itoa(port)), zone{
+			key("www.example.test.", dnsmessage.TypeA): {a(answer)},
+		}, tracker)
+		if port == 0 {
+			_, portStr, _ := net.SplitHostPort(addr)
+			port, _ = strconv.Atoi(portStr)
+		}
+
+		discovery[key("example.test.", dnsmessage.TypeNS)] = append(discovery[key("example.test.", dnsmessage.TypeNS)], ns(host))
+		discovery[key(host, dnsmessage.TypeA)] = []dnsmessage.ResourceBody{a(ip)}
+	}
+
+	return port, serve(t, "127.0.0.1:0", discovery)
+}
+
+func TestDNS(t *testing.T) {
+	tests := []struct {
+		name     string
+		recType  string
+		expected []string
+		status   ph.Status
+	}{
+		{
+			name:   "Record present",
+			status: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Expected record",
+			expected: []string{"192.0.2.1"},
+			status:   ph.Status_HEALTHY,
+		},
+		{
+			name:     "Unexpected record",
+			expected: []string{"192.0.2.2"},
+			status:   ph.Status_UNHEALTHY,
+		},
+		{
+			name:    "Unsupported type",
+			recType: "SRV",
+			status:  ph.Status_UNHEALTHY,
+		},
+	}
+
+	resolver := serve(t, "127.0.0.1:0", zone{
+		key("www.example.test.", dnsmessage.TypeA): {a("192.0.2.1")},
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &dns.DNS{
+				Name:     "TestDNS",
+				Host:     "www.example.test",
+				Type:     tt.recType,
+				Expected: tt.expected,
+				Resolver: resolver,
+				Timeout:  time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, dns.TypeDNS, result.GetType())
+			assert.Equal(t, tt.status, result.GetStatus())
+		})
+	}
+}
+
+func TestDNSPropagation(t *testing.T) {
+	tests := []struct {
+		name       string
+		answers    []string
+		expected   []string
+		propagated bool
+	}{
+		{
+			name:       "All servers updated",
+			answers:    []string{"192.0.2.1", "192.0.2.1", "192.0.2.1"},
+			expected:   []string{"192.0.2.1"},
+			propagated: true,
+		},
+		{
+			name:       "One server stale",
+			answers:    []string{"192.0.2.1", "192.0.2.99", "192.0.2.1"},
+			expected:   []string{"192.0.2.1"},
+			propagated: false,
+		},
+		{
+			name:       "All servers stale",
+			answers:    []string{"192.0.2.99", "192.0.2.99"},
+			expected:   []string{"192.0.2.1"},
+			propagated: false,
+		},
+		{
+			name:       "Servers agree without expectation",
+			answers:    []string{"192.0.2.1", "192.0.2.1"},
+			propagated: true,
+		},
+		{
+			name:       "Servers disagree without expectation",
+			answers:    []string{"192.0.2.1", "192.0.2.99"},
+			propagated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, resolver := serveAuthoritative(t, tt.answers)
+
+			instance := &dns.DNS{
+				Name:        "TestDNSPropagation",
+				Host:        "www.example.test",
+				Expected:    tt.expected,
+				Resolver:    resolver,
+				Propagation: true,
+				Port:        port,
+				Timeout:     time.Second,
+				Detail:      true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_DNS{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+
+			assert.Len(t, detail.Answers, len(tt.answers))
+			assert.Equal(t, tt.propagated, detail.Propagated)
+			if tt.propagated {
+				assert.Equal(t, ph.Status_HEALTHY, result.GetStatus())
+			} else {
+				assert.Equal(t, ph.Status_UNHEALTHY, result.GetStatus())
+			}
+		})
+	}
+}
+
+func TestDNSMaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name           string
+		servers        int
+		maxConcurrency int
+	}{
+		{name: "Serial", servers: 6, maxConcurrency: 1},
+		{name: "Limited", servers: 12, maxConcurrency: 3},
+		{name: "Unconstrained", servers: 4, maxConcurrency: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := make([]string, tt.servers)
+			for n := range answers {
+				answers[n] = "192.0.2.1"
+			}
+
+			tracker := &inflight{delay: 20 * time.Millisecond}
+			port, resolver := serveAuthoritativeTracked(t, answers, tracker)
+
+			instance := &dns.DNS{
+				Name:           "TestDNSMaxConcurrency",
+				Host:           "www.example.test",
+				Expected:       []string{"192.0.2.1"},
+				Resolver:       resolver,
+				Propagation:    true,
+				Port:           port,
+				MaxConcurrency: tt.maxConcurrency,
+				Timeout:        5 * time.Second,
+				Detail:         true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, ph.Status_HEALTHY, result.GetStatus())
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_DNS{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Len(t, detail.Answers, tt.servers)
+			for _, answer := range detail.Answers {
+				assert.Empty(t, answer.Error)
+				assert.Equal(t, []string{"192.0.2.1"}, answer.Records)
+			}
+
+			tracker.mu.Lock()
+			defer tracker.mu.Unlock()
+			assert.LessOrEqual(t, tracker.peak, min(tt.maxConcurrency, tt.servers))
+			assert.Positive(t, tracker.peak)
+		})
+	}
+}
+
+func TestDNSMaxConcurrencyTimeout(t *testing.T) {
+	answers := []string{"192.0.2.1", "192.0.2.1", "192.0.2.1", "192.0.2.1"}
+	tracker := &inflight{delay: 150 * time.Millisecond}
+	port, resolver := serveAuthoritativeTracked(t, answers, tracker)
+
+	instance := &dns.DNS{
+		Name:           "TestDNSMaxConcurrencyTimeout",
+		Host:           "www.example.test",
+		Resolver:       resolver,
+		Propagation:    true,
+		Port:           port,
+		MaxConcurrency: 1,
+		Timeout:        250 * time.Millisecond,
+		Detail:         true,
+	}
+	instance.SetDefaults()
+
+	start := time.Now()
+	result := instance.GetHealth(context.Background())
+	require.NotNil(t, result)
+
+	// queued queries must not extend the check beyond its timeout
+	assert.Less(t, time.Since(start), 2*instance.Timeout)
+	assert.Equal(t, ph.Status_UNHEALTHY, result.GetStatus())
+
+	require.Len(t, result.Details, 1)
+	detail := &details.Detail_DNS{}
+	require.NoError(t, result.Details[0].UnmarshalTo(detail))
+	assert.Len(t, detail.Answers, len(answers))
+}
@@ -0,0 +1,256 @@
+package featureflag
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	httpProvider "github.com/isometry/platform-health/pkg/provider/http"
+	tlsProvider "github.com/isometry/platform-health/pkg/provider/tls"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypeFeatureFlag = "featureflag"
+
+const (
+	FormatUnleash = "unleash"
+	FormatOFREP   = "ofrep"
+)
+
+type FeatureFlag struct {
+	Name       string            `mapstructure:"name"`
+	URL        string            `mapstructure:"url"`
+	Format     string            `mapstructure:"format" default:"unleash"`
+	Headers    map[string]string `mapstructure:"headers"`
+	Flags      []Flag            `mapstructure:"flags"`
+	Timeout    time.Duration     `mapstructure:"timeout" default:"5s"`
+	Insecure   bool              `mapstructure:"insecure"`
+	ClientCert string            `mapstructure:"clientCert"`
+	ClientKey  string            `mapstructure:"clientKey"`
+	CACert     string            `mapstructure:"caCert"`
+	Detail     bool              `mapstructure:"detail"`
+}
+
+type Flag struct {
+	Name    string `mapstructure:"name"`
+	Enabled bool   `mapstructure:"enabled"`
+}
+
+// unleashFeatures is the response of the Unleash client API (/api/client/features)
+type unleashFeatures struct {
+	Features []struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+	} `json:"features"`
+}
+
+// ofrepFlags is the response of the OpenFeature Remote Evaluation Protocol bulk evaluation API (/ofrep/v1/evaluate/flags)
+type ofrepFlags struct {
+	Flags []struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	} `json:"flags"`
+}
+
+func init() {
+	provider.Register(TypeFeatureFlag, new(FeatureFlag))
+}
+
+func (i *FeatureFlag) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("url", i.URL),
+		slog.String("format", i.Format),
+		slog.Any("flags", i.Flags),
+		slog.Any("timeout", i.Timeout),
+		slog.Bool("insecure", i.Insecure),
+		slog.Bool("detail", i.Detail),
+	}
+	if i.ClientCert != "" {
+		logAttr = append(logAttr, slog.Bool("clientCert", true))
+	}
+	if i.CACert != "" {
+		logAttr = append(logAttr, slog.Bool("caCert", true))
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *FeatureFlag) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *FeatureFlag) GetType() string {
+	return TypeFeatureFlag
+}
+
+func (i *FeatureFlag) GetName() string {
+	return i.Name
+}
+
+func (i *FeatureFlag) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *FeatureFlag) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypeFeatureFlag), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypeFeatureFlag,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	flags, connection, err := i.fetchFlags(ctx)
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	if i.Detail && connection != nil {
+		if detail, err := anypb.New(tlsProvider.Detail(connection)); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, detail)
+		}
+	}
+
+	if i.Detail {
+		if detail, err := anypb.New(i.detail(flags)); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, detail)
+		}
+	}
+
+	for _, flag := range i.Flags {
+		enabled, ok := flags[flag.Name]
+		if !ok {
+			return component.Unhealthy(fmt.Sprintf("flag %q not found", flag.Name))
+		}
+		if enabled != flag.Enabled {
+			return component.Unhealthy(fmt.Sprintf("flag %q is %s; expected %s", flag.Name, state(enabled), state(flag.Enabled)))
+		}
+	}
+
+	return component.Healthy()
+}
+
+// fetchFlags queries the flag service, returning the enabled state of each
+// flag and the state of the TLS connection, if any
+func (i *FeatureFlag) fetchFlags(ctx context.Context) (map[string]bool, *tls.ConnectionState, error) {
+	var request *http.Request
+	var err error
+	switch i.Format {
+	case FormatUnleash:
+		request, err = http.NewRequestWithContext(ctx, http.MethodGet, i.URL, nil)
+	case FormatOFREP:
+		request, err = http.NewRequestWithContext(ctx, http.MethodPost, i.URL, bytes.NewBufferString(`{"context":{}}`))
+		if err == nil {
+			request.Header.Set("Content-Type", "application/json")
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported format %q", i.Format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	for key, value := range i.Headers {
+		request.Header.Set(key, value)
+	}
+
+	tlsConf, err := i.httpClient().TLSConfig(request.URL.Hostname())
+	if err != nil {
+		return nil, nil, err
+	}
+	client := &http.Client{
+		Timeout:   i.Timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConf},
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, nil, errors.New(httpProvider.ErrorMessage(err))
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("flag service returned status %d", response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flags := make(map[string]bool)
+	switch i.Format {
+	case FormatUnleash:
+		var features unleashFeatures
+		if err := json.Unmarshal(body, &features); err != nil {
+			return nil, nil, fmt.Errorf("invalid flag service response: %w", err)
+		}
+		for _, feature := range features.Features {
+			flags[feature.Name] = feature.Enabled
+		}
+	case FormatOFREP:
+		var evaluated ofrepFlags
+		if err := json.Unmarshal(body, &evaluated); err != nil {
+			return nil, nil, fmt.Errorf("invalid flag service response: %w", err)
+		}
+		for _, flag := range evaluated.Flags {
+			flags[flag.Key] = flag.Value == true
+		}
+	}
+
+	return flags, response.TLS, nil
+}
+
+// httpClient returns an http provider instance carrying the TLS settings of i,
+// such that the flag service is reached exactly as the http provider would.
+func (i *FeatureFlag) httpClient() *httpProvider.HTTP {
+	return &httpProvider.HTTP{
+		Insecure:   i.Insecure,
+		ClientCert: i.ClientCert,
+		ClientKey:  i.ClientKey,
+		CACert:     i.CACert,
+	}
+}
+
+// detail lists the state of every flag reported by the flag service, by name
+func (i *FeatureFlag) detail(flags map[string]bool) *details.Detail_FeatureFlag {
+	detail := &details.Detail_FeatureFlag{Format: i.Format}
+	for name, enabled := range flags {
+		detail.Flags = append(detail.Flags, &details.Detail_FeatureFlag_Flag{Name: name, Enabled: enabled})
+	}
+	slices.SortFunc(detail.Flags, func(a, b *details.Detail_FeatureFlag_Flag) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return detail
+}
+
+func state(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
@@ -0,0 +1,290 @@
+package featureflag_test
+
+import (
+	"context"
+	"encoding/pem"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/featureflag"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+func TestFeatureFlag(t *testing.T) {
+	const (
+		unleashResponse = `{"version":2,"features":[{"name":"new-checkout","enabled":true},{"name":"legacy-search","enabled":false}]}`
+		ofrepResponse   = `{"flags":[{"key":"new-checkout","value":true,"reason":"STATIC"},{"key":"legacy-search","value":false,"reason":"DISABLED"}]}`
+	)
+
+	tests := []struct {
+		name     string
+		format   string
+		status   int
+		response string
+		flags    []featureflag.Flag
+		expected ph.Status
+	}{
+		{
+			name:     "Unleash service available",
+			format:   featureflag.FormatUnleash,
+			status:   http.StatusOK,
+			response: unleashResponse,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Unleash enabled flag",
+			format:   featureflag.FormatUnleash,
+			status:   http.StatusOK,
+			response: unleashResponse,
+			flags:    []featureflag.Flag{{Name: "new-checkout", Enabled: true}},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Unleash disabled flag",
+			format:   featureflag.FormatUnleash,
+			status:   http.StatusOK,
+			response: unleashResponse,
+			flags:    []featureflag.Flag{{Name: "legacy-search", Enabled: false}},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Unleash flag not in expected state",
+			format:   featureflag.FormatUnleash,
+			status:   http.StatusOK,
+			response: unleashResponse,
+			flags:    []featureflag.Flag{{Name: "legacy-search", Enabled: true}},
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Unleash missing flag",
+			format:   featureflag.FormatUnleash,
+			status:   http.StatusOK,
+			response: unleashResponse,
+			flags:    []featureflag.Flag{{Name: "unknown", Enabled: true}},
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "OFREP enabled and disabled flags",
+			format:   featureflag.FormatOFREP,
+			status:   http.StatusOK,
+			response: ofrepResponse,
+			flags: []featureflag.Flag{
+				{Name: "new-checkout", Enabled: true},
+				{Name: "legacy-search", Enabled: false},
+			},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "OFREP flag not in expected state",
+			format:   featureflag.FormatOFREP,
+			status:   http.StatusOK,
+			response: ofrepResponse,
+			flags:    []featureflag.Flag{{Name: "new-checkout", Enabled: false}},
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Service failure",
+			format:   featureflag.FormatUnleash,
+			status:   http.StatusInternalServerError,
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Unauthorized",
+			format:   featureflag.FormatUnleash,
+			status:   http.StatusUnauthorized,
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Malformed response",
+			format:   featureflag.FormatUnleash,
+			status:   http.StatusOK,
+			response: `{"features":`,
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Unsupported format",
+			format:   "launchdarkly",
+			status:   http.StatusOK,
+			response: unleashResponse,
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						if r.Header.Get("Authorization") != "test-token" {
+							w.WriteHeader(http.StatusUnauthorized)
+							return
+						}
+						w.Header().Set("Content-Type", "application/json")
+						w.WriteHeader(tt.status)
+						w.Write([]byte(tt.response))
+					}))
+			defer server.Close()
+
+			headers := map[string]string{"Authorization": "test-token"}
+			if tt.status == http.StatusUnauthorized {
+				headers = nil
+			}
+
+			instance := &featureflag.FeatureFlag{
+				Name:    "TestFeatureFlag",
+				URL:     server.URL,
+				Format:  tt.format,
+				Headers: headers,
+				Flags:   tt.flags,
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, featureflag.TypeFeatureFlag, result.GetType())
+			assert.Equal(t, tt.expected, result.GetStatus())
+		})
+	}
+}
+
+func TestFeatureFlagDetail(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"version":2,"features":[{"name":"new-checkout","enabled":true},{"name":"legacy-search","enabled":false}]}`))
+			}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		detail   bool
+		flags    []featureflag.Flag
+		expected ph.Status
+	}{
+		{
+			name:     "Detail disabled",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Detail enabled",
+			detail:   true,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Detail of unhealthy flags",
+			detail:   true,
+			flags:    []featureflag.Flag{{Name: "legacy-search", Enabled: true}},
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &featureflag.FeatureFlag{
+				Name:    "TestFeatureFlagDetail",
+				URL:     server.URL,
+				Flags:   tt.flags,
+				Detail:  tt.detail,
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if !tt.detail {
+				assert.Empty(t, result.GetDetails())
+				return
+			}
+			require.Len(t, result.GetDetails(), 1)
+			detail := &details.Detail_FeatureFlag{}
+			require.NoError(t, result.GetDetails()[0].UnmarshalTo(detail))
+			assert.Equal(t, featureflag.FormatUnleash, detail.GetFormat())
+			assert.Equal(t, []string{"legacy-search", "new-checkout"}, []string{detail.GetFlags()[0].GetName(), detail.GetFlags()[1].GetName()})
+			assert.Equal(t, []bool{false, true}, []bool{detail.GetFlags()[0].GetEnabled(), detail.GetFlags()[1].GetEnabled()})
+		})
+	}
+}
+
+func TestFeatureFlagTLS(t *testing.T) {
+	server := httptest.NewTLSServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"features":[{"name":"new-checkout","enabled":true}]}`))
+			}))
+	defer server.Close()
+
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	tests := []struct {
+		name     string
+		caCert   string
+		insecure bool
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Trusted CA certificate",
+			caCert:   caCert,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Unknown authority",
+			expected: ph.Status_UNHEALTHY,
+			message:  "unknown authority",
+		},
+		{
+			name:     "Insecure",
+			insecure: true,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Invalid CA certificate",
+			caCert:   "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n",
+			expected: ph.Status_UNHEALTHY,
+			message:  "failed to load CA certificate: no certificates found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &featureflag.FeatureFlag{
+				Name:     "TestFeatureFlagTLS",
+				URL:      server.URL,
+				Flags:    []featureflag.Flag{{Name: "new-checkout", Enabled: true}},
+				CACert:   tt.caCert,
+				Insecure: tt.insecure,
+				Detail:   true,
+				Timeout:  time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			if tt.expected == ph.Status_HEALTHY {
+				require.Len(t, result.GetDetails(), 2)
+				assert.True(t, result.GetDetails()[0].MessageIs(&details.Detail_TLS{}))
+				assert.True(t, result.GetDetails()[1].MessageIs(&details.Detail_FeatureFlag{}))
+			}
+		})
+	}
+}
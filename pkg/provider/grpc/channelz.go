@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+var errChannelzDisabled = errors.New("channelz service not enabled on target")
+
+// channelzStats queries the target's channelz service for the call and socket
+// statistics of each of its servers.
+func channelzStats(ctx context.Context, conn *grpc.ClientConn) (*details.Detail_Channelz, error) {
+	client := channelz.NewChannelzClient(conn)
+
+	detail := &details.Detail_Channelz{}
+	for start := int64(0); ; {
+		response, err := client.GetServers(ctx, &channelz.GetServersRequest{StartServerId: start})
+		if err != nil {
+			if status.Code(err) == codes.Unimplemented {
+				return nil, errChannelzDisabled
+			}
+			return nil, fmt.Errorf("channelz: %w", err)
+		}
+
+		for _, server := range response.GetServer() {
+			data := server.GetData()
+			stats := &details.Detail_Channelz_Server{
+				Id:             server.GetRef().GetServerId(),
+				Name:           server.GetRef().GetName(),
+				CallsStarted:   data.GetCallsStarted(),
+				CallsSucceeded: data.GetCallsSucceeded(),
+				CallsFailed:    data.GetCallsFailed(),
+				ActiveCalls:    data.GetCallsStarted() - data.GetCallsSucceeded() - data.GetCallsFailed(),
+			}
+
+			sockets, err := countServerSockets(ctx, client, stats.Id)
+			if err != nil {
+				return nil, fmt.Errorf("channelz: %w", err)
+			}
+			stats.Sockets = sockets
+
+			detail.Servers = append(detail.Servers, stats)
+			start = stats.Id + 1
+		}
+
+		if response.GetEnd() || len(response.GetServer()) == 0 {
+			return detail, nil
+		}
+	}
+}
+
+func countServerSockets(ctx context.Context, client channelz.ChannelzClient, serverID int64) (count int64, err error) {
+	for start := int64(0); ; {
+		response, err := client.GetServerSockets(ctx, &channelz.GetServerSocketsRequest{ServerId: serverID, StartSocketId: start})
+		if err != nil {
+			return 0, err
+		}
+
+		refs := response.GetSocketRef()
+		count += int64(len(refs))
+		if response.GetEnd() || len(refs) == 0 {
+			return count, nil
+		}
+		start = refs[len(refs)-1].GetSocketId() + 1
+	}
+}
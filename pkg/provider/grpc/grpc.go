@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
 	"github.com/isometry/platform-health/pkg/provider"
@@ -29,6 +30,9 @@ type GRPC struct {
 	TLS      bool          `mapstructure:"tls" default:"false"`
 	Insecure bool          `mapstructure:"insecure" default:"false"`
 	Timeout  time.Duration `mapstructure:"timeout" default:"1s"`
+	Channelz bool          `mapstructure:"channelz"`
+	Samples  int           `mapstructure:"samples"`
+	MaxP95   time.Duration `mapstructure:"maxP95"`
 }
 
 func init() {
@@ -41,6 +45,9 @@ func (i *GRPC) LogValue() slog.Value {
 		slog.String("host", i.Host),
 		slog.Int("port", i.Port),
 		slog.Any("timeout", i.Timeout),
+		slog.Bool("channelz", i.Channelz),
+		slog.Int("samples", i.Samples),
+		slog.Any("maxP95", i.MaxP95),
 	}
 	return slog.GroupValue(logAttr...)
 }
@@ -57,6 +64,10 @@ func (i *GRPC) GetName() string {
 	return i.Name
 }
 
+func (i *GRPC) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
 func (i *GRPC) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	log := utils.ContextLogger(ctx, slog.String("provider", TypeGRPC), slog.Any("instance", i))
 	log.Debug("checking")
@@ -108,5 +119,32 @@ func (i *GRPC) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 		return component.Unhealthy(response.Status.String())
 	}
 
+	if i.Samples > 0 {
+		latency, err := sampleLatency(ctx, client, request, i.Samples)
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		if detail, err := anypb.New(latency); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, detail)
+		}
+		if p95 := latency.GetP95().AsDuration(); i.MaxP95 > 0 && p95 > i.MaxP95 {
+			return component.Unhealthy(fmt.Sprintf("p95 latency %s exceeds %s", p95.Round(time.Microsecond), i.MaxP95))
+		}
+	}
+
+	if i.Channelz {
+		stats, err := channelzStats(ctx, conn)
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		if detail, err := anypb.New(stats); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, detail)
+		}
+	}
+
 	return component.Healthy()
 }
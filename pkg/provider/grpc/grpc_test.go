@@ -5,13 +5,17 @@ import (
 	"log/slog"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/service"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	provider_grpc "github.com/isometry/platform-health/pkg/provider/grpc"
 )
 
@@ -96,3 +100,187 @@ func TestGetHealth(t *testing.T) {
 		})
 	}
 }
+
+// serve starts a gRPC server with a SERVING health service, optionally
+// registering the channelz service, and returns its port.
+func serve(t *testing.T, withChannelz bool) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	if withChannelz {
+		channelz.RegisterChannelzServiceToServer(server)
+	}
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestChannelz(t *testing.T) {
+	tests := []struct {
+		name     string
+		channelz bool
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "ChannelzEnabled",
+			channelz: true,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "ChannelzDisabled",
+			channelz: false,
+			expected: ph.Status_UNHEALTHY,
+			message:  "channelz service not enabled on target",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &provider_grpc.GRPC{
+				Name:     "test",
+				Host:     "localhost",
+				Port:     serve(t, tt.channelz),
+				Channelz: true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.Status)
+			assert.Equal(t, tt.message, result.Message)
+
+			if !tt.channelz {
+				assert.Empty(t, result.Details)
+				return
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_Channelz{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+
+			require.NotEmpty(t, detail.Servers)
+			var started, sockets int64
+			for _, server := range detail.Servers {
+				started += server.CallsStarted
+				sockets += server.Sockets
+			}
+			// the health check precedes the channelz query on the same connection
+			assert.Positive(t, started)
+			assert.Positive(t, sockets)
+		})
+	}
+}
+
+// delayedHealth delays each health check by a fixed duration before
+// reporting SERVING.
+type delayedHealth struct {
+	grpc_health_v1.UnimplementedHealthServer
+	delay time.Duration
+}
+
+func (h *delayedHealth) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	select {
+	case <-time.After(h.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func TestLatency(t *testing.T) {
+	const delay = 20 * time.Millisecond
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, &delayedHealth{delay: delay})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		name     string
+		samples  int
+		maxP95   time.Duration
+		timeout  time.Duration
+		expected ph.Status
+		detail   bool
+	}{
+		{
+			name:     "Sampled",
+			samples:  10,
+			timeout:  5 * time.Second,
+			expected: ph.Status_HEALTHY,
+			detail:   true,
+		},
+		{
+			name:     "WithinThreshold",
+			samples:  5,
+			maxP95:   time.Second,
+			timeout:  5 * time.Second,
+			expected: ph.Status_HEALTHY,
+			detail:   true,
+		},
+		{
+			name:     "ExceedsThreshold",
+			samples:  5,
+			maxP95:   time.Millisecond,
+			timeout:  5 * time.Second,
+			expected: ph.Status_UNHEALTHY,
+			detail:   true,
+		},
+		{
+			name:     "SamplesExceedTimeout",
+			samples:  50,
+			timeout:  200 * time.Millisecond,
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &provider_grpc.GRPC{
+				Name:    "test",
+				Host:    "localhost",
+				Port:    port,
+				Samples: tt.samples,
+				MaxP95:  tt.maxP95,
+				Timeout: tt.timeout,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.Status)
+
+			if !tt.detail {
+				assert.Empty(t, result.Details)
+				return
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_Latency{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+
+			assert.EqualValues(t, tt.samples, detail.Samples)
+			p50, p95, maximum := detail.P50.AsDuration(), detail.P95.AsDuration(), detail.Max.AsDuration()
+			assert.GreaterOrEqual(t, p50, delay)
+			assert.GreaterOrEqual(t, p95, p50)
+			assert.GreaterOrEqual(t, maximum, p95)
+			assert.Less(t, maximum, tt.timeout)
+
+			if tt.expected == ph.Status_UNHEALTHY {
+				assert.Contains(t, result.Message, "p95 latency")
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+// sampleLatency issues count sequential health checks, returning the latency
+// percentiles of the calls. Sampling fails if any call fails or does not
+// report SERVING, or if the check times out before all samples are taken.
+func sampleLatency(ctx context.Context, client grpc_health_v1.HealthClient, request *grpc_health_v1.HealthCheckRequest, count int) (*details.Detail_Latency, error) {
+	latencies := make([]time.Duration, 0, count)
+	for range count {
+		start := time.Now()
+		response, err := client.Check(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("sample %d of %d: %w", len(latencies)+1, count, err)
+		}
+		if response.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			return nil, fmt.Errorf("sample %d of %d: %s", len(latencies)+1, count, response.GetStatus())
+		}
+		latencies = append(latencies, time.Since(start))
+	}
+	slices.Sort(latencies)
+
+	return &details.Detail_Latency{
+		Samples: int32(len(latencies)),
+		P50:     durationpb.New(percentile(latencies, 0.50)),
+		P95:     durationpb.New(percentile(latencies, 0.95)),
+		Max:     durationpb.New(latencies[len(latencies)-1]),
+	}, nil
+}
+
+// percentile returns the nearest-rank percentile p of the sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,11 @@
+package helm
+
+import "context"
+
+// Unready exposes the summary of resources that are not ready for testing
+var Unready = unready
+
+// LatestVersion exposes the repository index lookup for testing
+func (i *Helm) LatestVersion(ctx context.Context, chart string) (string, error) {
+	return i.latestVersion(ctx, chart)
+}
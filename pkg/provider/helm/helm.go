@@ -2,15 +2,20 @@ package helm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/types/known/anypb"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/cli"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	"github.com/isometry/platform-health/pkg/provider"
 	"github.com/isometry/platform-health/pkg/utils"
 )
@@ -18,10 +23,13 @@ import (
 const TypeHelm = "helm"
 
 type Helm struct {
-	Name      string        `mapstructure:"name"`
-	Chart     string        `mapstructure:"chart"`
-	Namespace string        `mapstructure:"namespace"`
-	Timeout   time.Duration `mapstructure:"timeout" default:"5s"`
+	Name           string        `mapstructure:"name"`
+	Chart          string        `mapstructure:"chart"`
+	Namespace      string        `mapstructure:"namespace"`
+	Repository     string        `mapstructure:"repository"`
+	CheckResources bool          `mapstructure:"checkResources"`
+	Timeout        time.Duration `mapstructure:"timeout" default:"5s"`
+	Detail         bool          `mapstructure:"detail"`
 }
 
 func init() {
@@ -33,6 +41,8 @@ func (i *Helm) LogValue() slog.Value {
 		slog.String("name", i.Name),
 		slog.String("chart", i.Chart),
 		slog.String("namespace", i.Namespace),
+		slog.String("repository", i.Repository),
+		slog.Bool("checkResources", i.CheckResources),
 		slog.Any("timeout", i.Timeout),
 	}
 	return slog.GroupValue(logAttr...)
@@ -50,10 +60,17 @@ func (i *Helm) GetName() string {
 	return i.Name
 }
 
+func (i *Helm) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
 func (i *Helm) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	log := utils.ContextLogger(ctx, slog.String("provider", TypeHelm), slog.Any("instance", i))
 	log.Debug("checking")
 
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
 	component := &ph.HealthCheckResponse{
 		Type: TypeHelm,
 		Name: i.Name,
@@ -64,13 +81,17 @@ func (i *Helm) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	client.SetNamespace(i.Namespace)
 	clientgetter := client.RESTClientGetter()
 	actionConfig := new(action.Configuration)
-	if err := actionConfig.Init(clientgetter, i.Namespace, "secret", func(format string, v ...any) { log.Debug(fmt.Sprintf(format, v...)) }); err != nil {
+	debug := func(format string, v ...any) { log.Debug(fmt.Sprintf(format, v...)) }
+	if err := actionConfig.Init(clientgetter, i.Namespace, "secret", debug); err != nil {
 		return component.Unhealthy(err.Error())
 	}
 
 	statusAction := action.NewStatus(actionConfig)
 
-	resultChan := make(chan error)
+	resultChan := make(chan error, 1)
+	var deprecated string
+	var deployed *chart.Metadata
+	var resources []*details.Detail_Helm_Resource
 	go func() {
 		status, err := statusAction.Run(i.Name)
 		if err != nil {
@@ -81,11 +102,27 @@ func (i *Helm) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 			resultChan <- fmt.Errorf("expected status 'deployed'; actual status '%s'", status.Info.Status)
 			return
 		}
+		if status.Chart != nil && status.Chart.Metadata != nil {
+			deployed = status.Chart.Metadata
+			if deployed.Deprecated {
+				deprecated = fmt.Sprintf("chart %s %s is deprecated", deployed.Name, deployed.Version)
+			}
+		}
+		if i.CheckResources {
+			if resources, err = liveStatus(ctx, actionConfig, status.Manifest, debug); err != nil {
+				resultChan <- err
+				return
+			}
+			if msg := unready(resources); msg != "" {
+				resultChan <- errors.New(msg)
+				return
+			}
+		}
 		resultChan <- nil
 	}()
 
 	select {
-	case <-time.After(i.Timeout):
+	case <-ctx.Done():
 		return component.Unhealthy("timeout")
 	case err := <-resultChan:
 		if err != nil {
@@ -93,5 +130,55 @@ func (i *Helm) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 		}
 	}
 
+	if deprecated != "" {
+		component.Warn(deprecated)
+	}
+
+	if deployed == nil {
+		return component.Healthy()
+	}
+
+	detail := &details.Detail_Helm{
+		Chart:     deployed.Name,
+		Version:   deployed.Version,
+		Resources: resources,
+	}
+
+	if i.Repository != "" {
+		latest, err := i.latestVersion(ctx, deployed.Name)
+		if err != nil {
+			// the release itself is deployed, so its health is unknown rather than unhealthy
+			component.Status = ph.Status_UNKNOWN
+			component.Message = fmt.Sprintf("failed to check repository: %v", err)
+			return component
+		}
+		detail.LatestVersion = latest
+		detail.UpgradeAvailable = newer(latest, deployed.Version)
+		if detail.UpgradeAvailable {
+			component.Warn(fmt.Sprintf("chart %s %s is available; deployed %s", deployed.Name, latest, deployed.Version))
+		}
+	}
+
+	if i.Detail {
+		if anyDetail, err := anypb.New(detail); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, anyDetail)
+		}
+	}
+
 	return component.Healthy()
 }
+
+// newer reports whether version latest is greater than current.
+func newer(latest, current string) bool {
+	latestVersion, err := semver.NewVersion(latest)
+	if err != nil {
+		return false
+	}
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return false
+	}
+	return latestVersion.GreaterThan(currentVersion)
+}
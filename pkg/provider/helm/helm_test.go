@@ -0,0 +1,140 @@
+package helm_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/helm"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+const index = `apiVersion: v1
+entries:
+  podinfo:
+    - name: podinfo
+      version: 6.4.0
+    - name: podinfo
+      version: 6.7.1
+    - name: podinfo
+      version: 6.8.0-rc.1
+    - name: podinfo
+      version: 6.5.2
+generated: "2024-01-01T00:00:00Z"
+`
+
+func TestLatestVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/charts/index.yaml":
+			w.Write([]byte(index))
+		case "/invalid/index.yaml":
+			w.Write([]byte("entries: ["))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		repository string
+		chart      string
+		expected   string
+		err        string
+	}{
+		{
+			name:       "Latest stable version",
+			repository: server.URL + "/charts",
+			chart:      "podinfo",
+			expected:   "6.7.1",
+		},
+		{
+			name:       "Trailing slash",
+			repository: server.URL + "/charts/",
+			chart:      "podinfo",
+			expected:   "6.7.1",
+		},
+		{
+			name:       "Unknown chart",
+			repository: server.URL + "/charts",
+			chart:      "missing",
+			err:        "chart missing not found in repository",
+		},
+		{
+			name:       "Missing index",
+			repository: server.URL + "/missing",
+			chart:      "podinfo",
+			err:        "repository returned status 404",
+		},
+		{
+			name:       "Invalid index",
+			repository: server.URL + "/invalid",
+			chart:      "podinfo",
+			err:        "invalid repository index",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &helm.Helm{
+				Name:       "TestLatestVersion",
+				Repository: tt.repository,
+				Timeout:    time.Second,
+			}
+			instance.SetDefaults()
+
+			latest, err := instance.LatestVersion(context.Background(), tt.chart)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, latest)
+		})
+	}
+}
+
+func TestUnready(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*details.Detail_Helm_Resource
+		expected  string
+	}{
+		{
+			name: "All ready",
+			resources: []*details.Detail_Helm_Resource{
+				{Kind: "Deployment", Name: "web", Ready: true},
+				{Kind: "Service", Name: "web", Ready: true},
+			},
+		},
+		{
+			name: "Some not ready",
+			resources: []*details.Detail_Helm_Resource{
+				{Kind: "Deployment", Name: "web", Ready: false},
+				{Kind: "Service", Name: "web", Ready: true},
+				{Kind: "StatefulSet", Name: "db", Ready: false},
+			},
+			expected: "2 of 3 resources not ready: Deployment/web, StatefulSet/db",
+		},
+		{
+			name: "No resources",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, helm.Unready(tt.resources))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+)
+
+// latestVersion fetches the index of the chart repository, returning the
+// latest stable version of chart.
+func (i *Helm) latestVersion(ctx context.Context, chart string) (string, error) {
+	url := strings.TrimSuffix(i.Repository, "/") + "/index.yaml"
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("repository returned status %d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	index := &repo.IndexFile{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return "", fmt.Errorf("invalid repository index: %w", err)
+	}
+	index.SortEntries()
+
+	latest, err := index.Get(chart, "")
+	if err != nil {
+		return "", fmt.Errorf("chart %s not found in repository", chart)
+	}
+
+	return latest.Version, nil
+}
@@ -0,0 +1,57 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/kube"
+
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+// liveStatus checks the live readiness of every resource in the release
+// manifest, as `helm install --wait` would. Resources of kinds without a
+// readiness check are reported ready if they exist.
+func liveStatus(ctx context.Context, actionConfig *action.Configuration, manifest string, log func(string, ...any)) ([]*details.Detail_Helm_Resource, error) {
+	resources, err := actionConfig.KubeClient.Build(strings.NewReader(manifest), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build release resources: %w", err)
+	}
+
+	clientset, err := actionConfig.KubernetesClientSet()
+	if err != nil {
+		return nil, err
+	}
+	checker := kube.NewReadyChecker(clientset, log, kube.PausedAsReady(true), kube.CheckJobs(true))
+
+	live := make([]*details.Detail_Helm_Resource, 0, len(resources))
+	for _, info := range resources {
+		resource := &details.Detail_Helm_Resource{
+			Kind:      info.Mapping.GroupVersionKind.Kind,
+			Namespace: info.Namespace,
+			Name:      info.Name,
+		}
+		if resource.Ready, err = checker.IsReady(ctx, info); err != nil {
+			return nil, fmt.Errorf("failed to check %s/%s: %w", resource.Kind, resource.Name, err)
+		}
+		live = append(live, resource)
+	}
+
+	return live, nil
+}
+
+// unready summarizes the resources that are not ready, if any.
+func unready(resources []*details.Detail_Helm_Resource) string {
+	var names []string
+	for _, resource := range resources {
+		if !resource.Ready {
+			names = append(names, resource.Kind+"/"+resource.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d of %d resources not ready: %s", len(names), len(resources), strings.Join(names, ", "))
+}
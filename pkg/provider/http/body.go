@@ -0,0 +1,53 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxBodySize is the maximum number of bytes of a response body read when no smaller limit is configured
+const maxBodySize = 10 << 20
+
+type responseBody struct {
+	Data      []byte
+	Truncated bool
+}
+
+// readBody reads up to limit bytes of the response body (maxBodySize if limit
+// is unset), recording whether the body was truncated at the limit.
+func readBody(response *http.Response, limit int64) (*responseBody, error) {
+	if limit <= 0 || limit > maxBodySize {
+		limit = maxBodySize
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	body := &responseBody{Data: data}
+	if int64(len(data)) > limit {
+		body.Data = data[:limit]
+		body.Truncated = true
+	}
+
+	return body, nil
+}
+
+// readsBody reports whether any configured check requires the response body.
+func (i *HTTP) readsBody() bool {
+	return i.MinSize > 0 || i.MaxSize > 0 || i.ResponseSchema != ""
+}
+
+// checkSize validates the size of the response body against the configured bounds.
+func (i *HTTP) checkSize(body *responseBody) string {
+	size := int64(len(body.Data))
+	switch {
+	case i.MaxSize > 0 && body.Truncated:
+		return fmt.Sprintf("response size exceeds %d bytes", i.MaxSize)
+	case size < i.MinSize:
+		return fmt.Sprintf("response size %d bytes is less than %d bytes", size, i.MinSize)
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const (
+	ChangeChanged   = "changed"
+	ChangeUnchanged = "unchanged"
+)
+
+// changeState records the validator (ETag or Last-Modified) of the previous
+// response, persisting across checks of the same instance.
+type changeState struct {
+	mu        sync.Mutex
+	validator string
+	seen      bool
+}
+
+// validator returns the response's ETag, or its Last-Modified date if it has no ETag.
+func validator(response *http.Response) (name, value string) {
+	if etag := response.Header.Get("ETag"); etag != "" {
+		return "ETag", etag
+	}
+	if lastModified := response.Header.Get("Last-Modified"); lastModified != "" {
+		return "Last-Modified", lastModified
+	}
+	return "", ""
+}
+
+// checkChange compares the response's validator to that of the previous
+// check. The first check of an instance only records a baseline, and
+// always passes.
+func (i *HTTP) checkChange(response *http.Response) string {
+	if i.Change != ChangeChanged && i.Change != ChangeUnchanged {
+		return fmt.Sprintf("unsupported change mode %q", i.Change)
+	}
+
+	name, value := validator(response)
+	if name == "" {
+		return "response has no ETag or Last-Modified header"
+	}
+
+	i.change.mu.Lock()
+	defer i.change.mu.Unlock()
+
+	previous, seen := i.change.validator, i.change.seen
+	i.change.validator, i.change.seen = value, true
+
+	switch {
+	case !seen:
+		return ""
+	case i.Change == ChangeChanged && value == previous:
+		return fmt.Sprintf("content unchanged: %s %s", name, value)
+	case i.Change == ChangeUnchanged && value != previous:
+		return fmt.Sprintf("content changed: %s %s; previously %s", name, value, previous)
+	}
+
+	return ""
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+// computeDigest computes the digest of the response body, hashing the body
+// already read if any, else streaming up to the body size limit from the
+// response without buffering it.
+func (i *HTTP) computeDigest(response *http.Response, body *responseBody) (*utils.Digest, error) {
+	digest, err := utils.ParseDigest(i.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := i.MaxSize
+	if limit <= 0 || limit > maxBodySize {
+		limit = maxBodySize
+	}
+
+	if body != nil {
+		if body.Truncated {
+			return nil, fmt.Errorf("response size exceeds %d bytes", limit)
+		}
+		digest.Write(body.Data)
+		return digest, nil
+	}
+
+	if _, err := io.Copy(digest, io.LimitReader(response.Body, limit+1)); err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if digest.Size > limit {
+		return nil, fmt.Errorf("response size exceeds %d bytes", limit)
+	}
+
+	return digest, nil
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding lists the content encodings which decodeBody supports.
+const acceptEncoding = "gzip, deflate"
+
+// contentEncoding returns the normalized content encoding of the response,
+// or the empty string if its content is not encoded.
+func contentEncoding(response *http.Response) string {
+	encoding := strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding")))
+	if encoding == "identity" {
+		return ""
+	}
+	return encoding
+}
+
+// decodeBody replaces the body of the response with a reader decoding its
+// content encoding, such that size, schema and digest checks apply to the
+// decoded payload. The original body remains the caller's to close.
+func decodeBody(response *http.Response) error {
+	var decoded io.Reader
+	var err error
+	switch encoding := contentEncoding(response); encoding {
+	case "":
+		return nil
+	case "gzip", "x-gzip":
+		decoded, err = gzip.NewReader(response.Body)
+	case "deflate":
+		decoded, err = zlib.NewReader(response.Body)
+	default:
+		return fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	response.Body = io.NopCloser(decoded)
+	response.ContentLength = -1
+	return nil
+}
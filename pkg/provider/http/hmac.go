@@ -0,0 +1,51 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type HMAC struct {
+	Algorithm string `mapstructure:"algorithm" default:"sha256"`
+	Secret    string `mapstructure:"secret"`
+	Header    string `mapstructure:"header" default:"X-Signature"`
+}
+
+var hmacAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+func (h *HMAC) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("algorithm", h.Algorithm),
+		slog.String("header", h.Header),
+		slog.String("secret", "REDACTED"),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+// Sign sets the signature header to the hex-encoded HMAC of the request method,
+// request URI and body, each separated by a newline.
+func (h *HMAC) Sign(request *http.Request, body []byte) error {
+	newHash, ok := hmacAlgorithms[strings.ToLower(h.Algorithm)]
+	if !ok {
+		return fmt.Errorf("unsupported hmac algorithm %q", h.Algorithm)
+	}
+
+	mac := hmac.New(newHash, []byte(h.Secret))
+	fmt.Fprintf(mac, "%s\n%s\n", request.Method, request.URL.RequestURI())
+	mac.Write(body)
+
+	request.Header.Set(h.Header, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
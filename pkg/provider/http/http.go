@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -15,6 +16,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	"github.com/isometry/platform-health/pkg/provider"
 	tlsProvider "github.com/isometry/platform-health/pkg/provider/tls"
 	"github.com/isometry/platform-health/pkg/utils"
@@ -23,13 +25,29 @@ import (
 const TypeHTTP = "http"
 
 type HTTP struct {
-	Name     string        `mapstructure:"name"`
-	URL      string        `mapstructure:"url"`
-	Method   string        `mapstructure:"method" default:"HEAD"`
-	Timeout  time.Duration `mapstructure:"timeout" default:"10s"`
-	Insecure bool          `mapstructure:"insecure"`
-	Status   []int         `mapstructure:"status" default:"[200]"` // expected status
-	Detail   bool          `mapstructure:"detail"`
+	Name            string        `mapstructure:"name"`
+	URL             string        `mapstructure:"url"`
+	Method          string        `mapstructure:"method" default:"HEAD"`
+	Body            string        `mapstructure:"body"`
+	BodyFile        string        `mapstructure:"bodyFile"`
+	BodyEnv         string        `mapstructure:"bodyEnv"`
+	Timeout         time.Duration `mapstructure:"timeout" default:"10s"`
+	Insecure        bool          `mapstructure:"insecure"`
+	ClientCert      string        `mapstructure:"clientCert"`
+	ClientKey       string        `mapstructure:"clientKey"`
+	CACert          string        `mapstructure:"caCert"`
+	Status          []int         `mapstructure:"status" default:"[200]"` // expected status
+	Detail          bool          `mapstructure:"detail"`
+	HMAC            *HMAC         `mapstructure:"hmac"`
+	MinSize         int64         `mapstructure:"minSize"`
+	MaxSize         int64         `mapstructure:"maxSize"`
+	ResponseSchema  string        `mapstructure:"responseSchema"`
+	Change          string        `mapstructure:"change"`
+	Digest          string        `mapstructure:"digest"`
+	FollowRedirects *bool         `mapstructure:"followRedirects"`
+	MaxRedirects    int           `mapstructure:"maxRedirects" default:"10"`
+
+	change changeState
 }
 
 var certPool *x509.CertPool = nil
@@ -50,11 +68,45 @@ func (i *HTTP) LogValue() slog.Value {
 		slog.Bool("insecure", i.Insecure),
 		slog.Bool("detail", i.Detail),
 	}
+	if i.HMAC != nil {
+		logAttr = append(logAttr, slog.Any("hmac", i.HMAC))
+	}
+	if i.Body != "" {
+		logAttr = append(logAttr, slog.Bool("body", true))
+	}
+	if i.BodyFile != "" {
+		logAttr = append(logAttr, slog.String("bodyFile", i.BodyFile))
+	}
+	if i.BodyEnv != "" {
+		logAttr = append(logAttr, slog.String("bodyEnv", i.BodyEnv))
+	}
+	if i.ClientCert != "" {
+		logAttr = append(logAttr, slog.Bool("clientCert", true))
+	}
+	if i.CACert != "" {
+		logAttr = append(logAttr, slog.Bool("caCert", true))
+	}
+	if i.MinSize > 0 || i.MaxSize > 0 {
+		logAttr = append(logAttr, slog.Int64("minSize", i.MinSize), slog.Int64("maxSize", i.MaxSize))
+	}
+	if i.ResponseSchema != "" {
+		logAttr = append(logAttr, slog.Bool("responseSchema", true))
+	}
+	if i.Change != "" {
+		logAttr = append(logAttr, slog.String("change", i.Change))
+	}
+	if i.Digest != "" {
+		logAttr = append(logAttr, slog.String("digest", i.Digest))
+	}
+	logAttr = append(logAttr, slog.Bool("followRedirects", i.followsRedirects()), slog.Int("maxRedirects", i.MaxRedirects))
 	return slog.GroupValue(logAttr...)
 }
 
 func (i *HTTP) SetDefaults() {
 	defaults.SetDefaults(i)
+	if i.HMAC != nil {
+		defaults.SetDefaults(i.HMAC)
+	}
 }
 
 func (i *HTTP) GetType() string {
@@ -65,6 +117,10 @@ func (i *HTTP) GetName() string {
 	return i.Name
 }
 
+func (i *HTTP) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
 func (i *HTTP) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	log := utils.ContextLogger(ctx, slog.String("provider", TypeHTTP), slog.Any("instance", i))
 	log.Debug("checking")
@@ -78,33 +134,46 @@ func (i *HTTP) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	}
 	defer component.LogStatus(log)
 
-	request, err := http.NewRequestWithContext(ctx, i.Method, i.URL, nil)
+	requestBody, err := i.requestBody()
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	request, err := http.NewRequestWithContext(ctx, i.Method, i.URL, bytes.NewReader(requestBody))
 	if err != nil {
 		log.Error("failed to create request", "error", err.Error())
 		return component.Unhealthy(err.Error())
 	}
 
-	client := &http.Client{Timeout: i.Timeout}
-	tlsConf := &tls.Config{
-		ServerName: request.URL.Hostname(),
-		RootCAs:    certPool,
+	request.Header.Set("Accept-Encoding", acceptEncoding)
+
+	if i.HMAC != nil {
+		if err := i.HMAC.Sign(request, requestBody); err != nil {
+			log.Error("failed to sign request", "error", err.Error())
+			return component.Unhealthy(err.Error())
+		}
+	}
+
+	var redirects []string
+	client := &http.Client{
+		Timeout:       i.Timeout,
+		CheckRedirect: i.checkRedirect(&redirects),
 	}
-	if i.Insecure {
-		tlsConf.InsecureSkipVerify = true
+	tlsConf, err := i.TLSConfig(request.URL.Hostname())
+	if err != nil {
+		return component.Unhealthy(err.Error())
 	}
 	client.Transport = &http.Transport{TLSClientConfig: tlsConf}
 
 	response, err := client.Do(request)
 	if err != nil {
-		switch {
-		case errors.As(err, new(x509.CertificateInvalidError)):
-			return component.Unhealthy("certificate invalid")
-		case errors.As(err, new(x509.HostnameError)):
-			return component.Unhealthy("hostname mismatch")
-		case errors.As(err, new(x509.UnknownAuthorityError)):
-			return component.Unhealthy("unknown authority")
-		default:
-			return component.Unhealthy(err.Error())
+		return component.Unhealthy(ErrorMessage(err))
+	}
+	defer response.Body.Close()
+
+	if i.Insecure && response.TLS != nil {
+		if err := verifyConnection(response.TLS, tlsConf.ServerName, tlsConf.RootCAs); err != nil {
+			component.Warn(fmt.Sprintf("insecure: accepted certificate failing verification: %v", err))
 		}
 	}
 
@@ -116,10 +185,96 @@ func (i *HTTP) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 		}
 	}
 
+	if i.Detail && (len(redirects) > 0 || contentEncoding(response) != "") {
+		if detail, err := anypb.New(&details.Detail_HTTP{Url: response.Request.URL.String(), Redirects: redirects, Encoding: contentEncoding(response)}); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, detail)
+		}
+	}
+
 	if !slices.Contains[[]int, int](i.Status, response.StatusCode) {
 		return component.Unhealthy(fmt.Sprintf("expected status %d; actual status %d", i.Status, response.StatusCode))
 	}
-	_ = response.Body.Close()
+
+	if i.Change != "" {
+		if msg := i.checkChange(response); msg != "" {
+			return component.Unhealthy(msg)
+		}
+	}
+
+	if i.readsBody() || i.Digest != "" {
+		if err := decodeBody(response); err != nil {
+			return component.Unhealthy(err.Error())
+		}
+	}
+
+	var body *responseBody
+	if i.readsBody() {
+		body, err = readBody(response, i.MaxSize)
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		if msg := i.checkSize(body); msg != "" {
+			return component.Unhealthy(msg)
+		}
+		if msg := i.checkSchema(body); msg != "" {
+			return component.Unhealthy(msg)
+		}
+	}
+
+	if i.Digest != "" {
+		digest, err := i.computeDigest(response, body)
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		if i.Detail {
+			if detail, err := anypb.New(&details.Detail_Digest{Algorithm: digest.Algorithm, Digest: digest.Actual(), Size: digest.Size}); err != nil {
+				return component.Unhealthy(err.Error())
+			} else {
+				component.Details = append(component.Details, detail)
+			}
+		}
+		if err := digest.Verify(); err != nil {
+			return component.Unhealthy(err.Error())
+		}
+	}
 
 	return component.Healthy()
 }
+
+// ErrorMessage describes the failure of a request, naming the reason a
+// certificate was rejected rather than the full verification error.
+func ErrorMessage(err error) string {
+	switch {
+	case errors.As(err, new(x509.CertificateInvalidError)):
+		return "certificate invalid"
+	case errors.As(err, new(x509.HostnameError)):
+		return "hostname mismatch"
+	case errors.As(err, new(x509.UnknownAuthorityError)):
+		return "unknown authority"
+	default:
+		return err.Error()
+	}
+}
+
+// verifyConnection verifies the peer certificate chain of an insecure
+// connection against roots as it would have been verified had verification
+// been enabled.
+func verifyConnection(state *tls.ConnectionState, serverName string, roots *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("no peer certificates")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
@@ -1,16 +1,35 @@
 package http_test
 
 import (
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
 	"log/slog"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	httpProvider "github.com/isometry/platform-health/pkg/provider/http"
 )
 
@@ -102,6 +121,920 @@ func TestLocalHTTP(t *testing.T) {
 	}
 }
 
+func TestHMAC(t *testing.T) {
+	const secret = "s3cr3t"
+
+	tests := []struct {
+		name     string
+		hmac     *httpProvider.HMAC
+		expected ph.Status
+	}{
+		{
+			name:     "Correct secret",
+			hmac:     &httpProvider.HMAC{Secret: secret},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Wrong secret",
+			hmac:     &httpProvider.HMAC{Secret: "wrong"},
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Unsupported algorithm",
+			hmac:     &httpProvider.HMAC{Secret: secret, Algorithm: "md5"},
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Unsigned",
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				mac := hmac.New(sha256.New, []byte(secret))
+				mac.Write([]byte(r.Method + "\n" + r.URL.RequestURI() + "\n"))
+				expected := hex.EncodeToString(mac.Sum(nil))
+				if !hmac.Equal([]byte(expected), []byte(r.Header.Get("X-Signature"))) {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &httpProvider.HTTP{
+				Name:   "TestHMAC",
+				URL:    server.URL + "/signed?probe=1",
+				Method: "GET",
+				HMAC:   tt.hmac,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+		})
+	}
+}
+
+func TestRequestBody(t *testing.T) {
+	const credentials = `{"username":"probe","password":"s3cr3t"}`
+
+	bodyFile := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, os.WriteFile(bodyFile, []byte(credentials), 0o600))
+	t.Setenv("TEST_REQUEST_BODY", credentials)
+
+	tests := []struct {
+		name     string
+		body     string
+		bodyFile string
+		bodyEnv  string
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Inline body",
+			body:     credentials,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Body from file",
+			bodyFile: bodyFile,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Body from environment",
+			bodyEnv:  "TEST_REQUEST_BODY",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "No body",
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Missing file",
+			bodyFile: filepath.Join(t.TempDir(), "missing.json"),
+			expected: ph.Status_UNHEALTHY,
+			message:  "failed to read request body",
+		},
+		{
+			name:     "Unset environment variable",
+			bodyEnv:  "TEST_REQUEST_BODY_UNSET",
+			expected: ph.Status_UNHEALTHY,
+			message:  "request body environment variable TEST_REQUEST_BODY_UNSET not set",
+		},
+		{
+			name:     "Multiple sources",
+			body:     credentials,
+			bodyEnv:  "TEST_REQUEST_BODY",
+			expected: ph.Status_UNHEALTHY,
+			message:  "only one of body, bodyFile and bodyEnv may be configured",
+		},
+	}
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != credentials {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &httpProvider.HTTP{
+				Name:     "TestRequestBody",
+				URL:      server.URL + "/login",
+				Method:   "POST",
+				Body:     tt.body,
+				BodyFile: tt.bodyFile,
+				BodyEnv:  tt.bodyEnv,
+				Timeout:  time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Contains(t, result.GetMessage(), tt.message)
+		})
+	}
+}
+
+func TestResponseSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		minSize  int64
+		maxSize  int64
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Empty body without bounds",
+			body:     "",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Empty body with minimum",
+			body:     "",
+			minSize:  1,
+			expected: ph.Status_UNHEALTHY,
+			message:  "response size 0 bytes is less than 1 bytes",
+		},
+		{
+			name:     "Small body within bounds",
+			body:     `{"status":"ok"}`,
+			minSize:  1,
+			maxSize:  1024,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Body exactly at maximum",
+			body:     "0123456789",
+			maxSize:  10,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Large body exceeding maximum",
+			body:     strings.Repeat("x", 4096),
+			minSize:  1,
+			maxSize:  1024,
+			expected: ph.Status_UNHEALTHY,
+			message:  "response size exceeds 1024 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Write([]byte(tt.body))
+					}))
+			defer server.Close()
+
+			instance := &httpProvider.HTTP{
+				Name:    "TestResponseSize",
+				URL:     server.URL,
+				Method:  http.MethodGet,
+				MinSize: tt.minSize,
+				MaxSize: tt.maxSize,
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func TestDigest(t *testing.T) {
+	const (
+		artifact = "platform-health artifact\n"
+		sha256   = "c53f5254ace8f233ecdf726415d27b20d57d24d011155dfb82a188e16eb2ea3a"
+		md5      = "45df4b91279da77d8131d76d5cd38393"
+	)
+
+	tests := []struct {
+		name     string
+		body     string
+		digest   string
+		minSize  int64
+		maxSize  int64
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Matching sha256",
+			body:     artifact,
+			digest:   "sha256:" + sha256,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Matching uppercase md5",
+			body:     artifact,
+			digest:   "MD5:" + strings.ToUpper(md5),
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Matching digest with buffered body",
+			body:     artifact,
+			digest:   "sha256:" + sha256,
+			minSize:  1,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Mismatched digest",
+			body:     "tampered artifact\n",
+			digest:   "sha256:" + sha256,
+			expected: ph.Status_UNHEALTHY,
+			message:  "expected sha256 digest " + sha256 + "; actual c5709634f6c724d5594bcd40689e45f5e36965a7c5e15b57220bddd4f43991d0",
+		},
+		{
+			name:     "Body exceeding maximum",
+			body:     artifact,
+			digest:   "sha256:" + sha256,
+			maxSize:  8,
+			expected: ph.Status_UNHEALTHY,
+			message:  "response size exceeds 8 bytes",
+		},
+		{
+			name:     "Unsupported algorithm",
+			body:     artifact,
+			digest:   "crc32:00000000",
+			expected: ph.Status_UNHEALTHY,
+			message:  `unsupported digest algorithm "crc32"`,
+		},
+		{
+			name:     "Malformed digest",
+			body:     artifact,
+			digest:   sha256,
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Write([]byte(tt.body))
+					}))
+			defer server.Close()
+
+			instance := &httpProvider.HTTP{
+				Name:    "TestDigest",
+				URL:     server.URL,
+				Method:  http.MethodGet,
+				Digest:  tt.digest,
+				MinSize: tt.minSize,
+				MaxSize: tt.maxSize,
+				Timeout: time.Second,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+
+			if len(result.Details) == 0 {
+				assert.NotEqual(t, ph.Status_HEALTHY, result.GetStatus())
+				return
+			}
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_Digest{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.EqualValues(t, len(tt.body), detail.Size)
+			if tt.expected == ph.Status_HEALTHY {
+				assert.Contains(t, []string{sha256, md5}, detail.Digest)
+			}
+		})
+	}
+}
+
+func TestContentEncoding(t *testing.T) {
+	const payload = `{"status":"ok","padding":"` + "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" + `"}`
+	const schema = `{"type":"object","required":["status"],"properties":{"status":{"const":"ok"}}}`
+	sum := sha256.Sum256([]byte(payload))
+
+	encode := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":    func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate": func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+	}
+
+	tests := []struct {
+		name     string
+		encoding string
+		raw      string
+		maxSize  int64
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Identity",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Gzip",
+			encoding: "gzip",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Deflate",
+			encoding: "deflate",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Size limit applies after decoding",
+			encoding: "gzip",
+			maxSize:  int64(len(payload)) - 1,
+			expected: ph.Status_UNHEALTHY,
+			message:  "response size exceeds",
+		},
+		{
+			name:     "Corrupt encoding",
+			encoding: "gzip",
+			raw:      "not gzip",
+			expected: ph.Status_UNHEALTHY,
+			message:  "failed to decode response body",
+		},
+		{
+			name:     "Unsupported encoding",
+			encoding: "br",
+			raw:      payload,
+			expected: ph.Status_UNHEALTHY,
+			message:  `unsupported content encoding "br"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						if tt.encoding != "" {
+							w.Header().Set("Content-Encoding", tt.encoding)
+						}
+						switch {
+						case tt.raw != "":
+							w.Write([]byte(tt.raw))
+						case tt.encoding == "":
+							w.Write([]byte(payload))
+						default:
+							assert.Contains(t, r.Header.Get("Accept-Encoding"), tt.encoding)
+							encoder := encode[tt.encoding](w)
+							encoder.Write([]byte(payload))
+							encoder.Close()
+						}
+					}))
+			defer server.Close()
+
+			instance := &httpProvider.HTTP{
+				Name:           "TestContentEncoding",
+				URL:            server.URL,
+				Method:         "GET",
+				MaxSize:        tt.maxSize,
+				ResponseSchema: schema,
+				Digest:         "sha256:" + hex.EncodeToString(sum[:]),
+				Timeout:        time.Second,
+				Detail:         true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Contains(t, result.GetMessage(), tt.message)
+			if tt.expected != ph.Status_HEALTHY || tt.encoding == "" {
+				return
+			}
+
+			require.Len(t, result.Details, 2)
+			detail := &details.Detail_HTTP{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.encoding, detail.Encoding)
+			assert.Empty(t, detail.Redirects)
+		})
+	}
+}
+
+func TestResponseSchema(t *testing.T) {
+	const schema = `{
+		"type": "object",
+		"required": ["status", "checks"],
+		"properties": {
+			"status": {"type": "string", "enum": ["ok", "degraded"]},
+			"checks": {"type": "integer", "minimum": 1}
+		}
+	}`
+
+	schemaFile := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(schema), 0o600))
+
+	tests := []struct {
+		name       string
+		schema     string
+		body       string
+		expected   ph.Status
+		violations []string
+	}{
+		{
+			name:     "Conforming response",
+			schema:   schema,
+			body:     `{"status":"ok","checks":3}`,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Conforming response with schema file",
+			schema:   schemaFile,
+			body:     `{"status":"degraded","checks":1,"extra":true}`,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:       "Missing required field",
+			schema:     schema,
+			body:       `{"status":"ok"}`,
+			expected:   ph.Status_UNHEALTHY,
+			violations: []string{"checks is required"},
+		},
+		{
+			name:       "Wrong type",
+			schema:     schemaFile,
+			body:       `{"status":"ok","checks":"three"}`,
+			expected:   ph.Status_UNHEALTHY,
+			violations: []string{"checks: Invalid type. Expected: integer, given: string"},
+		},
+		{
+			name:       "Multiple violations",
+			schema:     schema,
+			body:       `{"status":"broken","checks":0}`,
+			expected:   ph.Status_UNHEALTHY,
+			violations: []string{"status: status must be one of the following", "checks: Must be greater than or equal to 1"},
+		},
+		{
+			name:       "Invalid JSON",
+			schema:     schema,
+			body:       `<html></html>`,
+			expected:   ph.Status_UNHEALTHY,
+			violations: []string{"failed to validate response"},
+		},
+		{
+			name:       "Missing schema file",
+			schema:     filepath.Join(t.TempDir(), "missing.json"),
+			body:       `{"status":"ok","checks":3}`,
+			expected:   ph.Status_UNHEALTHY,
+			violations: []string{"failed to read response schema"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Content-Type", "application/json")
+						w.Write([]byte(tt.body))
+					}))
+			defer server.Close()
+
+			instance := &httpProvider.HTTP{
+				Name:           "TestResponseSchema",
+				URL:            server.URL,
+				Method:         http.MethodGet,
+				ResponseSchema: tt.schema,
+				Timeout:        time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			for _, violation := range tt.violations {
+				assert.Contains(t, result.GetMessage(), violation)
+			}
+		})
+	}
+}
+
+func TestChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   string
+		header   string
+		values   []string // validator served on each successive check
+		expected []ph.Status
+		message  string // message of the last check
+	}{
+		{
+			name:     "Changing ETag expected to change",
+			change:   httpProvider.ChangeChanged,
+			header:   "ETag",
+			values:   []string{`"v1"`, `"v2"`, `"v3"`},
+			expected: []ph.Status{ph.Status_HEALTHY, ph.Status_HEALTHY, ph.Status_HEALTHY},
+		},
+		{
+			name:     "Stale ETag expected to change",
+			change:   httpProvider.ChangeChanged,
+			header:   "ETag",
+			values:   []string{`"v1"`, `"v2"`, `"v2"`},
+			expected: []ph.Status{ph.Status_HEALTHY, ph.Status_HEALTHY, ph.Status_UNHEALTHY},
+			message:  `content unchanged: ETag "v2"`,
+		},
+		{
+			name:     "Stable ETag expected to be unchanged",
+			change:   httpProvider.ChangeUnchanged,
+			header:   "ETag",
+			values:   []string{`"v1"`, `"v1"`},
+			expected: []ph.Status{ph.Status_HEALTHY, ph.Status_HEALTHY},
+		},
+		{
+			name:     "Changing Last-Modified expected to be unchanged",
+			change:   httpProvider.ChangeUnchanged,
+			header:   "Last-Modified",
+			values:   []string{"Mon, 01 Jan 2024 00:00:00 GMT", "Tue, 02 Jan 2024 00:00:00 GMT"},
+			expected: []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY},
+			message:  "content changed: Last-Modified Tue, 02 Jan 2024 00:00:00 GMT; previously Mon, 01 Jan 2024 00:00:00 GMT",
+		},
+		{
+			name:     "No validator",
+			change:   httpProvider.ChangeChanged,
+			values:   []string{""},
+			expected: []ph.Status{ph.Status_UNHEALTHY},
+			message:  "response has no ETag or Last-Modified header",
+		},
+		{
+			name:     "Unsupported mode",
+			change:   "sometimes",
+			header:   "ETag",
+			values:   []string{`"v1"`},
+			expected: []ph.Status{ph.Status_UNHEALTHY},
+			message:  `unsupported change mode "sometimes"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := 0
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						if tt.header != "" {
+							w.Header().Set(tt.header, tt.values[check])
+						}
+						check++
+					}))
+			defer server.Close()
+
+			instance := &httpProvider.HTTP{
+				Name:    "TestChange",
+				URL:     server.URL,
+				Change:  tt.change,
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			var result *ph.HealthCheckResponse
+			for n, expected := range tt.expected {
+				result = instance.GetHealth(context.Background())
+				require.NotNil(t, result)
+				assert.Equal(t, expected, result.GetStatus(), "check %d", n+1)
+			}
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func TestInsecureWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   func(http.Handler) *httptest.Server
+		insecure bool
+		warnings int
+	}{
+		{
+			name:     "Untrusted certificate accepted as insecure",
+			server:   httptest.NewTLSServer,
+			insecure: true,
+			warnings: 1,
+		},
+		{
+			name:     "Insecure without TLS",
+			server:   httptest.NewServer,
+			insecure: true,
+		},
+		{
+			name:   "Plain HTTP",
+			server: httptest.NewServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := tt.server(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			defer server.Close()
+
+			instance := &httpProvider.HTTP{
+				Name:     "TestInsecureWarning",
+				URL:      server.URL,
+				Insecure: tt.insecure,
+				Timeout:  time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, ph.Status_HEALTHY, result.GetStatus())
+			assert.Len(t, result.GetWarnings(), tt.warnings)
+			for _, warning := range result.GetWarnings() {
+				assert.Contains(t, warning, "unknown authority")
+			}
+		})
+	}
+}
+
+func TestRedirects(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		follow    *bool
+		max       int
+		status    []int
+		delay     time.Duration
+		expected  ph.Status
+		redirects []string
+	}{
+		{
+			name:     "No redirect",
+			path:     "/final",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:      "Redirects followed",
+			path:      "/first",
+			expected:  ph.Status_HEALTHY,
+			redirects: []string{"/second", "/final"},
+		},
+		{
+			name:     "Redirects not followed",
+			path:     "/first",
+			follow:   new(bool),
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Redirect expected",
+			path:     "/first",
+			follow:   new(bool),
+			status:   []int{http.StatusFound},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Too many redirects",
+			path:     "/first",
+			max:      1,
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Timeout covers redirect chain",
+			path:     "/first",
+			delay:    40 * time.Millisecond,
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/first", func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tt.delay)
+				http.Redirect(w, r, "/second", http.StatusFound)
+			})
+			mux.HandleFunc("/second", func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tt.delay)
+				http.Redirect(w, r, "/final", http.StatusMovedPermanently)
+			})
+			mux.HandleFunc("/final", func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tt.delay)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			instance := &httpProvider.HTTP{
+				Name:            "TestRedirects",
+				URL:             server.URL + tt.path,
+				Status:          tt.status,
+				FollowRedirects: tt.follow,
+				MaxRedirects:    tt.max,
+				Timeout:         100 * time.Millisecond,
+				Detail:          true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.expected != ph.Status_HEALTHY {
+				return
+			}
+
+			if tt.redirects == nil {
+				assert.Empty(t, result.Details)
+				return
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_HTTP{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			for n, path := range tt.redirects {
+				tt.redirects[n] = server.URL + path
+			}
+			assert.Equal(t, tt.redirects, detail.Redirects)
+			assert.Equal(t, server.URL+"/final", detail.Url)
+		})
+	}
+}
+
+// newClientCert returns a self-signed client certificate and key as PEM
+func newClientCert(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "platform-health"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestMTLS(t *testing.T) {
+	clientCert, clientKey := newClientCert(t)
+	otherCert, otherKey := newClientCert(t)
+
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM([]byte(clientCert)))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	tests := []struct {
+		name       string
+		clientCert string
+		clientKey  string
+		caCert     string
+		expected   ph.Status
+		message    string
+	}{
+		{
+			name:       "Inline PEM",
+			clientCert: clientCert,
+			clientKey:  clientKey,
+			caCert:     caCert,
+			expected:   ph.Status_HEALTHY,
+		},
+		{
+			name:       "PEM files",
+			clientCert: writeFile(t, "client.crt", clientCert),
+			clientKey:  writeFile(t, "client.key", clientKey),
+			caCert:     writeFile(t, "ca.crt", caCert),
+			expected:   ph.Status_HEALTHY,
+		},
+		{
+			name:     "No client certificate",
+			caCert:   caCert,
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:       "Untrusted client certificate",
+			clientCert: otherCert,
+			clientKey:  otherKey,
+			caCert:     caCert,
+			expected:   ph.Status_UNHEALTHY,
+		},
+		{
+			name:       "Unknown server authority",
+			clientCert: clientCert,
+			clientKey:  clientKey,
+			expected:   ph.Status_UNHEALTHY,
+			message:    "unknown authority",
+		},
+		{
+			name:       "Mismatched key",
+			clientCert: clientCert,
+			clientKey:  otherKey,
+			caCert:     caCert,
+			expected:   ph.Status_UNHEALTHY,
+			message:    "failed to load client certificate",
+		},
+		{
+			name:       "Missing key",
+			clientCert: clientCert,
+			caCert:     caCert,
+			expected:   ph.Status_UNHEALTHY,
+			message:    "clientCert and clientKey must be configured together",
+		},
+		{
+			name:       "Missing key file",
+			clientCert: clientCert,
+			clientKey:  filepath.Join(t.TempDir(), "missing.key"),
+			caCert:     caCert,
+			expected:   ph.Status_UNHEALTHY,
+			message:    "failed to read client key",
+		},
+		{
+			name:       "Invalid CA certificate",
+			clientCert: clientCert,
+			clientKey:  clientKey,
+			caCert:     "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n",
+			expected:   ph.Status_UNHEALTHY,
+			message:    "failed to load CA certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &httpProvider.HTTP{
+				Name:       "TestMTLS",
+				URL:        server.URL,
+				ClientCert: tt.clientCert,
+				ClientKey:  tt.clientKey,
+				CACert:     tt.caCert,
+				Timeout:    time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Contains(t, result.GetMessage(), tt.message)
+		})
+	}
+}
+
 func TestRemoteHTTP(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLSConfig returns the TLS configuration for requests to serverName,
+// trusting the system certificate pool unless caCert is configured. Other
+// providers speaking HTTP use it to share the TLS settings of this provider.
+func (i *HTTP) TLSConfig(serverName string) (*tls.Config, error) {
+	tlsConf := &tls.Config{
+		ServerName: serverName,
+		RootCAs:    certPool,
+	}
+	if i.Insecure {
+		tlsConf.InsecureSkipVerify = true
+	}
+	if err := i.configureTLS(tlsConf); err != nil {
+		return nil, err
+	}
+	return tlsConf, nil
+}
+
+// configureTLS adds the configured client certificate and certificate
+// authority, if any, to tlsConf.
+func (i *HTTP) configureTLS(tlsConf *tls.Config) error {
+	if i.ClientCert != "" || i.ClientKey != "" {
+		if i.ClientCert == "" || i.ClientKey == "" {
+			return errors.New("clientCert and clientKey must be configured together")
+		}
+		certPEM, err := readPEM(i.ClientCert)
+		if err != nil {
+			return fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(i.ClientKey)
+		if err != nil {
+			return fmt.Errorf("failed to read client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+
+	if i.CACert != "" {
+		caPEM, err := readPEM(i.CACert)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return errors.New("failed to load CA certificate: no certificates found")
+		}
+		tlsConf.RootCAs = pool
+	}
+
+	return nil
+}
+
+// readPEM returns value if it is an inline PEM document, otherwise the
+// contents of the file at the given path.
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// followsRedirects reports whether redirects are followed, which they are
+// unless explicitly disabled.
+func (i *HTTP) followsRedirects() bool {
+	return i.FollowRedirects == nil || *i.FollowRedirects
+}
+
+// checkRedirect returns a redirect policy for the client which records each
+// URL redirected to in redirects. When redirects are not followed, the
+// redirect response itself is returned; otherwise at most MaxRedirects are
+// followed.
+func (i *HTTP) checkRedirect(redirects *[]string) func(*http.Request, []*http.Request) error {
+	return func(request *http.Request, via []*http.Request) error {
+		if !i.followsRedirects() {
+			return http.ErrUseLastResponse
+		}
+		if len(via) > i.MaxRedirects {
+			return fmt.Errorf("stopped after %d redirects", i.MaxRedirects)
+		}
+		*redirects = append(*redirects, request.URL.String())
+		return nil
+	}
+}
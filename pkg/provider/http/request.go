@@ -0,0 +1,41 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// requestBody returns the configured request body, read from the inline body,
+// the file at BodyFile or the environment variable BodyEnv, of which at most
+// one may be configured.
+func (i *HTTP) requestBody() ([]byte, error) {
+	sources := 0
+	for _, source := range []string{i.Body, i.BodyFile, i.BodyEnv} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, errors.New("only one of body, bodyFile and bodyEnv may be configured")
+	}
+
+	switch {
+	case i.BodyFile != "":
+		body, err := os.ReadFile(i.BodyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		return body, nil
+	case i.BodyEnv != "":
+		body, ok := os.LookupEnv(i.BodyEnv)
+		if !ok {
+			return nil, fmt.Errorf("request body environment variable %s not set", i.BodyEnv)
+		}
+		return []byte(body), nil
+	case i.Body != "":
+		return []byte(i.Body), nil
+	default:
+		return nil, nil
+	}
+}
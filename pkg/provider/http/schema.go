@@ -0,0 +1,54 @@
+package http
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// schemaLoader returns a loader for the configured JSON Schema: inline if it
+// is a JSON document, otherwise read from the file at the given path.
+func (i *HTTP) schemaLoader() (gojsonschema.JSONLoader, error) {
+	if strings.HasPrefix(strings.TrimSpace(i.ResponseSchema), "{") {
+		return gojsonschema.NewStringLoader(i.ResponseSchema), nil
+	}
+
+	schema, err := os.ReadFile(i.ResponseSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response schema: %w", err)
+	}
+	return gojsonschema.NewBytesLoader(schema), nil
+}
+
+// checkSchema validates the response body against the configured JSON Schema.
+func (i *HTTP) checkSchema(body *responseBody) string {
+	if i.ResponseSchema == "" {
+		return ""
+	}
+
+	if body.Truncated {
+		return fmt.Sprintf("response exceeds %d bytes; not validated", len(body.Data))
+	}
+
+	schema, err := i.schemaLoader()
+	if err != nil {
+		return err.Error()
+	}
+
+	result, err := gojsonschema.Validate(schema, gojsonschema.NewBytesLoader(body.Data))
+	if err != nil {
+		return fmt.Sprintf("failed to validate response: %v", err)
+	}
+
+	if !result.Valid() {
+		violations := make([]string, 0, len(result.Errors()))
+		for _, violation := range result.Errors() {
+			violations = append(violations, violation.String())
+		}
+		return fmt.Sprintf("response does not match schema: %s", strings.Join(violations, "; "))
+	}
+
+	return ""
+}
@@ -47,6 +47,25 @@ var commonGVToKinds = map[GV][]string{
 		"poddisruptionbudget", "pdb",
 		"podsecuritypolicy", "psp",
 	},
+	{Group: "kustomize.toolkit.fluxcd.io", Version: "v1"}: {
+		"kustomization", "ks",
+	},
+	{Group: "helm.toolkit.fluxcd.io", Version: "v2"}: {
+		"helmrelease", "hr",
+	},
+	{Group: "source.toolkit.fluxcd.io", Version: "v1"}: {
+		"gitrepository", "gitrepo",
+		"helmrepository", "helmrepo",
+		"helmchart",
+		"bucket",
+	},
+	{Group: "source.toolkit.fluxcd.io", Version: "v1beta2"}: {
+		"ocirepository", "ocirepo",
+	},
+	{Group: "argoproj.io", Version: "v1alpha1"}: {
+		"application", "app",
+		"applicationset", "appset",
+	},
 }
 
 func generateOutput(gvToKinds map[GV][]string) map[string]GV {
@@ -3,6 +3,11 @@
 package kubernetes
 
 var commonKindToGV = map[string]GV{
+	"app": {Group: "argoproj.io", Version: "v1alpha1"},
+	"application": {Group: "argoproj.io", Version: "v1alpha1"},
+	"applicationset": {Group: "argoproj.io", Version: "v1alpha1"},
+	"appset": {Group: "argoproj.io", Version: "v1alpha1"},
+	"bucket": {Group: "source.toolkit.fluxcd.io", Version: "v1"},
 	"certificate": {Group: "cert-manager.io", Version: "v1"},
 	"clusterissuer": {Group: "cert-manager.io", Version: "v1"},
 	"cm": {Group: "", Version: "v1"},
@@ -12,15 +17,26 @@ var commonKindToGV = map[string]GV{
 	"deploy": {Group: "apps", Version: "v1"},
 	"deployment": {Group: "apps", Version: "v1"},
 	"ds": {Group: "apps", Version: "v1"},
+	"gitrepo": {Group: "source.toolkit.fluxcd.io", Version: "v1"},
+	"gitrepository": {Group: "source.toolkit.fluxcd.io", Version: "v1"},
+	"helmchart": {Group: "source.toolkit.fluxcd.io", Version: "v1"},
+	"helmrelease": {Group: "helm.toolkit.fluxcd.io", Version: "v2"},
+	"helmrepo": {Group: "source.toolkit.fluxcd.io", Version: "v1"},
+	"helmrepository": {Group: "source.toolkit.fluxcd.io", Version: "v1"},
+	"hr": {Group: "helm.toolkit.fluxcd.io", Version: "v2"},
 	"ingress": {Group: "networking.k8s.io", Version: "v1"},
 	"ingressclass": {Group: "networking.k8s.io", Version: "v1"},
 	"issuer": {Group: "cert-manager.io", Version: "v1"},
 	"job": {Group: "batch", Version: "v1"},
+	"ks": {Group: "kustomize.toolkit.fluxcd.io", Version: "v1"},
+	"kustomization": {Group: "kustomize.toolkit.fluxcd.io", Version: "v1"},
 	"namespace": {Group: "", Version: "v1"},
 	"networkpolicy": {Group: "networking.k8s.io", Version: "v1"},
 	"no": {Group: "", Version: "v1"},
 	"node": {Group: "", Version: "v1"},
 	"ns": {Group: "", Version: "v1"},
+	"ocirepo": {Group: "source.toolkit.fluxcd.io", Version: "v1beta2"},
+	"ocirepository": {Group: "source.toolkit.fluxcd.io", Version: "v1beta2"},
 	"pdb": {Group: "policy", Version: "v1"},
 	"persistentvolume": {Group: "", Version: "v1"},
 	"persistentvolumeclaim": {Group: "", Version: "v1"},
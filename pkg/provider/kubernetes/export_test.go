@@ -0,0 +1,24 @@
+package kubernetes
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
+)
+
+// SetClients replaces the dynamic client and REST mapper for the duration of a
+// test, with an empty kubeconfig, so that the current namespace is "default"
+func SetClients(t *testing.T, client dynamic.Interface, mapper meta.RESTMapper) {
+	t.Helper()
+
+	t.Setenv("KUBECONFIG", os.DevNull)
+
+	original := newClients
+	newClients = func(time.Duration) (dynamic.Interface, meta.RESTMapper, error) {
+		return client, mapper, nil
+	}
+	t.Cleanup(func() { newClients = original })
+}
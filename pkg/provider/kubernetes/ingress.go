@@ -0,0 +1,162 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var servicesResource = schema.GroupVersionResource{Version: "v1", Resource: "services"}
+
+type serviceBackend struct {
+	Service *struct {
+		Name string `json:"name"`
+	} `json:"service,omitempty"`
+}
+
+// ingress is the subset of an Ingress determining whether it routes traffic
+type ingress struct {
+	Spec struct {
+		DefaultBackend *serviceBackend `json:"defaultBackend,omitempty"`
+		Rules          []struct {
+			HTTP *struct {
+				Paths []struct {
+					Backend serviceBackend `json:"backend"`
+				} `json:"paths"`
+			} `json:"http,omitempty"`
+		} `json:"rules"`
+	} `json:"spec"`
+	Status struct {
+		LoadBalancer struct {
+			Ingress []any `json:"ingress,omitempty"`
+		} `json:"loadBalancer"`
+	} `json:"status"`
+}
+
+// httpRoute is the subset of a Gateway API HTTPRoute determining whether it routes traffic
+type httpRoute struct {
+	Spec struct {
+		Rules []struct {
+			BackendRefs []struct {
+				Group     *string `json:"group,omitempty"`
+				Kind      *string `json:"kind,omitempty"`
+				Name      string  `json:"name"`
+				Namespace *string `json:"namespace,omitempty"`
+			} `json:"backendRefs"`
+		} `json:"rules"`
+	} `json:"spec"`
+	Status struct {
+		Parents []struct {
+			ParentRef struct {
+				Name string `json:"name"`
+			} `json:"parentRef"`
+			Conditions []struct {
+				Type    string `json:"type"`
+				Status  string `json:"status"`
+				Message string `json:"message,omitempty"`
+			} `json:"conditions"`
+		} `json:"parents"`
+	} `json:"status"`
+}
+
+// checkIngress validates that an Ingress has been assigned a load balancer
+// address and that every backend service it references exists.
+func checkIngress(ctx context.Context, client dynamic.Interface, namespace string, obj map[string]any) string {
+	var ing ingress
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &ing); err != nil {
+		return fmt.Sprintf("invalid ingress: %v", err)
+	}
+
+	if len(ing.Status.LoadBalancer.Ingress) == 0 {
+		return "load balancer not ready"
+	}
+
+	var backends []string
+	if backend := ing.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		backends = append(backends, backend.Service.Name)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				backends = append(backends, path.Backend.Service.Name)
+			}
+		}
+	}
+
+	for _, name := range backends {
+		if msg := checkService(ctx, client, namespace, name); msg != "" {
+			return msg
+		}
+	}
+
+	return ""
+}
+
+// checkHTTPRoute validates that an HTTPRoute has been accepted by each of its
+// parent gateways with all references resolved, and that every backend
+// service it references exists.
+func checkHTTPRoute(ctx context.Context, client dynamic.Interface, namespace string, obj map[string]any) string {
+	var route httpRoute
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &route); err != nil {
+		return fmt.Sprintf("invalid httproute: %v", err)
+	}
+
+	if len(route.Status.Parents) == 0 {
+		return "route not accepted by any parent"
+	}
+	for _, parent := range route.Status.Parents {
+		for _, required := range []string{"Accepted", "ResolvedRefs"} {
+			status := "Unknown"
+			message := ""
+			for _, condition := range parent.Conditions {
+				if condition.Type == required {
+					status = condition.Status
+					message = condition.Message
+				}
+			}
+			if status != "True" {
+				msg := fmt.Sprintf("parent %s condition %s is %s", parent.ParentRef.Name, required, status)
+				if message != "" {
+					msg = fmt.Sprintf("%s: %s", msg, message)
+				}
+				return msg
+			}
+		}
+	}
+
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Service") {
+				continue
+			}
+			refNamespace := namespace
+			if ref.Namespace != nil {
+				refNamespace = *ref.Namespace
+			}
+			if msg := checkService(ctx, client, refNamespace, ref.Name); msg != "" {
+				return msg
+			}
+		}
+	}
+
+	return ""
+}
+
+// checkService validates that the named backend service exists.
+func checkService(ctx context.Context, client dynamic.Interface, namespace, name string) string {
+	if _, err := client.Resource(servicesResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("backend service %s/%s not found", namespace, name)
+		}
+		return fmt.Sprintf("backend service %s/%s: %v", namespace, name, err)
+	}
+	return ""
+}
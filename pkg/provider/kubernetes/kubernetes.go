@@ -4,13 +4,19 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/mcuadros/go-defaults"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
@@ -25,13 +31,19 @@ import (
 const TypeKubernetes = "kubernetes"
 
 type Kubernetes struct {
-	Group     string        `mapstructure:"group" default:"apps"`
-	Version   string        `mapstructure:"version" default:"v1"`
-	Kind      string        `mapstructure:"kind" default:"deployment"`
-	Namespace string        `mapstructure:"namespace" default:"default"`
-	Name      string        `mapstructure:"name"`
-	Condition *Condition    `mapstructure:"condition"`
-	Timeout   time.Duration `mapstructure:"timeout" default:"10s"`
+	Group         string            `mapstructure:"group" default:"apps"`
+	Version       string            `mapstructure:"version" default:"v1"`
+	Kind          string            `mapstructure:"kind" default:"deployment"`
+	Namespace     string            `mapstructure:"namespace"`
+	Name          string            `mapstructure:"name"`
+	LabelSelector string            `mapstructure:"labelSelector"`
+	FieldSelector string            `mapstructure:"fieldSelector"`
+	Condition     *Condition        `mapstructure:"condition"`
+	Phase         string            `mapstructure:"phase"`
+	MinCapacity   string            `mapstructure:"minCapacity"`
+	IncludeOwned  bool              `mapstructure:"includeOwned"`
+	MaxUsage      map[string]string `mapstructure:"maxUsage"`
+	Timeout       time.Duration     `mapstructure:"timeout" default:"10s"`
 }
 
 type Condition struct {
@@ -44,6 +56,29 @@ type GV struct {
 	Version string
 }
 
+// newClients returns the dynamic client and REST mapper used to query resources
+var newClients = func(timeout time.Duration) (dynamic.Interface, meta.RESTMapper, error) {
+	config, err := utils.GetKubeConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config.Timeout = timeout
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
+
+	return client, mapper, nil
+}
+
 func init() {
 	provider.Register(TypeKubernetes, new(Kubernetes))
 }
@@ -55,6 +90,12 @@ func (i *Kubernetes) LogValue() slog.Value {
 		slog.String("kind", i.Kind),
 		slog.String("name", i.Name),
 		slog.String("namespace", i.Namespace),
+		slog.String("labelSelector", i.LabelSelector),
+		slog.String("fieldSelector", i.FieldSelector),
+		slog.String("phase", i.Phase),
+		slog.String("minCapacity", i.MinCapacity),
+		slog.Bool("includeOwned", i.IncludeOwned),
+		slog.Any("maxUsage", i.MaxUsage),
 		slog.Any("timeout", i.Timeout),
 	}
 	return slog.GroupValue(logAttr...)
@@ -69,9 +110,23 @@ func (i *Kubernetes) GetType() string {
 }
 
 func (i *Kubernetes) GetName() string {
+	if i.listMode() {
+		selectors := slices.DeleteFunc([]string{i.LabelSelector, i.FieldSelector}, func(s string) bool { return s == "" })
+		return fmt.Sprintf("%s/%s", i.Kind, strings.Join(selectors, ","))
+	}
 	return fmt.Sprintf("%s/%s", i.Kind, i.Name)
 }
 
+// listMode reports whether the instance selects resources by label or field
+// selector rather than by name.
+func (i *Kubernetes) listMode() bool {
+	return i.Name == "" && (i.LabelSelector != "" || i.FieldSelector != "")
+}
+
+func (i *Kubernetes) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
 func (i *Kubernetes) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	log := utils.ContextLogger(ctx, slog.String("provider", TypeKubernetes), slog.Any("instance", i))
 	log.Debug("checking")
@@ -82,21 +137,15 @@ func (i *Kubernetes) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	}
 	defer component.LogStatus(log)
 
-	config, err := utils.GetKubeConfig()
-	if err != nil {
-		return component.Unhealthy(err.Error())
+	if i.Name != "" && (i.LabelSelector != "" || i.FieldSelector != "") {
+		return component.Unhealthy("labelSelector and fieldSelector cannot be combined with name")
 	}
 
-	config.Timeout = i.Timeout
-
-	client, err := dynamic.NewForConfig(config)
+	client, mapper, err := newClients(i.Timeout)
 	if err != nil {
 		return component.Unhealthy(err.Error())
 	}
 
-	dc, _ := discovery.NewDiscoveryClientForConfig(config)
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
-
 	// fix default group and version for common resources
 	if i.Group == "apps" && i.Version == "v1" && i.Kind != "deployment" {
 		k := strings.ToLower(i.Kind)
@@ -115,30 +164,146 @@ func (i *Kubernetes) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 		return component.Unhealthy(err.Error())
 	}
 
-	gvr := mapping.Resource
+	var usageMapping *meta.RESTMapping
+	if len(i.MaxUsage) > 0 {
+		usageMapping, err = metricsMapping(mapper, mapping.GroupVersionKind.Kind)
+		if errors.Is(err, errMetricsUnavailable) {
+			component.Status = ph.Status_UNKNOWN
+			component.Message = err.Error()
+			return component
+		} else if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+	}
+
+	var resourceClient dynamic.ResourceInterface = client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := i.Namespace
+		if namespace == "" {
+			if namespace, err = utils.GetKubeNamespace(); err != nil {
+				return component.Unhealthy(err.Error())
+			}
+		}
+		resourceClient = client.Resource(mapping.Resource).Namespace(namespace)
+	}
 
-	blob, err := client.Resource(gvr).Namespace(i.Namespace).Get(ctx, i.Name, metav1.GetOptions{})
+	if i.listMode() {
+		list, err := resourceClient.List(ctx, metav1.ListOptions{
+			LabelSelector: i.LabelSelector,
+			FieldSelector: i.FieldSelector,
+		})
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		if len(list.Items) == 0 {
+			return component.Unhealthy("no resources match selector")
+		}
+		for _, blob := range list.Items {
+			if msg := i.check(ctx, client, usageMapping, &blob); msg != "" {
+				return component.Unhealthy(fmt.Sprintf("%s: %s", blob.GetName(), msg))
+			}
+		}
+		return component.Healthy()
+	}
 
+	blob, err := resourceClient.Get(ctx, i.Name, metav1.GetOptions{})
 	if err != nil {
 		return component.Unhealthy(err.Error())
 	}
 
+	if msg := i.check(ctx, client, usageMapping, blob); msg != "" {
+		return component.Unhealthy(msg)
+	}
+
+	return component.Healthy()
+}
+
+// check validates the status of a single resource, returning the reason it
+// is not healthy, if any.
+func (i *Kubernetes) check(ctx context.Context, client dynamic.Interface, usageMapping *meta.RESTMapping, blob *unstructured.Unstructured) string {
 	resource, err := NewResource(blob.Object)
 	if err != nil {
-		return component.Unhealthy(err.Error())
+		return err.Error()
+	}
+
+	switch resource.Kind {
+	case "Node":
+		if msg := checkNode(resource); msg != "" {
+			return msg
+		}
+	case "StatefulSet":
+		if msg := checkStatefulSet(blob.Object); msg != "" {
+			return msg
+		}
+	case "Ingress":
+		if msg := checkIngress(ctx, client, blob.GetNamespace(), blob.Object); msg != "" {
+			return msg
+		}
+	case "HTTPRoute":
+		if msg := checkHTTPRoute(ctx, client, blob.GetNamespace(), blob.Object); msg != "" {
+			return msg
+		}
+	}
+
+	if i.Phase != "" && resource.Status.Phase != i.Phase {
+		return fmt.Sprintf("phase is %s; expected %s", resource.Status.Phase, i.Phase)
+	}
+
+	if i.MinCapacity != "" {
+		if msg := checkCapacity(resource, i.MinCapacity); msg != "" {
+			return msg
+		}
 	}
 
 	if i.Condition != nil {
 		for _, condition := range resource.Status.Conditions {
 			if string(condition.Type) == i.Condition.Type {
 				if string(condition.Status) == i.Condition.Status {
-					return component.Healthy()
-				} else {
-					return component.Unhealthy(fmt.Sprintf("condition %s is %s", i.Condition.Type, condition.Status))
+					break
+				}
+				msg := fmt.Sprintf("condition %s is %s", i.Condition.Type, condition.Status)
+				if condition.Message != "" {
+					msg = fmt.Sprintf("%s: %s", msg, condition.Message)
 				}
+				return msg
 			}
 		}
 	}
 
-	return component.Healthy()
+	if usageMapping != nil {
+		if msg := checkUsage(ctx, client, usageMapping, blob, i.MaxUsage); msg != "" {
+			return msg
+		}
+	}
+
+	if i.IncludeOwned {
+		return checkOwnedPods(ctx, client, blob)
+	}
+
+	return ""
+}
+
+// checkCapacity validates that the storage capacity reported by the resource
+// (e.g. a bound PersistentVolumeClaim) is at least minCapacity.
+func checkCapacity(resource Resource, minCapacity string) string {
+	required, err := apiresource.ParseQuantity(minCapacity)
+	if err != nil {
+		return fmt.Sprintf("invalid minCapacity %q: %v", minCapacity, err)
+	}
+
+	capacity, ok := resource.Status.Capacity[string(v1.ResourceStorage)]
+	if !ok {
+		return "no storage capacity reported"
+	}
+
+	actual, err := apiresource.ParseQuantity(capacity)
+	if err != nil {
+		return fmt.Sprintf("invalid capacity %q: %v", capacity, err)
+	}
+
+	if actual.Cmp(required) < 0 {
+		return fmt.Sprintf("capacity %s is less than %s", capacity, minCapacity)
+	}
+
+	return ""
 }
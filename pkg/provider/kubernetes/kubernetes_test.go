@@ -0,0 +1,1793 @@
+package kubernetes_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider/kubernetes"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+// newObject builds an unstructured resource with the given Ready condition
+func newObject(gvk schema.GroupVersionKind, name, ready, message string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": "flux-system",
+		},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{
+					"type":    "Ready",
+					"status":  ready,
+					"message": message,
+				},
+			},
+		},
+	}}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
+
+// newMapper maps each namespaced kind, as discovery does, under both its canonical and lowercase names
+func newMapper(gvks ...schema.GroupVersionKind) meta.RESTMapper {
+	return newScopedMapper(meta.RESTScopeNamespace, gvks...)
+}
+
+func newScopedMapper(scope meta.RESTScope, gvks ...schema.GroupVersionKind) meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for _, gvk := range gvks {
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		mapper.AddSpecific(gvk, plural, singular, scope)
+		lower := gvk.GroupVersion().WithKind(strings.ToLower(gvk.Kind))
+		mapper.AddSpecific(lower, plural, singular, scope)
+	}
+	return mapper
+}
+
+// fromYAML decodes a manifest as returned by the API server, such that fields
+// keep their camelCase JSON names
+func fromYAML(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+
+	data, err := yaml.YAMLToJSON([]byte(manifest))
+	require.NoError(t, err)
+	obj := &unstructured.Unstructured{}
+	require.NoError(t, obj.UnmarshalJSON(data))
+	return obj
+}
+
+func TestFluxSyncStatus(t *testing.T) {
+	kustomization := schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+	helmRelease := schema.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}
+
+	objects := []runtime.Object{
+		newObject(kustomization, "synced", "True", "Applied revision: main@sha1:abc123"),
+		newObject(kustomization, "out-of-sync", "False", "kustomize build failed"),
+		newObject(helmRelease, "released", "True", "Helm install succeeded"),
+		newObject(helmRelease, "failed", "False", "Helm upgrade failed"),
+	}
+
+	tests := []struct {
+		name     string
+		kind     string
+		resource string
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Kustomization synced",
+			kind:     "kustomization",
+			resource: "synced",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Kustomization out of sync",
+			kind:     "kustomization",
+			resource: "out-of-sync",
+			expected: ph.Status_UNHEALTHY,
+			message:  "condition Ready is False: kustomize build failed",
+		},
+		{
+			name:     "HelmRelease released",
+			kind:     "helmrelease",
+			resource: "released",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "HelmRelease failed",
+			kind:     "helmrelease",
+			resource: "failed",
+			expected: ph.Status_UNHEALTHY,
+			message:  "condition Ready is False: Helm upgrade failed",
+		},
+		{
+			name:     "Kustomization missing",
+			kind:     "kustomization",
+			resource: "missing",
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+			kubernetes.SetClients(t, client, newMapper(kustomization, helmRelease))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:      tt.kind,
+				Name:      tt.resource,
+				Namespace: "flux-system",
+				Condition: &kubernetes.Condition{Type: "Ready"},
+				Timeout:   time.Second,
+			}
+			instance.SetDefaults()
+			instance.Condition.Status = "True"
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, kubernetes.TypeKubernetes, result.GetType())
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+		})
+	}
+}
+
+// newPVC builds an unstructured PersistentVolumeClaim in the given phase
+func newPVC(name, phase, capacity string) *unstructured.Unstructured {
+	status := map[string]any{"phase": phase}
+	if capacity != "" {
+		status["capacity"] = map[string]any{"storage": capacity}
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"resources": map[string]any{
+				"requests": map[string]any{"storage": "10Gi"},
+			},
+		},
+		"status": status,
+	}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "PersistentVolumeClaim"})
+	return obj
+}
+
+func TestPersistentVolumeClaim(t *testing.T) {
+	pvc := schema.GroupVersionKind{Version: "v1", Kind: "PersistentVolumeClaim"}
+
+	objects := []runtime.Object{
+		newPVC("bound", "Bound", "10Gi"),
+		newPVC("pending", "Pending", ""),
+	}
+
+	tests := []struct {
+		name        string
+		resource    string
+		phase       string
+		minCapacity string
+		expected    ph.Status
+		message     string
+	}{
+		{
+			name:     "Bound",
+			resource: "bound",
+			phase:    "Bound",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Pending",
+			resource: "pending",
+			phase:    "Bound",
+			expected: ph.Status_UNHEALTHY,
+			message:  "phase is Pending; expected Bound",
+		},
+		{
+			name:     "Pending without phase expectation",
+			resource: "pending",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:        "Sufficient capacity",
+			resource:    "bound",
+			phase:       "Bound",
+			minCapacity: "8Gi",
+			expected:    ph.Status_HEALTHY,
+		},
+		{
+			name:        "Insufficient capacity",
+			resource:    "bound",
+			minCapacity: "20Gi",
+			expected:    ph.Status_UNHEALTHY,
+			message:     "capacity 10Gi is less than 20Gi",
+		},
+		{
+			name:        "No capacity reported",
+			resource:    "pending",
+			minCapacity: "1Gi",
+			expected:    ph.Status_UNHEALTHY,
+			message:     "no storage capacity reported",
+		},
+		{
+			name:        "Invalid minimum capacity",
+			resource:    "bound",
+			minCapacity: "lots",
+			expected:    ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+			kubernetes.SetClients(t, client, newMapper(pvc))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:        "persistentvolumeclaim",
+				Name:        tt.resource,
+				Phase:       tt.phase,
+				MinCapacity: tt.minCapacity,
+				Timeout:     time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+		})
+	}
+}
+
+// newNode builds an unstructured Node with the given condition statuses
+func newNode(name string, unschedulable bool, conditions map[string]string) *unstructured.Unstructured {
+	var status []any
+	for conditionType, conditionStatus := range conditions {
+		status = append(status, map[string]any{
+			"type":    conditionType,
+			"status":  conditionStatus,
+			"message": "kubelet reports " + conditionType + " " + conditionStatus,
+		})
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name": name,
+		},
+		"spec": map[string]any{
+			"unschedulable": unschedulable,
+		},
+		"status": map[string]any{
+			"conditions": status,
+		},
+	}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Node"})
+	return obj
+}
+
+func TestNode(t *testing.T) {
+	node := schema.GroupVersionKind{Version: "v1", Kind: "Node"}
+
+	healthy := map[string]string{"Ready": "True", "MemoryPressure": "False", "DiskPressure": "False", "PIDPressure": "False"}
+	objects := []runtime.Object{
+		newNode("healthy", false, healthy),
+		newNode("not-ready", false, map[string]string{"Ready": "False", "MemoryPressure": "False"}),
+		newNode("unknown", false, map[string]string{"Ready": "Unknown"}),
+		newNode("memory-pressure", false, map[string]string{"Ready": "True", "MemoryPressure": "True", "DiskPressure": "False", "PIDPressure": "False"}),
+		newNode("memory-disk-pressure", false, map[string]string{"Ready": "True", "MemoryPressure": "True", "DiskPressure": "True", "PIDPressure": "False"}),
+		newNode("pid-pressure", false, map[string]string{"Ready": "True", "PIDPressure": "True"}),
+		newNode("cordoned", true, healthy),
+	}
+
+	tests := []struct {
+		name     string
+		resource string
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Ready",
+			resource: "healthy",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Not ready",
+			resource: "not-ready",
+			expected: ph.Status_UNHEALTHY,
+			message:  "node is not ready: kubelet reports Ready False",
+		},
+		{
+			name:     "Ready unknown",
+			resource: "unknown",
+			expected: ph.Status_UNHEALTHY,
+			message:  "node is not ready: kubelet reports Ready Unknown",
+		},
+		{
+			name:     "Memory pressure",
+			resource: "memory-pressure",
+			expected: ph.Status_UNHEALTHY,
+			message:  "node is under memory pressure",
+		},
+		{
+			name:     "Memory and disk pressure",
+			resource: "memory-disk-pressure",
+			expected: ph.Status_UNHEALTHY,
+			message:  "node is under disk, memory pressure",
+		},
+		{
+			name:     "PID pressure",
+			resource: "pid-pressure",
+			expected: ph.Status_UNHEALTHY,
+			message:  "node is under pid pressure",
+		},
+		{
+			name:     "Unschedulable",
+			resource: "cordoned",
+			expected: ph.Status_UNHEALTHY,
+			message:  "node is unschedulable",
+		},
+		{
+			name:     "Missing",
+			resource: "missing",
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+			kubernetes.SetClients(t, client, newScopedMapper(meta.RESTScopeRoot, node))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:    "node",
+				Name:    tt.resource,
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+		})
+	}
+}
+
+// rollout describes the spec and status of a StatefulSet
+type rollout struct {
+	generation, observedGeneration    int64
+	replicas, ready, current, updated int64
+	currentRevision, updateRevision   string
+	strategy                          string
+	partition                         int64
+}
+
+// newStatefulSet builds an unstructured StatefulSet in the given rollout state
+func newStatefulSet(name string, r rollout) *unstructured.Unstructured {
+	updateStrategy := map[string]any{"type": "RollingUpdate"}
+	if r.strategy != "" {
+		updateStrategy["type"] = r.strategy
+	}
+	if r.partition > 0 {
+		updateStrategy["rollingUpdate"] = map[string]any{"partition": r.partition}
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":       name,
+			"namespace":  "default",
+			"generation": r.generation,
+		},
+		"spec": map[string]any{
+			"replicas":       r.replicas,
+			"updateStrategy": updateStrategy,
+		},
+		"status": map[string]any{
+			"observedGeneration": r.observedGeneration,
+			"replicas":           r.replicas,
+			"readyReplicas":      r.ready,
+			"currentReplicas":    r.current,
+			"updatedReplicas":    r.updated,
+			"currentRevision":    r.currentRevision,
+			"updateRevision":     r.updateRevision,
+		},
+	}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"})
+	return obj
+}
+
+func TestStatefulSet(t *testing.T) {
+	statefulSet := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}
+
+	tests := []struct {
+		name     string
+		rollout  rollout
+		expected ph.Status
+		message  string
+	}{
+		{
+			name: "Rollout complete",
+			rollout: rollout{
+				generation: 2, observedGeneration: 2,
+				replicas: 3, ready: 3, current: 3, updated: 3,
+				currentRevision: "web-2", updateRevision: "web-2",
+			},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name: "Spec update not observed",
+			rollout: rollout{
+				generation: 3, observedGeneration: 2,
+				replicas: 3, ready: 3, current: 3, updated: 3,
+				currentRevision: "web-2", updateRevision: "web-2",
+			},
+			expected: ph.Status_UNHEALTHY,
+			message:  "rollout in progress: waiting for spec update to be observed",
+		},
+		{
+			name: "Replicas not ready",
+			rollout: rollout{
+				generation: 2, observedGeneration: 2,
+				replicas: 3, ready: 2, current: 3, updated: 3,
+				currentRevision: "web-2", updateRevision: "web-2",
+			},
+			expected: ph.Status_UNHEALTHY,
+			message:  "rollout in progress: 2 of 3 replicas ready",
+		},
+		{
+			name: "Mid rollout",
+			rollout: rollout{
+				generation: 3, observedGeneration: 3,
+				replicas: 3, ready: 3, current: 2, updated: 1,
+				currentRevision: "web-2", updateRevision: "web-3",
+			},
+			expected: ph.Status_UNHEALTHY,
+			message:  "rollout in progress: 1 of 3 replicas updated",
+		},
+		{
+			name: "Partitioned rollout complete",
+			rollout: rollout{
+				generation: 3, observedGeneration: 3,
+				replicas: 3, ready: 3, current: 2, updated: 1,
+				currentRevision: "web-2", updateRevision: "web-3",
+				partition: 2,
+			},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name: "Partitioned rollout in progress",
+			rollout: rollout{
+				generation: 3, observedGeneration: 3,
+				replicas: 3, ready: 3, current: 3, updated: 0,
+				currentRevision: "web-2", updateRevision: "web-3",
+				partition: 1,
+			},
+			expected: ph.Status_UNHEALTHY,
+			message:  "rollout in progress: 0 of 2 replicas updated",
+		},
+		{
+			name: "OnDelete strategy",
+			rollout: rollout{
+				generation: 3, observedGeneration: 3,
+				replicas: 3, ready: 3, current: 3, updated: 0,
+				currentRevision: "web-2", updateRevision: "web-3",
+				strategy: "OnDelete",
+			},
+			expected: ph.Status_HEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), newStatefulSet("web", tt.rollout))
+			kubernetes.SetClients(t, client, newMapper(statefulSet))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:    "statefulset",
+				Name:    "web",
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func TestStatefulSetManifest(t *testing.T) {
+	statefulSet := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected ph.Status
+		message  string
+	}{
+		{
+			name: "Rollout complete",
+			manifest: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: web
+  namespace: default
+  generation: 2
+spec:
+  replicas: 3
+  serviceName: web
+  podManagementPolicy: OrderedReady
+  updateStrategy:
+    type: RollingUpdate
+    rollingUpdate:
+      partition: 0
+status:
+  observedGeneration: 2
+  replicas: 3
+  readyReplicas: 3
+  availableReplicas: 3
+  currentReplicas: 3
+  updatedReplicas: 3
+  currentRevision: web-5d8f7c
+  updateRevision: web-5d8f7c
+  collisionCount: 0
+`,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name: "Partitioned rollout in progress",
+			manifest: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: web
+  namespace: default
+  generation: 3
+spec:
+  replicas: 3
+  serviceName: web
+  updateStrategy:
+    type: RollingUpdate
+    rollingUpdate:
+      partition: 1
+status:
+  observedGeneration: 3
+  replicas: 3
+  readyReplicas: 3
+  availableReplicas: 3
+  currentReplicas: 2
+  updatedReplicas: 1
+  currentRevision: web-5d8f7c
+  updateRevision: web-6b9d4e
+`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "rollout in progress: 1 of 2 replicas updated",
+		},
+		{
+			name: "Replicas not ready",
+			manifest: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: web
+  namespace: default
+  generation: 2
+spec:
+  replicas: 3
+  serviceName: web
+status:
+  observedGeneration: 2
+  replicas: 3
+  readyReplicas: 1
+  currentReplicas: 3
+  updatedReplicas: 3
+  currentRevision: web-5d8f7c
+  updateRevision: web-5d8f7c
+`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "rollout in progress: 1 of 3 replicas ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), fromYAML(t, tt.manifest))
+			kubernetes.SetClients(t, client, newMapper(statefulSet))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:    "statefulset",
+				Name:    "web",
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func newService(namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+		},
+	}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Service"})
+	return obj
+}
+
+func newIngress(name string, ready bool, backends ...string) *unstructured.Unstructured {
+	paths := []any{}
+	for _, backend := range backends {
+		paths = append(paths, map[string]any{
+			"path":     "/" + backend,
+			"pathType": "Prefix",
+			"backend":  map[string]any{"service": map[string]any{"name": backend, "port": map[string]any{"number": int64(80)}}},
+		})
+	}
+	loadBalancer := map[string]any{}
+	if ready {
+		loadBalancer["ingress"] = []any{map[string]any{"ip": "192.0.2.10"}}
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"rules": []any{
+				map[string]any{"host": "www.example.com", "http": map[string]any{"paths": paths}},
+			},
+		},
+		"status": map[string]any{"loadBalancer": loadBalancer},
+	}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"})
+	return obj
+}
+
+func TestIngress(t *testing.T) {
+	ingress := schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+
+	tests := []struct {
+		name     string
+		ready    bool
+		backends []string
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Ready with existing backends",
+			ready:    true,
+			backends: []string{"web", "api"},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Load balancer not ready",
+			backends: []string{"web"},
+			expected: ph.Status_UNHEALTHY,
+			message:  "load balancer not ready",
+		},
+		{
+			name:     "Missing backend",
+			ready:    true,
+			backends: []string{"web", "missing"},
+			expected: ph.Status_UNHEALTHY,
+			message:  "backend service default/missing not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+				newIngress("site", tt.ready, tt.backends...),
+				newService("default", "web"),
+				newService("default", "api"),
+			)
+			kubernetes.SetClients(t, client, newMapper(ingress))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:    "ingress",
+				Name:    "site",
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func newHTTPRoute(name string, conditions map[string]string, backends ...map[string]any) *unstructured.Unstructured {
+	parentConditions := []any{}
+	for conditionType, status := range conditions {
+		parentConditions = append(parentConditions, map[string]any{"type": conditionType, "status": status})
+	}
+	backendRefs := []any{}
+	for _, backend := range backends {
+		backendRefs = append(backendRefs, backend)
+	}
+	status := map[string]any{"parents": []any{}}
+	if conditions != nil {
+		status["parents"] = []any{map[string]any{
+			"parentRef":      map[string]any{"name": "gateway"},
+			"controllerName": "example.com/gateway-controller",
+			"conditions":     parentConditions,
+		}}
+	}
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": map[string]any{
+			"parentRefs": []any{map[string]any{"name": "gateway"}},
+			"rules":      []any{map[string]any{"backendRefs": backendRefs}},
+		},
+		"status": status,
+	}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"})
+	return obj
+}
+
+func TestHTTPRoute(t *testing.T) {
+	httpRoute := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	accepted := map[string]string{"Accepted": "True", "ResolvedRefs": "True"}
+
+	tests := []struct {
+		name       string
+		conditions map[string]string
+		backends   []map[string]any
+		expected   ph.Status
+		message    string
+	}{
+		{
+			name:       "Accepted with existing backends",
+			conditions: accepted,
+			backends: []map[string]any{
+				{"name": "web", "port": int64(80)},
+				{"name": "api", "namespace": "backend", "port": int64(80)},
+			},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Not accepted by any parent",
+			backends: []map[string]any{{"name": "web"}},
+			expected: ph.Status_UNHEALTHY,
+			message:  "route not accepted by any parent",
+		},
+		{
+			name:       "Not accepted",
+			conditions: map[string]string{"Accepted": "False", "ResolvedRefs": "True"},
+			backends:   []map[string]any{{"name": "web"}},
+			expected:   ph.Status_UNHEALTHY,
+			message:    "parent gateway condition Accepted is False",
+		},
+		{
+			name:       "References not resolved",
+			conditions: map[string]string{"Accepted": "True"},
+			backends:   []map[string]any{{"name": "web"}},
+			expected:   ph.Status_UNHEALTHY,
+			message:    "parent gateway condition ResolvedRefs is Unknown",
+		},
+		{
+			name:       "Missing backend",
+			conditions: accepted,
+			backends:   []map[string]any{{"name": "web"}, {"name": "api"}},
+			expected:   ph.Status_UNHEALTHY,
+			message:    "backend service default/api not found",
+		},
+		{
+			name:       "Non-service backend ignored",
+			conditions: accepted,
+			backends:   []map[string]any{{"name": "web"}, {"name": "bucket", "group": "storage.example.com", "kind": "Bucket"}},
+			expected:   ph.Status_HEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+				newHTTPRoute("site", tt.conditions, tt.backends...),
+				newService("default", "web"),
+				newService("backend", "api"),
+			)
+			kubernetes.SetClients(t, client, newMapper(httpRoute))
+
+			instance := &kubernetes.Kubernetes{
+				Group:   "gateway.networking.k8s.io",
+				Kind:    "httproute",
+				Name:    "site",
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func TestRouteManifest(t *testing.T) {
+	ingress := schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}
+	httpRoute := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+	tests := []struct {
+		name     string
+		group    string
+		kind     string
+		manifest string
+		expected ph.Status
+		message  string
+	}{
+		{
+			name: "Ingress with load balancer",
+			kind: "ingress",
+			manifest: `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: site
+  namespace: default
+spec:
+  ingressClassName: nginx
+  defaultBackend:
+    service:
+      name: web
+      port:
+        number: 80
+  rules:
+  - host: www.example.com
+    http:
+      paths:
+      - path: /api
+        pathType: Prefix
+        backend:
+          service:
+            name: api
+            port:
+              number: 8080
+status:
+  loadBalancer:
+    ingress:
+    - ip: 192.0.2.10
+      ports:
+      - port: 443
+        protocol: TCP
+`,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name: "Ingress without load balancer",
+			kind: "ingress",
+			manifest: `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: site
+  namespace: default
+spec:
+  rules:
+  - host: www.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web
+            port:
+              number: 80
+status:
+  loadBalancer: {}
+`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "load balancer not ready",
+		},
+		{
+			name: "Ingress default backend missing",
+			kind: "ingress",
+			manifest: `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: site
+  namespace: default
+spec:
+  defaultBackend:
+    service:
+      name: missing
+      port:
+        number: 80
+status:
+  loadBalancer:
+    ingress:
+    - hostname: lb.example.com
+`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "backend service default/missing not found",
+		},
+		{
+			name:  "HTTPRoute accepted",
+			group: "gateway.networking.k8s.io",
+			kind:  "httproute",
+			manifest: `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: site
+  namespace: default
+spec:
+  parentRefs:
+  - name: gateway
+    sectionName: https
+  rules:
+  - matches:
+    - path:
+        type: PathPrefix
+        value: /
+    backendRefs:
+    - name: web
+      port: 80
+      weight: 90
+    - name: api
+      namespace: backend
+      port: 8080
+      weight: 10
+status:
+  parents:
+  - parentRef:
+      group: gateway.networking.k8s.io
+      kind: Gateway
+      name: gateway
+      sectionName: https
+    controllerName: example.com/gateway-controller
+    conditions:
+    - type: Accepted
+      status: "True"
+      reason: Accepted
+      observedGeneration: 1
+      lastTransitionTime: "2024-01-01T00:00:00Z"
+    - type: ResolvedRefs
+      status: "True"
+      reason: ResolvedRefs
+      observedGeneration: 1
+      lastTransitionTime: "2024-01-01T00:00:00Z"
+`,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:  "HTTPRoute references not resolved",
+			group: "gateway.networking.k8s.io",
+			kind:  "httproute",
+			manifest: `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: site
+  namespace: default
+spec:
+  parentRefs:
+  - name: gateway
+  rules:
+  - backendRefs:
+    - name: web
+      port: 80
+status:
+  parents:
+  - parentRef:
+      name: gateway
+    controllerName: example.com/gateway-controller
+    conditions:
+    - type: Accepted
+      status: "True"
+      reason: Accepted
+    - type: ResolvedRefs
+      status: "False"
+      reason: BackendNotFound
+      message: backend not found
+`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "parent gateway condition ResolvedRefs is False: backend not found",
+		},
+		{
+			name:  "HTTPRoute backend in other namespace missing",
+			group: "gateway.networking.k8s.io",
+			kind:  "httproute",
+			manifest: `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: site
+  namespace: default
+spec:
+  parentRefs:
+  - name: gateway
+  rules:
+  - backendRefs:
+    - name: web
+      namespace: frontend
+      port: 80
+status:
+  parents:
+  - parentRef:
+      name: gateway
+    controllerName: example.com/gateway-controller
+    conditions:
+    - type: Accepted
+      status: "True"
+    - type: ResolvedRefs
+      status: "True"
+`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "backend service frontend/web not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+				fromYAML(t, tt.manifest),
+				newService("default", "web"),
+				newService("backend", "api"),
+				newService("default", "api"),
+			)
+			kubernetes.SetClients(t, client, newMapper(ingress, httpRoute))
+
+			instance := &kubernetes.Kubernetes{
+				Group:   tt.group,
+				Kind:    tt.kind,
+				Name:    "site",
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+// newPod builds an unstructured Pod with the given labels in the given phase
+func newPod(name, app, phase string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": "default",
+			"labels":    map[string]any{"app": app},
+		},
+		"status": map[string]any{"phase": phase},
+	}}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+	return obj
+}
+
+func TestSelector(t *testing.T) {
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+	tests := []struct {
+		name          string
+		resource      string
+		labelSelector string
+		fieldSelector string
+		expected      ph.Status
+		message       string
+	}{
+		{
+			name:          "All selected running",
+			labelSelector: "app=web",
+			expected:      ph.Status_HEALTHY,
+		},
+		{
+			name:          "Selected pod pending",
+			labelSelector: "app=api",
+			expected:      ph.Status_UNHEALTHY,
+			message:       "api-2: phase is Pending; expected Running",
+		},
+		{
+			name:          "Field selector",
+			labelSelector: "app=web",
+			fieldSelector: "status.phase=Running",
+			expected:      ph.Status_HEALTHY,
+		},
+		{
+			name:          "No match",
+			labelSelector: "app=missing",
+			expected:      ph.Status_UNHEALTHY,
+			message:       "no resources match selector",
+		},
+		{
+			name:          "Name with selector",
+			resource:      "web-1",
+			fieldSelector: "status.phase=Running",
+			expected:      ph.Status_UNHEALTHY,
+			message:       "labelSelector and fieldSelector cannot be combined with name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+				newPod("web-1", "web", "Running"),
+				newPod("web-2", "web", "Running"),
+				newPod("api-1", "api", "Running"),
+				newPod("api-2", "api", "Pending"),
+			)
+			var fieldSelector string
+			client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				fieldSelector = action.(k8stesting.ListAction).GetListRestrictions().Fields.String()
+				return false, nil, nil
+			})
+			kubernetes.SetClients(t, client, newMapper(pod))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:          "pod",
+				Name:          tt.resource,
+				LabelSelector: tt.labelSelector,
+				FieldSelector: tt.fieldSelector,
+				Phase:         "Running",
+				Timeout:       time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			if tt.resource == "" {
+				assert.Equal(t, tt.fieldSelector, fieldSelector)
+			}
+		})
+	}
+}
+
+// newOwned builds an unstructured resource of the given kind, controlled by owner
+func newOwned(gvk schema.GroupVersionKind, name, uid string, owner *unstructured.Unstructured, status map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": "default",
+			"uid":       uid,
+			"labels":    map[string]any{"app": "web"},
+		},
+		"spec": map[string]any{
+			"selector": map[string]any{"matchLabels": map[string]any{"app": "web"}},
+		},
+		"status": status,
+	}}
+	obj.SetGroupVersionKind(gvk)
+	if owner != nil {
+		controller := true
+		obj.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: owner.GetAPIVersion(),
+			Kind:       owner.GetKind(),
+			Name:       owner.GetName(),
+			UID:        owner.GetUID(),
+			Controller: &controller,
+		}})
+	}
+	return obj
+}
+
+func TestIncludeOwned(t *testing.T) {
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	replicaSetGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}
+	podGVK := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+	running := map[string]any{
+		"phase":      "Running",
+		"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+	}
+	crashLooping := map[string]any{
+		"phase":      "Running",
+		"conditions": []any{map[string]any{"type": "Ready", "status": "False"}},
+		"containerStatuses": []any{map[string]any{
+			"name":  "app",
+			"state": map[string]any{"waiting": map[string]any{"reason": "CrashLoopBackOff"}},
+		}},
+	}
+	notReady := map[string]any{
+		"phase":      "Running",
+		"conditions": []any{map[string]any{"type": "Ready", "status": "False"}},
+	}
+
+	tests := []struct {
+		name         string
+		includeOwned bool
+		pod          map[string]any
+		expected     ph.Status
+		message      string
+	}{
+		{
+			name:         "Owned pods running",
+			includeOwned: true,
+			pod:          running,
+			expected:     ph.Status_HEALTHY,
+		},
+		{
+			name:         "Owned pod crash looping",
+			includeOwned: true,
+			pod:          crashLooping,
+			expected:     ph.Status_UNHEALTHY,
+			message:      "pod web-1-b has waiting containers: app (CrashLoopBackOff)",
+		},
+		{
+			name:         "Owned pod not ready",
+			includeOwned: true,
+			pod:          notReady,
+			expected:     ph.Status_UNHEALTHY,
+			message:      "pod web-1-b is not ready",
+		},
+		{
+			name:     "Owned pods ignored",
+			pod:      crashLooping,
+			expected: ph.Status_HEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := newOwned(deploymentGVK, "web", "deployment", nil, map[string]any{
+				"conditions": []any{map[string]any{"type": "Available", "status": "True"}},
+			})
+			replicaSet := newOwned(replicaSetGVK, "web-1", "replicaset", deployment, map[string]any{})
+			foreign := newOwned(replicaSetGVK, "other-1", "foreign", nil, map[string]any{})
+
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+				deployment,
+				replicaSet,
+				foreign,
+				newOwned(podGVK, "web-1-a", "pod-a", replicaSet, running),
+				newOwned(podGVK, "web-1-b", "pod-b", replicaSet, tt.pod),
+				// matched by the selector, but not managed by the deployment
+				newOwned(podGVK, "other-1-a", "pod-c", foreign, crashLooping),
+			)
+			kubernetes.SetClients(t, client, newMapper(deploymentGVK))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:         "deployment",
+				Name:         "web",
+				Condition:    &kubernetes.Condition{Type: "Available", Status: "True"},
+				IncludeOwned: tt.includeOwned,
+				Timeout:      time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func TestIncludeOwnedManifest(t *testing.T) {
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	deployment := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+  uid: 3f1c2a9e-0d4b-4c57-9a61-5b2d8e7f1a01
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: web
+status:
+  conditions:
+  - type: Available
+    status: "True"
+    reason: MinimumReplicasAvailable
+`
+	replicaSet := `
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  name: web-7c9d5f
+  namespace: default
+  uid: 8a4e6b21-7f3c-4d90-b2a5-1c6e9d0f3b02
+  labels:
+    app: web
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: Deployment
+    name: web
+    uid: 3f1c2a9e-0d4b-4c57-9a61-5b2d8e7f1a01
+    controller: true
+    blockOwnerDeletion: true
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: web
+`
+
+	tests := []struct {
+		name     string
+		pod      string
+		expected ph.Status
+		message  string
+	}{
+		{
+			name: "Owned pod running",
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-7c9d5f-x2k8p
+  namespace: default
+  labels:
+    app: web
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: ReplicaSet
+    name: web-7c9d5f
+    uid: 8a4e6b21-7f3c-4d90-b2a5-1c6e9d0f3b02
+    controller: true
+    blockOwnerDeletion: true
+status:
+  phase: Running
+  hostIP: 192.0.2.20
+  podIP: 10.0.0.12
+  startTime: "2024-01-01T00:00:00Z"
+  conditions:
+  - type: PodScheduled
+    status: "True"
+  - type: Ready
+    status: "True"
+  containerStatuses:
+  - name: app
+    ready: true
+    restartCount: 0
+    started: true
+    state:
+      running:
+        startedAt: "2024-01-01T00:00:05Z"
+`,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name: "Owned pod crash looping",
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-7c9d5f-x2k8p
+  namespace: default
+  labels:
+    app: web
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: ReplicaSet
+    name: web-7c9d5f
+    uid: 8a4e6b21-7f3c-4d90-b2a5-1c6e9d0f3b02
+    controller: true
+    blockOwnerDeletion: true
+status:
+  phase: Running
+  conditions:
+  - type: Ready
+    status: "False"
+    reason: ContainersNotReady
+  containerStatuses:
+  - name: app
+    ready: false
+    restartCount: 7
+    started: false
+    state:
+      waiting:
+        reason: CrashLoopBackOff
+        message: back-off 5m0s restarting failed container
+    lastState:
+      terminated:
+        exitCode: 1
+        reason: Error
+`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "pod web-7c9d5f-x2k8p has waiting containers: app (CrashLoopBackOff)",
+		},
+		{
+			name: "Unowned pod ignored",
+			pod: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-debug
+  namespace: default
+  labels:
+    app: web
+status:
+  phase: Failed
+`,
+			expected: ph.Status_HEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+				fromYAML(t, deployment),
+				fromYAML(t, replicaSet),
+				fromYAML(t, tt.pod),
+			)
+			kubernetes.SetClients(t, client, newMapper(deploymentGVK))
+
+			instance := &kubernetes.Kubernetes{
+				Kind:         "deployment",
+				Name:         "web",
+				Condition:    &kubernetes.Condition{Type: "Available", Status: "True"},
+				IncludeOwned: true,
+				Timeout:      time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+// newMetrics builds an unstructured PodMetrics with the given per-container
+// usage, or NodeMetrics with the usage of the first container if not namespaced
+func newMetrics(kind, namespace, name string, containers ...map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": name},
+	}}
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+		items := []any{}
+		for _, usage := range containers {
+			items = append(items, map[string]any{"usage": usage})
+		}
+		obj.Object["containers"] = items
+	} else {
+		obj.Object["usage"] = containers[0]
+	}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: kind})
+	return obj
+}
+
+func TestMaxUsage(t *testing.T) {
+	podGVK := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	nodeGVK := schema.GroupVersionKind{Version: "v1", Kind: "Node"}
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	podMetricsGVK := schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}
+	nodeMetricsGVK := schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for _, gvk := range []schema.GroupVersionKind{podGVK, deploymentGVK, podMetricsGVK} {
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		mapper.AddSpecific(gvk, plural, singular, meta.RESTScopeNamespace)
+		mapper.AddSpecific(gvk.GroupVersion().WithKind(strings.ToLower(gvk.Kind)), plural, singular, meta.RESTScopeNamespace)
+	}
+	for _, gvk := range []schema.GroupVersionKind{nodeGVK, nodeMetricsGVK} {
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		mapper.AddSpecific(gvk, plural, singular, meta.RESTScopeRoot)
+		mapper.AddSpecific(gvk.GroupVersion().WithKind(strings.ToLower(gvk.Kind)), plural, singular, meta.RESTScopeRoot)
+	}
+
+	objects := []runtime.Object{
+		newPod("web-1", "web", "Running"),
+		newPod("web-2", "web", "Running"),
+		newNode("worker-1", false, map[string]string{"Ready": "True"}),
+		newObject(deploymentGVK, "web", "True", ""),
+		newMetrics("PodMetrics", "default", "web-1",
+			map[string]any{"cpu": "200m", "memory": "300Mi"},
+			map[string]any{"cpu": "100m", "memory": "200Mi"},
+		),
+		newMetrics("NodeMetrics", "", "worker-1", map[string]any{"cpu": "1500m", "memory": "6Gi"}),
+	}
+
+	tests := []struct {
+		name     string
+		kind     string
+		resource string
+		maxUsage map[string]string
+		mapper   meta.RESTMapper
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Pod within limits",
+			kind:     "pod",
+			resource: "web-1",
+			maxUsage: map[string]string{"cpu": "500m", "memory": "1Gi"},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Pod exceeds limits",
+			kind:     "pod",
+			resource: "web-1",
+			maxUsage: map[string]string{"cpu": "250m", "memory": "256Mi"},
+			expected: ph.Status_UNHEALTHY,
+			message:  "cpu usage 300m exceeds 250m; memory usage 500Mi exceeds 256Mi",
+		},
+		{
+			name:     "Pod without metrics",
+			kind:     "pod",
+			resource: "web-2",
+			maxUsage: map[string]string{"cpu": "500m"},
+			expected: ph.Status_UNHEALTHY,
+			message:  "no metrics reported",
+		},
+		{
+			name:     "Node exceeds limits",
+			kind:     "node",
+			resource: "worker-1",
+			maxUsage: map[string]string{"cpu": "1", "memory": "8Gi"},
+			expected: ph.Status_UNHEALTHY,
+			message:  "cpu usage 1500m exceeds 1",
+		},
+		{
+			name:     "Unsupported kind",
+			kind:     "deployment",
+			resource: "web",
+			maxUsage: map[string]string{"cpu": "1"},
+			expected: ph.Status_UNHEALTHY,
+			message:  "maxUsage is not supported for deployment",
+		},
+		{
+			name:     "Metrics API not installed",
+			kind:     "pod",
+			resource: "web-1",
+			maxUsage: map[string]string{"cpu": "500m"},
+			mapper:   newMapper(podGVK),
+			expected: ph.Status_UNKNOWN,
+			message:  "metrics API not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+			if tt.mapper != nil {
+				kubernetes.SetClients(t, client, tt.mapper)
+			} else {
+				kubernetes.SetClients(t, client, mapper)
+			}
+
+			instance := &kubernetes.Kubernetes{
+				Kind:     tt.kind,
+				Name:     tt.resource,
+				MaxUsage: tt.maxUsage,
+				Timeout:  time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func TestMaxUsageManifest(t *testing.T) {
+	podGVK := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	nodeGVK := schema.GroupVersionKind{Version: "v1", Kind: "Node"}
+	podMetricsGVK := schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}
+	nodeMetricsGVK := schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	for _, gvk := range []schema.GroupVersionKind{podGVK, podMetricsGVK} {
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		mapper.AddSpecific(gvk, plural, singular, meta.RESTScopeNamespace)
+		mapper.AddSpecific(gvk.GroupVersion().WithKind(strings.ToLower(gvk.Kind)), plural, singular, meta.RESTScopeNamespace)
+	}
+	for _, gvk := range []schema.GroupVersionKind{nodeGVK, nodeMetricsGVK} {
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		mapper.AddSpecific(gvk, plural, singular, meta.RESTScopeRoot)
+		mapper.AddSpecific(gvk.GroupVersion().WithKind(strings.ToLower(gvk.Kind)), plural, singular, meta.RESTScopeRoot)
+	}
+
+	podMetrics := fromYAML(t, `
+apiVersion: metrics.k8s.io/v1beta1
+kind: PodMetrics
+metadata:
+  name: web-1
+  namespace: default
+  creationTimestamp: "2024-01-01T00:00:00Z"
+timestamp: "2024-01-01T00:00:00Z"
+window: 15.012s
+containers:
+- name: app
+  usage:
+    cpu: 212345678n
+    memory: "314572800"
+- name: sidecar
+  usage:
+    cpu: 100m
+    memory: 200Mi
+`)
+	nodeMetrics := fromYAML(t, `
+apiVersion: metrics.k8s.io/v1beta1
+kind: NodeMetrics
+metadata:
+  name: worker-1
+  creationTimestamp: "2024-01-01T00:00:00Z"
+timestamp: "2024-01-01T00:00:00Z"
+window: 20.05s
+usage:
+  cpu: 1500m
+  memory: 6Gi
+`)
+
+	tests := []struct {
+		name     string
+		kind     string
+		resource string
+		maxUsage map[string]string
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Pod within limits",
+			kind:     "pod",
+			resource: "web-1",
+			maxUsage: map[string]string{"cpu": "500m", "memory": "1Gi"},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Pod exceeds limits",
+			kind:     "pod",
+			resource: "web-1",
+			maxUsage: map[string]string{"cpu": "250m", "memory": "256Mi"},
+			expected: ph.Status_UNHEALTHY,
+			message:  "cpu usage 312345678n exceeds 250m; memory usage 524288k exceeds 256Mi",
+		},
+		{
+			name:     "Node exceeds limits",
+			kind:     "node",
+			resource: "worker-1",
+			maxUsage: map[string]string{"cpu": "2", "memory": "4Gi"},
+			expected: ph.Status_UNHEALTHY,
+			message:  "memory usage 6Gi exceeds 4Gi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+				newPod("web-1", "web", "Running"),
+				newNode("worker-1", false, map[string]string{"Ready": "True"}),
+				podMetrics.DeepCopy(),
+				nodeMetrics.DeepCopy(),
+			)
+			kubernetes.SetClients(t, client, mapper)
+
+			instance := &kubernetes.Kubernetes{
+				Kind:     tt.kind,
+				Name:     tt.resource,
+				MaxUsage: tt.maxUsage,
+				Timeout:  time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
+
+func TestCurrentNamespace(t *testing.T) {
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+	const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+  - name: test
+    cluster:
+      server: https://127.0.0.1:6443
+users:
+  - name: test
+    user: {}
+contexts:
+  - name: test
+    context:
+      cluster: test
+      user: test
+      namespace: shop
+current-context: test
+`
+
+	tests := []struct {
+		name      string
+		namespace string
+		expected  ph.Status
+	}{
+		{
+			name:     "Current context namespace",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:      "Explicit namespace",
+			namespace: "default",
+			expected:  ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkout := newPod("checkout", "checkout", "Running")
+			checkout.SetNamespace("shop")
+
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), checkout)
+			kubernetes.SetClients(t, client, newMapper(pod))
+
+			path := filepath.Join(t.TempDir(), "kubeconfig")
+			require.NoError(t, os.WriteFile(path, []byte(kubeconfig), 0o600))
+			t.Setenv("KUBECONFIG", path)
+
+			instance := &kubernetes.Kubernetes{
+				Kind:      "pod",
+				Name:      "checkout",
+				Namespace: tt.namespace,
+				Phase:     "Running",
+				Timeout:   time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// nodePressure maps node pressure condition types to their display names
+var nodePressure = map[string]string{
+	string(v1.NodeMemoryPressure): "memory",
+	string(v1.NodeDiskPressure):   "disk",
+	string(v1.NodePIDPressure):    "pid",
+}
+
+// checkNode validates that a Node is ready, under no memory, disk or PID
+// pressure, and schedulable.
+func checkNode(resource Resource) string {
+	ready := false
+	readyMessage := ""
+	var pressure []string
+	for _, condition := range resource.Status.Conditions {
+		switch {
+		case condition.Type == string(v1.NodeReady):
+			ready = condition.Status == v1.ConditionTrue
+			readyMessage = condition.Message
+		case nodePressure[condition.Type] != "" && condition.Status == v1.ConditionTrue:
+			pressure = append(pressure, nodePressure[condition.Type])
+		}
+	}
+
+	if !ready {
+		if readyMessage != "" {
+			return fmt.Sprintf("node is not ready: %s", readyMessage)
+		}
+		return "node is not ready"
+	}
+
+	if len(pressure) > 0 {
+		slices.Sort(pressure)
+		return fmt.Sprintf("node is under %s pressure", strings.Join(pressure, ", "))
+	}
+
+	if resource.Spec.Unschedulable {
+		return "node is unschedulable"
+	}
+
+	return ""
+}
@@ -0,0 +1,141 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	podsResource        = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	replicaSetsResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+)
+
+// pod is the subset of a Pod determining whether it is running correctly
+type pod struct {
+	Status struct {
+		Phase      string `json:"phase,omitempty"`
+		Conditions []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+		ContainerStatuses []struct {
+			Name  string `json:"name"`
+			State struct {
+				Waiting *struct {
+					Reason string `json:"reason,omitempty"`
+				} `json:"waiting,omitempty"`
+			} `json:"state"`
+		} `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// checkOwnedPods validates the pods managed by a workload resource, either
+// directly or, for a Deployment, through its ReplicaSets. Pods are found by
+// the resource's own selector and confirmed by their owner references.
+func checkOwnedPods(ctx context.Context, client dynamic.Interface, blob *unstructured.Unstructured) string {
+	selector, err := podSelector(blob.Object)
+	if err != nil {
+		return err.Error()
+	}
+	options := metav1.ListOptions{LabelSelector: selector}
+
+	owners := map[types.UID]bool{blob.GetUID(): true}
+	if blob.GetKind() == "Deployment" {
+		replicaSets, err := client.Resource(replicaSetsResource).Namespace(blob.GetNamespace()).List(ctx, options)
+		if err != nil {
+			return fmt.Sprintf("failed to list replicasets: %v", err)
+		}
+		for _, replicaSet := range replicaSets.Items {
+			if ownedBy(&replicaSet, owners) {
+				owners[replicaSet.GetUID()] = true
+			}
+		}
+	}
+
+	pods, err := client.Resource(podsResource).Namespace(blob.GetNamespace()).List(ctx, options)
+	if err != nil {
+		return fmt.Sprintf("failed to list pods: %v", err)
+	}
+	for _, item := range pods.Items {
+		if !ownedBy(&item, owners) {
+			continue
+		}
+		if msg := checkPod(item.Object); msg != "" {
+			return fmt.Sprintf("pod %s %s", item.GetName(), msg)
+		}
+	}
+
+	return ""
+}
+
+// podSelector returns the pod label selector of a workload resource.
+func podSelector(obj map[string]any) (string, error) {
+	raw, found, err := unstructured.NestedMap(obj, "spec", "selector")
+	if err != nil || !found {
+		return "", fmt.Errorf("resource has no pod selector")
+	}
+
+	var labelSelector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &labelSelector); err != nil {
+		return "", fmt.Errorf("invalid pod selector: %w", err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid pod selector: %w", err)
+	}
+
+	return selector.String(), nil
+}
+
+// ownedBy reports whether obj is controlled by any of owners.
+func ownedBy(obj *unstructured.Unstructured, owners map[types.UID]bool) bool {
+	if controller := metav1.GetControllerOfNoCopy(obj); controller != nil {
+		return owners[controller.UID]
+	}
+	return false
+}
+
+// checkPod validates that a pod has completed or is running and ready, with
+// no container waiting on an error such as CrashLoopBackOff.
+func checkPod(obj map[string]any) string {
+	var p pod
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &p); err != nil {
+		return fmt.Sprintf("is invalid: %v", err)
+	}
+
+	switch p.Status.Phase {
+	case "Succeeded":
+		return ""
+	case "Failed":
+		return "has failed"
+	}
+
+	var waiting []string
+	for _, container := range p.Status.ContainerStatuses {
+		if container.State.Waiting != nil && container.State.Waiting.Reason != "" && container.State.Waiting.Reason != "ContainerCreating" {
+			waiting = append(waiting, fmt.Sprintf("%s (%s)", container.Name, container.State.Waiting.Reason))
+		}
+	}
+	if len(waiting) > 0 {
+		return "has waiting containers: " + strings.Join(waiting, ", ")
+	}
+
+	for _, condition := range p.Status.Conditions {
+		if condition.Type == "Ready" {
+			if condition.Status == "True" {
+				return ""
+			}
+			break
+		}
+	}
+
+	return "is not ready"
+}
@@ -14,7 +14,12 @@ type Resource struct {
 		Name      string `json:"name"`
 		Namespace string `json:"namespace"`
 	} `json:"metadata"`
+	Spec struct {
+		Unschedulable bool `json:"unschedulable,omitempty"`
+	} `json:"spec"`
 	Status struct {
+		Phase      string            `json:"phase,omitempty"`
+		Capacity   map[string]string `json:"capacity,omitempty"`
 		Conditions []struct {
 			Type    string             `json:"type"`
 			Status  v1.ConditionStatus `json:"status"`
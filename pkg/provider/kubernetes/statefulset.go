@@ -0,0 +1,74 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// statefulSet is the subset of a StatefulSet determining rollout progress
+type statefulSet struct {
+	Metadata struct {
+		Generation int64 `json:"generation"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas       *int32 `json:"replicas,omitempty"`
+		UpdateStrategy struct {
+			Type          string `json:"type,omitempty"`
+			RollingUpdate *struct {
+				Partition *int32 `json:"partition,omitempty"`
+			} `json:"rollingUpdate,omitempty"`
+		} `json:"updateStrategy"`
+	} `json:"spec"`
+	Status struct {
+		ObservedGeneration int64  `json:"observedGeneration,omitempty"`
+		ReadyReplicas      int32  `json:"readyReplicas,omitempty"`
+		CurrentReplicas    int32  `json:"currentReplicas,omitempty"`
+		UpdatedReplicas    int32  `json:"updatedReplicas,omitempty"`
+		CurrentRevision    string `json:"currentRevision,omitempty"`
+		UpdateRevision     string `json:"updateRevision,omitempty"`
+	} `json:"status"`
+}
+
+// checkStatefulSet validates that a StatefulSet has completed its rollout, as
+// `kubectl rollout status` would: the latest spec has been observed, all
+// replicas are ready, and all replicas (above any partition) are updated to
+// the latest revision.
+func checkStatefulSet(obj map[string]any) string {
+	var sts statefulSet
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &sts); err != nil {
+		return fmt.Sprintf("invalid statefulset: %v", err)
+	}
+
+	if sts.Status.ObservedGeneration < sts.Metadata.Generation {
+		return "rollout in progress: waiting for spec update to be observed"
+	}
+
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+
+	if sts.Status.ReadyReplicas < replicas {
+		return fmt.Sprintf("rollout in progress: %d of %d replicas ready", sts.Status.ReadyReplicas, replicas)
+	}
+
+	if sts.Spec.UpdateStrategy.Type == string(appsv1.OnDeleteStatefulSetStrategyType) {
+		return ""
+	}
+
+	if rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		expected := max(replicas-*rollingUpdate.Partition, 0)
+		if sts.Status.UpdatedReplicas < expected {
+			return fmt.Sprintf("rollout in progress: %d of %d replicas updated", sts.Status.UpdatedReplicas, expected)
+		}
+		return ""
+	}
+
+	if sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		return fmt.Sprintf("rollout in progress: %d of %d replicas updated", sts.Status.UpdatedReplicas, replicas)
+	}
+
+	return ""
+}
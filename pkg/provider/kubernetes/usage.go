@@ -0,0 +1,119 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	metricsGroup   = "metrics.k8s.io"
+	metricsVersion = "v1beta1"
+)
+
+// errMetricsUnavailable is returned when the metrics API is not installed
+var errMetricsUnavailable = errors.New("metrics API not available")
+
+// metrics is the subset of a PodMetrics or NodeMetrics reporting resource usage
+type metrics struct {
+	Usage      map[string]string `json:"usage,omitempty"`
+	Containers []struct {
+		Usage map[string]string `json:"usage"`
+	} `json:"containers,omitempty"`
+}
+
+// metricsMapping returns the mapping of the metrics.k8s.io resource reporting
+// the usage of resources of the given kind.
+func metricsMapping(mapper meta.RESTMapper, kind string) (*meta.RESTMapping, error) {
+	var metricsKind string
+	switch strings.ToLower(kind) {
+	case "pod":
+		metricsKind = "PodMetrics"
+	case "node":
+		metricsKind = "NodeMetrics"
+	default:
+		return nil, fmt.Errorf("maxUsage is not supported for %s", kind)
+	}
+
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: metricsGroup, Kind: metricsKind}, metricsVersion)
+	if meta.IsNoMatchError(err) {
+		return nil, errMetricsUnavailable
+	}
+	return mapping, err
+}
+
+// checkUsage validates that the resource usage reported by the metrics API for
+// a pod or node is within maxUsage.
+func checkUsage(ctx context.Context, client dynamic.Interface, mapping *meta.RESTMapping, blob *unstructured.Unstructured, maxUsage map[string]string) string {
+	var resourceClient dynamic.ResourceInterface = client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resourceClient = client.Resource(mapping.Resource).Namespace(blob.GetNamespace())
+	}
+
+	obj, err := resourceClient.Get(ctx, blob.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "no metrics reported"
+	} else if err != nil {
+		return fmt.Sprintf("failed to get metrics: %v", err)
+	}
+
+	var m metrics
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &m); err != nil {
+		return fmt.Sprintf("invalid metrics: %v", err)
+	}
+
+	usage := map[string]*apiresource.Quantity{}
+	add := func(name, value string) error {
+		quantity, err := apiresource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s usage %q: %v", name, value, err)
+		}
+		if total, ok := usage[name]; ok {
+			total.Add(quantity)
+		} else {
+			usage[name] = &quantity
+		}
+		return nil
+	}
+	for name, value := range m.Usage {
+		if err := add(name, value); err != nil {
+			return err.Error()
+		}
+	}
+	for _, container := range m.Containers {
+		for name, value := range container.Usage {
+			if err := add(name, value); err != nil {
+				return err.Error()
+			}
+		}
+	}
+
+	var exceeded []string
+	for _, name := range slices.Sorted(maps.Keys(maxUsage)) {
+		limit, err := apiresource.ParseQuantity(maxUsage[name])
+		if err != nil {
+			return fmt.Sprintf("invalid maxUsage %s %q: %v", name, maxUsage[name], err)
+		}
+		actual, ok := usage[name]
+		if !ok {
+			return fmt.Sprintf("no %s usage reported", name)
+		}
+		if actual.Cmp(limit) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s usage %s exceeds %s", name, actual.String(), maxUsage[name]))
+		}
+	}
+
+	return strings.Join(exceeded, "; ")
+}
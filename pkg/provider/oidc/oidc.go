@@ -0,0 +1,240 @@
+package oidc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypeOIDC = "oidc"
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+type OIDC struct {
+	Name      string        `mapstructure:"name"`
+	Issuer    string        `mapstructure:"issuer"`
+	JWKS      bool          `mapstructure:"jwks"`
+	Endpoints []string      `mapstructure:"endpoints"`
+	Timeout   time.Duration `mapstructure:"timeout" default:"5s"`
+	Insecure  bool          `mapstructure:"insecure"`
+	Detail    bool          `mapstructure:"detail"`
+}
+
+// configuration is the OpenID Provider metadata served at the discovery endpoint
+type configuration map[string]any
+
+type jwks struct {
+	Keys []json.RawMessage `json:"keys"`
+}
+
+var certPool *x509.CertPool = nil
+
+func init() {
+	provider.Register(TypeOIDC, new(OIDC))
+	if systemCertPool, err := x509.SystemCertPool(); err == nil {
+		certPool = systemCertPool
+	}
+}
+
+func (i *OIDC) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("issuer", i.Issuer),
+		slog.Bool("jwks", i.JWKS),
+		slog.Any("endpoints", i.Endpoints),
+		slog.Any("timeout", i.Timeout),
+		slog.Bool("insecure", i.Insecure),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *OIDC) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *OIDC) GetType() string {
+	return TypeOIDC
+}
+
+func (i *OIDC) GetName() string {
+	return i.Name
+}
+
+func (i *OIDC) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *OIDC) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypeOIDC), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypeOIDC,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	client := i.client()
+
+	var config configuration
+	if err := i.fetchJSON(ctx, client, strings.TrimSuffix(i.Issuer, "/")+discoveryPath, &config); err != nil {
+		return component.Unhealthy(fmt.Sprintf("discovery: %v", err))
+	}
+
+	detail := &details.Detail_OIDC{
+		Issuer:    config.get("issuer"),
+		Endpoints: config.endpoints(),
+	}
+
+	var jwksErr error
+	if i.JWKS {
+		jwksErr = i.probeJWKS(ctx, client, config.get("jwks_uri"))
+		detail.JwksOk = jwksErr == nil
+	}
+
+	if i.Detail {
+		if anyDetail, err := anypb.New(detail); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, anyDetail)
+		}
+	}
+
+	if detail.Issuer != i.Issuer {
+		return component.Unhealthy(fmt.Sprintf("discovery: issuer %q does not match %q", detail.Issuer, i.Issuer))
+	}
+
+	if jwksErr != nil {
+		return component.Unhealthy(fmt.Sprintf("jwks: %v", jwksErr))
+	}
+
+	for _, endpoint := range i.Endpoints {
+		endpointURI := detail.Endpoints[endpoint]
+		if endpointURI == "" {
+			return component.Unhealthy(fmt.Sprintf("discovery: no %s", endpoint))
+		}
+		if err := i.probe(ctx, client, endpointURI); err != nil {
+			return component.Unhealthy(fmt.Sprintf("%s: %v", endpoint, err))
+		}
+	}
+
+	return component.Healthy()
+}
+
+func (i *OIDC) client() *http.Client {
+	tlsConf := &tls.Config{
+		RootCAs: certPool,
+	}
+	if i.Insecure {
+		tlsConf.InsecureSkipVerify = true
+	}
+	return &http.Client{
+		Timeout:   i.Timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConf},
+	}
+}
+
+// fetchJSON fetches and decodes the JSON document at url
+func (i *OIDC) fetchJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid document: %w", err)
+	}
+
+	return nil
+}
+
+// probeJWKS verifies that the key set at uri parses and contains at least one key
+func (i *OIDC) probeJWKS(ctx context.Context, client *http.Client, uri string) error {
+	if uri == "" {
+		return errors.New("no jwks_uri advertised")
+	}
+
+	var keys jwks
+	if err := i.fetchJSON(ctx, client, uri, &keys); err != nil {
+		return err
+	}
+	if len(keys.Keys) == 0 {
+		return errors.New("no keys")
+	}
+
+	return nil
+}
+
+// probe verifies that the endpoint is served; endpoints such as the
+// authorization endpoint reject bare requests, so only server errors fail.
+func (i *OIDC) probe(ctx context.Context, client *http.Client, url string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+func (c configuration) get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+// endpoints returns the advertised endpoint URIs, keyed by metadata name
+func (c configuration) endpoints() map[string]string {
+	endpoints := make(map[string]string)
+	for key := range c {
+		if strings.HasSuffix(key, "_endpoint") || strings.HasSuffix(key, "_uri") {
+			if value := c.get(key); value != "" {
+				endpoints[key] = value
+			}
+		}
+	}
+	return endpoints
+}
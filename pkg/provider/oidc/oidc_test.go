@@ -0,0 +1,217 @@
+package oidc_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/oidc"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+const jwksDocument = `{"keys":[{"kty":"RSA","kid":"test","use":"sig","n":"AQAB","e":"AQAB"}]}`
+
+func TestOIDC(t *testing.T) {
+	tests := []struct {
+		name      string
+		discovery func(issuer string) string
+		jwks      string
+		jwksCheck bool
+		endpoints []string
+		expected  ph.Status
+		message   string
+	}{
+		{
+			name: "Valid discovery and JWKS",
+			discovery: func(issuer string) string {
+				return fmt.Sprintf(`{"issuer":%q,"jwks_uri":"%s/jwks","authorization_endpoint":"%s/authorize","token_endpoint":"%s/token"}`, issuer, issuer, issuer, issuer)
+			},
+			jwks:      jwksDocument,
+			jwksCheck: true,
+			endpoints: []string{"authorization_endpoint", "token_endpoint"},
+			expected:  ph.Status_HEALTHY,
+		},
+		{
+			name: "Malformed discovery document",
+			discovery: func(string) string {
+				return `{"issuer":`
+			},
+			jwksCheck: true,
+			expected:  ph.Status_UNHEALTHY,
+		},
+		{
+			name: "Issuer mismatch",
+			discovery: func(issuer string) string {
+				return fmt.Sprintf(`{"issuer":"https://other.example.com","jwks_uri":"%s/jwks"}`, issuer)
+			},
+			jwks:      jwksDocument,
+			jwksCheck: true,
+			expected:  ph.Status_UNHEALTHY,
+		},
+		{
+			name: "Unreachable JWKS",
+			discovery: func(issuer string) string {
+				return fmt.Sprintf(`{"issuer":%q,"jwks_uri":"http://127.0.0.1:1/jwks"}`, issuer)
+			},
+			jwksCheck: true,
+			expected:  ph.Status_UNHEALTHY,
+		},
+		{
+			name: "Unreachable JWKS ignored",
+			discovery: func(issuer string) string {
+				return fmt.Sprintf(`{"issuer":%q,"jwks_uri":"http://127.0.0.1:1/jwks"}`, issuer)
+			},
+			jwksCheck: false,
+			expected:  ph.Status_HEALTHY,
+		},
+		{
+			name: "Empty JWKS",
+			discovery: func(issuer string) string {
+				return fmt.Sprintf(`{"issuer":%q,"jwks_uri":"%s/jwks"}`, issuer, issuer)
+			},
+			jwks:      `{"keys":[]}`,
+			jwksCheck: true,
+			expected:  ph.Status_UNHEALTHY,
+			message:   "jwks: no keys",
+		},
+		{
+			name: "Missing endpoint",
+			discovery: func(issuer string) string {
+				return fmt.Sprintf(`{"issuer":%q,"jwks_uri":"%s/jwks"}`, issuer, issuer)
+			},
+			jwks:      jwksDocument,
+			jwksCheck: true,
+			endpoints: []string{"userinfo_endpoint"},
+			expected:  ph.Status_UNHEALTHY,
+			message:   "discovery: no userinfo_endpoint",
+		},
+		{
+			name: "Failing endpoint",
+			discovery: func(issuer string) string {
+				return fmt.Sprintf(`{"issuer":%q,"jwks_uri":"%s/jwks","token_endpoint":"%s/broken"}`, issuer, issuer, issuer)
+			},
+			jwks:      jwksDocument,
+			jwksCheck: true,
+			endpoints: []string{"token_endpoint"},
+			expected:  ph.Status_UNHEALTHY,
+			message:   "token_endpoint: status 503",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issuer string
+			mux := http.NewServeMux()
+			mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.discovery(issuer)))
+			})
+			mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.jwks))
+			})
+			mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			})
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			})
+			mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+			issuer = server.URL
+
+			instance := &oidc.OIDC{
+				Name:      "TestOIDC",
+				Issuer:    issuer,
+				JWKS:      tt.jwksCheck,
+				Endpoints: tt.endpoints,
+				Timeout:   time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, oidc.TypeOIDC, result.GetType())
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+		})
+	}
+}
+
+func TestOIDCDetail(t *testing.T) {
+	tests := []struct {
+		name   string
+		jwks   string
+		jwksOk bool
+	}{
+		{
+			name:   "JWKS reachable",
+			jwks:   "/jwks",
+			jwksOk: true,
+		},
+		{
+			name:   "JWKS unreachable",
+			jwks:   "/missing",
+			jwksOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issuer string
+			mux := http.NewServeMux()
+			mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"issuer":%q,"jwks_uri":"%s%s","token_endpoint":"%s/token","response_types_supported":["code"]}`, issuer, issuer, tt.jwks, issuer)
+			})
+			mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(jwksDocument))
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+			issuer = server.URL
+
+			instance := &oidc.OIDC{
+				Name:    "TestOIDCDetail",
+				Issuer:  issuer,
+				JWKS:    true,
+				Timeout: time.Second,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_OIDC{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+
+			assert.Equal(t, issuer, detail.Issuer)
+			assert.Equal(t, map[string]string{
+				"jwks_uri":       issuer + tt.jwks,
+				"token_endpoint": issuer + "/token",
+			}, detail.Endpoints)
+			assert.Equal(t, tt.jwksOk, detail.JwksOk)
+		})
+	}
+}
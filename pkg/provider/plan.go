@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Step describes how an instance would be checked.
+type Step struct {
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	Timeout   string        `json:"timeout,omitempty"`
+	DependsOn []string      `json:"dependsOn,omitempty"`
+	Requires  *Requirements `json:"requires,omitempty"`
+	Attempts  int           `json:"attempts,omitempty"`
+	CacheTTL  string        `json:"cacheTTL,omitempty"`
+}
+
+// Plan returns the steps by which instances would be checked, ordered by type
+// and name such that every instance follows those it depends on, without
+// checking any instance.
+func Plan(instances []Instance) ([]Step, error) {
+	instances = slices.Clone(instances)
+	slices.SortStableFunc(instances, func(a, b Instance) int {
+		return cmp.Or(cmp.Compare(a.GetType(), b.GetType()), cmp.Compare(a.GetName(), b.GetName()))
+	})
+
+	sorted, err := SortByDependencies(instances)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]Step, 0, len(sorted))
+	for _, instance := range sorted {
+		step := Step{
+			Type:      instance.GetType(),
+			Name:      instance.GetName(),
+			DependsOn: dependencies(instance),
+		}
+		if i, ok := instance.(InstanceWithTimeout); ok && i.GetTimeout() > 0 {
+			step.Timeout = i.GetTimeout().Round(time.Millisecond).String()
+		}
+		for wrapped := instance; wrapped != nil; {
+			switch w := wrapped.(type) {
+			case *Dependent:
+				wrapped = w.Instance
+			case *Conditional:
+				step.Requires = &w.Requires
+				wrapped = w.Instance
+			case *Cached:
+				step.CacheTTL = w.TTL.String()
+				wrapped = w.Instance
+			case *Retrying:
+				step.Attempts = w.Retry.Attempts
+				wrapped = w.Instance
+			default:
+				wrapped = nil
+			}
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,49 @@
+package provider_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+	"github.com/isometry/platform-health/pkg/provider/tcp"
+)
+
+func TestPlan(t *testing.T) {
+	web := &countingInstance{Mock: mock.Mock{Name: "web"}}
+	cache := &countingInstance{Mock: mock.Mock{Name: "cache"}}
+	database := &tcp.TCP{Name: "database", Host: "192.0.2.1", Port: 5432}
+	database.SetDefaults()
+
+	instances := []provider.Instance{
+		&provider.Dependent{
+			Instance:  &provider.Retrying{Instance: web, Retry: provider.RetryPolicy{Attempts: 3, Delay: time.Second, Backoff: 2}},
+			DependsOn: []string{"cache", "database"},
+		},
+		&provider.Conditional{Instance: cache, Requires: provider.Requirements{Env: []string{"CACHE_URL"}}},
+		database,
+	}
+
+	steps, err := provider.Plan(instances)
+	require.NoError(t, err)
+
+	assert.Equal(t, []provider.Step{
+		{Type: "mock", Name: "cache", Requires: &provider.Requirements{Env: []string{"CACHE_URL"}}},
+		{Type: "tcp", Name: "database", Timeout: database.Timeout.String()},
+		{Type: "mock", Name: "web", Timeout: "3s", DependsOn: []string{"cache", "database"}, Attempts: 3},
+	}, steps)
+
+	// planning must not check any instance
+	assert.Zero(t, web.checks.Load())
+	assert.Zero(t, cache.checks.Load())
+}
+
+func TestPlanInvalidDependencies(t *testing.T) {
+	_, err := provider.Plan([]provider.Instance{
+		&provider.Dependent{Instance: &mock.Mock{Name: "orphan"}, DependsOn: []string{"missing"}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypePlugin = "plugin"
+
+// Plugin checks health by invoking an external command implementing the
+// plugin protocol: a JSON Request is written to its stdin, and it must write
+// a HealthCheckResponse in protobuf JSON to its stdout.
+type Plugin struct {
+	Name    string        `mapstructure:"name"`
+	Command string        `mapstructure:"command"`
+	Args    []string      `mapstructure:"args"`
+	Env     []EnvVar      `mapstructure:"env"`
+	Config  []Setting     `mapstructure:"config"`
+	Timeout time.Duration `mapstructure:"timeout" default:"5s"`
+}
+
+// EnvVar is an environment variable set for the plugin command. Variables are
+// listed rather than keyed by name, as configuration keys are case-insensitive.
+type EnvVar struct {
+	Name  string `mapstructure:"name"`
+	Value string `mapstructure:"value"`
+}
+
+// Setting is an entry of the configuration passed to the plugin, listed rather
+// than keyed by name for the same reason as EnvVar.
+type Setting struct {
+	Name  string `mapstructure:"name"`
+	Value any    `mapstructure:"value"`
+}
+
+// Request is written to the stdin of the plugin command
+type Request struct {
+	Name    string         `json:"name"`
+	Config  map[string]any `json:"config,omitempty"`
+	Timeout string         `json:"timeout"`
+}
+
+// waitDelay bounds the time to wait for output after the plugin command is killed
+const waitDelay = 100 * time.Millisecond
+
+func init() {
+	provider.Register(TypePlugin, new(Plugin))
+}
+
+func (i *Plugin) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("command", i.Command),
+		slog.Any("args", i.Args),
+		slog.Any("timeout", i.Timeout),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *Plugin) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *Plugin) GetType() string {
+	return TypePlugin
+}
+
+func (i *Plugin) GetName() string {
+	return i.Name
+}
+
+func (i *Plugin) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *Plugin) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypePlugin), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypePlugin,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	request, err := json.Marshal(Request{Name: i.Name, Config: i.config(), Timeout: i.Timeout.String()})
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, i.Command, i.Args...)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = waitDelay
+	cmd.Env = os.Environ()
+	for _, env := range i.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", env.Name, env.Value))
+	}
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return component.Unhealthy(ctx.Err().Error())
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return component.Unhealthy(fmt.Sprintf("plugin %s: %s", exitErr, msg))
+			}
+			return component.Unhealthy(fmt.Sprintf("plugin %s", exitErr))
+		}
+		return component.Unhealthy(err.Error())
+	}
+
+	result := &ph.HealthCheckResponse{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(stdout.Bytes(), result); err != nil {
+		return component.Unhealthy(fmt.Sprintf("invalid plugin response: %v", err))
+	}
+
+	component.Status = result.GetStatus()
+	component.Message = result.GetMessage()
+	component.Details = result.GetDetails()
+	component.Components = result.GetComponents()
+	component.Warnings = result.GetWarnings()
+
+	return component
+}
+
+// config returns the configuration passed to the plugin, keyed by name.
+func (i *Plugin) config() map[string]any {
+	if len(i.Config) == 0 {
+		return nil
+	}
+	config := make(map[string]any, len(i.Config))
+	for _, setting := range i.Config {
+		config[setting.Name] = setting.Value
+	}
+	return config
+}
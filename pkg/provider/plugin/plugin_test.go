@@ -0,0 +1,202 @@
+package plugin_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isometry/platform-health/pkg/config"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/plugin"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+// TestMain runs the test binary as a sample plugin when PLUGIN_TEST_MODE is set
+func TestMain(m *testing.M) {
+	if mode := os.Getenv("PLUGIN_TEST_MODE"); mode != "" {
+		os.Exit(samplePlugin(mode))
+	}
+	os.Exit(m.Run())
+}
+
+// samplePlugin implements the plugin protocol, answering according to mode
+func samplePlugin(mode string) int {
+	var request plugin.Request
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	switch mode {
+	case "echo":
+		fmt.Printf(`{"status":"HEALTHY","message":"hello %s, region %v, timeout %s"}`, request.Name, request.Config["region"], request.Timeout)
+	case "config":
+		fmt.Printf(`{"status":"HEALTHY","message":"token %s, config %v"}`, os.Getenv("QUEUE_TOKEN"), request.Config)
+	case "unhealthy":
+		fmt.Print(`{"status":"UNHEALTHY","message":"queue backlog 1024"}`)
+	case "tree":
+		fmt.Print(`{"status":"UNHEALTHY","components":[{"type":"queue","name":"orders","status":"HEALTHY"},{"type":"queue","name":"payments","status":"UNHEALTHY","message":"stalled"}]}`)
+	case "fail":
+		fmt.Fprintln(os.Stderr, "license expired")
+		return 3
+	case "garbage":
+		fmt.Print("not json")
+	case "hang":
+		time.Sleep(10 * time.Second)
+	}
+	return 0
+}
+
+func TestPlugin(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		timeout    time.Duration
+		status     ph.Status
+		message    string
+		components int
+	}{
+		{
+			name:    "Request passed to plugin",
+			mode:    "echo",
+			status:  ph.Status_HEALTHY,
+			message: "hello TestPlugin, region eu-west-1, timeout 2s",
+		},
+		{
+			name:    "Unhealthy result",
+			mode:    "unhealthy",
+			status:  ph.Status_UNHEALTHY,
+			message: "queue backlog 1024",
+		},
+		{
+			name:       "Components merged into tree",
+			mode:       "tree",
+			status:     ph.Status_UNHEALTHY,
+			components: 2,
+		},
+		{
+			name:    "Non-zero exit",
+			mode:    "fail",
+			status:  ph.Status_UNHEALTHY,
+			message: "plugin exit status 3: license expired",
+		},
+		{
+			name:   "Invalid response",
+			mode:   "garbage",
+			status: ph.Status_UNHEALTHY,
+		},
+		{
+			name:    "Timeout",
+			mode:    "hang",
+			timeout: 200 * time.Millisecond,
+			status:  ph.Status_UNHEALTHY,
+			message: context.DeadlineExceeded.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &plugin.Plugin{
+				Name:    "TestPlugin",
+				Command: os.Args[0],
+				Env:     []plugin.EnvVar{{Name: "PLUGIN_TEST_MODE", Value: tt.mode}},
+				Config:  []plugin.Setting{{Name: "region", Value: "eu-west-1"}},
+				Timeout: 2 * time.Second,
+			}
+			if tt.timeout > 0 {
+				instance.Timeout = tt.timeout
+			}
+			instance.SetDefaults()
+
+			start := time.Now()
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, plugin.TypePlugin, result.GetType())
+			assert.Equal(t, "TestPlugin", result.GetName())
+			assert.Equal(t, tt.status, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+			assert.Len(t, result.GetComponents(), tt.components)
+			assert.Less(t, time.Since(start), 2*instance.Timeout)
+		})
+	}
+}
+
+func TestPluginRegistered(t *testing.T) {
+	assert.Contains(t, provider.ProviderList(), plugin.TypePlugin)
+
+	instance := &plugin.Plugin{
+		Name:    "TestPluginRegistered",
+		Command: os.Args[0],
+		Env:     []plugin.EnvVar{{Name: "PLUGIN_TEST_MODE", Value: "tree"}},
+	}
+	instance.SetDefaults()
+
+	response, status := provider.Check(context.Background(), []provider.Instance{instance})
+	assert.Equal(t, ph.Status_UNHEALTHY, status)
+	require.Len(t, response, 1)
+	require.Len(t, response[0].GetComponents(), 2)
+	assert.Equal(t, "payments", response[0].GetComponents()[1].GetName())
+	assert.Equal(t, "stalled", response[0].GetComponents()[1].GetMessage())
+}
+
+func TestPluginMissingCommand(t *testing.T) {
+	instance := &plugin.Plugin{
+		Name:    "TestPluginMissingCommand",
+		Command: "/nonexistent/plugin",
+	}
+	instance.SetDefaults()
+
+	result := instance.GetHealth(context.Background())
+	require.NotNil(t, result)
+	assert.Equal(t, ph.Status_UNHEALTHY, result.GetStatus())
+	assert.Contains(t, result.GetMessage(), "no such file or directory")
+}
+
+func TestPluginConfigLoad(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	dir := t.TempDir()
+	content := fmt.Sprintf(`plugin:
+  - name: orders
+    command: %q
+    env:
+      - name: PLUGIN_TEST_MODE
+        value: config
+      - name: QUEUE_TOKEN
+        value: s3cr3t
+    config:
+      - name: queue
+        value: orders
+      - name: maxBacklog
+        value: 100
+`, os.Args[0])
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "platform-health.yaml"), []byte(content), 0o600))
+
+	conf, err := config.Load(context.Background(), []string{dir}, "platform-health")
+	require.NoError(t, err)
+
+	instances := conf.GetInstances()
+	require.Len(t, instances, 1)
+
+	result := instances[0].GetHealth(context.Background())
+	require.NotNil(t, result)
+	assert.Equal(t, ph.Status_HEALTHY, result.GetStatus())
+	assert.Equal(t, "token s3cr3t, config map[maxBacklog:100 queue:orders]", result.GetMessage())
+}
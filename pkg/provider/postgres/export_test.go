@@ -0,0 +1,12 @@
+package postgres
+
+import "testing"
+
+// SetDriver replaces the database/sql driver for the duration of a test
+func SetDriver(t *testing.T, name string) {
+	t.Helper()
+
+	original := driverName
+	driverName = name
+	t.Cleanup(func() { driverName = original })
+}
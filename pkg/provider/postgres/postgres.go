@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypePostgres = "postgres"
+
+type Postgres struct {
+	Name         string        `mapstructure:"name"`
+	DSN          string        `mapstructure:"dsn"`
+	Query        string        `mapstructure:"query" default:"SELECT 1"`
+	SSLMode      string        `mapstructure:"sslMode"`
+	MaxOpenConns int           `mapstructure:"maxOpenConns" default:"1"`
+	MinRows      int           `mapstructure:"minRows"`
+	Timeout      time.Duration `mapstructure:"timeout" default:"5s"`
+	Detail       bool          `mapstructure:"detail"`
+}
+
+// maxDetailRows is the maximum number of result rows included in the response details
+const maxDetailRows = 100
+
+// driverName is the database/sql driver used to connect
+var driverName = "postgres"
+
+func init() {
+	provider.Register(TypePostgres, new(Postgres))
+}
+
+func (i *Postgres) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("query", i.Query),
+		slog.String("sslMode", i.SSLMode),
+		slog.Int("maxOpenConns", i.MaxOpenConns),
+		slog.Int("minRows", i.MinRows),
+		slog.Any("timeout", i.Timeout),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *Postgres) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *Postgres) GetType() string {
+	return TypePostgres
+}
+
+func (i *Postgres) GetName() string {
+	return i.Name
+}
+
+func (i *Postgres) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *Postgres) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypePostgres), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypePostgres,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	dsn, err := i.dataSourceName()
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(i.MaxOpenConns)
+
+	detail := &details.Detail_Postgres{}
+
+	start := time.Now()
+	if err := db.PingContext(ctx); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+	detail.Ping = durationpb.New(time.Since(start))
+
+	rows, err := query(ctx, db, i.Query)
+	if err != nil {
+		return component.Unhealthy(fmt.Sprintf("query: %v", err))
+	}
+	for _, row := range rows[:min(len(rows), maxDetailRows)] {
+		detail.Rows = append(detail.Rows, &details.Detail_Postgres_Row{Columns: row})
+	}
+
+	if i.Detail {
+		if anyDetail, err := anypb.New(detail); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, anyDetail)
+		}
+	}
+
+	if len(rows) < i.MinRows {
+		return component.Unhealthy(fmt.Sprintf("expected at least %d rows; actual %d rows", i.MinRows, len(rows)))
+	}
+
+	return component.Healthy()
+}
+
+// dataSourceName returns the DSN, in either URL or key/value form, with the
+// configured sslmode applied.
+func (i *Postgres) dataSourceName() (string, error) {
+	if i.SSLMode == "" {
+		return i.DSN, nil
+	}
+
+	if strings.HasPrefix(i.DSN, "postgres://") || strings.HasPrefix(i.DSN, "postgresql://") {
+		u, err := url.Parse(i.DSN)
+		if err != nil {
+			return "", fmt.Errorf("invalid dsn: %w", err)
+		}
+		values := u.Query()
+		values.Set("sslmode", i.SSLMode)
+		u.RawQuery = values.Encode()
+		return u.String(), nil
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s sslmode=%s", i.DSN, i.SSLMode)), nil
+}
+
+// query runs q, returning each result row as a map of column name to value
+func query(ctx context.Context, db *sql.DB, q string) ([]map[string]string, error) {
+	rows, err := db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []map[string]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		scan := make([]any, len(columns))
+		for n := range values {
+			scan[n] = &values[n]
+		}
+		if err := rows.Scan(scan...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]string, len(columns))
+		for n, column := range columns {
+			if values[n].Valid {
+				row[column] = values[n].String
+			}
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
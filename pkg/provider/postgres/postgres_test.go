@@ -0,0 +1,278 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/postgres"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+	sql.Register(fakeDriverName, fake)
+}
+
+const fakeDriverName = "fakepostgres"
+
+// table is the result of a query: column names followed by rows of values
+type table struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeDriver answers queries from a fixed set of tables, recording the DSNs
+// it is opened with. DSNs containing "unreachable" fail to connect.
+type fakeDriver struct {
+	mu     sync.Mutex
+	dsns   []string
+	tables map[string]table
+}
+
+var fake = &fakeDriver{
+	tables: map[string]table{
+		"SELECT 1": {
+			columns: []string{"?column?"},
+			rows:    [][]driver.Value{{int64(1)}},
+		},
+		"SELECT count(*) FROM orders": {
+			columns: []string{"count"},
+			rows:    [][]driver.Value{{int64(42)}},
+		},
+		"SELECT id, note FROM empty": {
+			columns: []string{"id", "note"},
+		},
+		"SELECT id, note FROM notes": {
+			columns: []string{"id", "note"},
+			rows:    [][]driver.Value{{int64(1), "first"}, {int64(2), nil}},
+		},
+	},
+}
+
+func (d *fakeDriver) Open(dsn string) (driver.Conn, error) {
+	d.mu.Lock()
+	d.dsns = append(d.dsns, dsn)
+	d.mu.Unlock()
+
+	if strings.Contains(dsn, "unreachable") {
+		return nil, errors.New("dial tcp: connection refused")
+	}
+	return &fakeConn{tables: d.tables}, nil
+}
+
+func (d *fakeDriver) lastDSN() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dsns[len(d.dsns)-1]
+}
+
+type fakeConn struct {
+	tables map[string]table
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if query == "SELECT pg_sleep(10)" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	t, ok := c.tables[query]
+	if !ok {
+		return nil, errors.New(`relation "missing" does not exist`)
+	}
+	return &fakeRows{table: t}, nil
+}
+
+type fakeRows struct {
+	table table
+	next  int
+}
+
+func (r *fakeRows) Columns() []string { return r.table.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.table.rows) {
+		return io.EOF
+	}
+	copy(dest, r.table.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestPostgres(t *testing.T) {
+	postgres.SetDriver(t, fakeDriverName)
+
+	tests := []struct {
+		name    string
+		dsn     string
+		query   string
+		minRows int
+		status  ph.Status
+		message string
+		rows    []map[string]string
+		timeout time.Duration
+	}{
+		{
+			name:   "Default query",
+			dsn:    "host=db.example.com user=health",
+			status: ph.Status_HEALTHY,
+			rows:   []map[string]string{{"?column?": "1"}},
+		},
+		{
+			name:    "Count query",
+			dsn:     "host=db.example.com user=health",
+			query:   "SELECT count(*) FROM orders",
+			minRows: 1,
+			status:  ph.Status_HEALTHY,
+			rows:    []map[string]string{{"count": "42"}},
+		},
+		{
+			name:    "Multiple rows with NULL",
+			dsn:     "host=db.example.com user=health",
+			query:   "SELECT id, note FROM notes",
+			minRows: 2,
+			status:  ph.Status_HEALTHY,
+			rows:    []map[string]string{{"id": "1", "note": "first"}, {"id": "2"}},
+		},
+		{
+			name:    "Too few rows",
+			dsn:     "host=db.example.com user=health",
+			query:   "SELECT id, note FROM empty",
+			minRows: 1,
+			status:  ph.Status_UNHEALTHY,
+			message: "expected at least 1 rows; actual 0 rows",
+		},
+		{
+			name:    "Query error",
+			dsn:     "host=db.example.com user=health",
+			query:   "SELECT * FROM missing",
+			status:  ph.Status_UNHEALTHY,
+			message: `query: relation "missing" does not exist`,
+		},
+		{
+			name:    "Connection error",
+			dsn:     "host=unreachable.example.com",
+			status:  ph.Status_UNHEALTHY,
+			message: "dial tcp: connection refused",
+		},
+		{
+			name:    "Query timeout",
+			dsn:     "host=db.example.com user=health",
+			query:   "SELECT pg_sleep(10)",
+			timeout: 100 * time.Millisecond,
+			status:  ph.Status_UNHEALTHY,
+			message: "query: context deadline exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &postgres.Postgres{
+				Name:    "TestPostgres",
+				DSN:     tt.dsn,
+				Query:   tt.query,
+				MinRows: tt.minRows,
+				Timeout: time.Second,
+				Detail:  true,
+			}
+			if tt.timeout > 0 {
+				instance.Timeout = tt.timeout
+			}
+			instance.SetDefaults()
+
+			start := time.Now()
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, postgres.TypePostgres, result.GetType())
+			assert.Equal(t, tt.status, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			assert.Less(t, time.Since(start), 2*instance.Timeout)
+
+			if tt.rows != nil {
+				require.Len(t, result.Details, 1)
+				detail := &details.Detail_Postgres{}
+				require.NoError(t, result.Details[0].UnmarshalTo(detail))
+				assert.NotNil(t, detail.Ping)
+				rows := make([]map[string]string, 0, len(detail.Rows))
+				for _, row := range detail.Rows {
+					columns := row.GetColumns()
+					if columns == nil {
+						columns = map[string]string{}
+					}
+					rows = append(rows, columns)
+				}
+				assert.Equal(t, tt.rows, rows)
+			}
+		})
+	}
+}
+
+func TestPostgresSSLMode(t *testing.T) {
+	postgres.SetDriver(t, fakeDriverName)
+
+	tests := []struct {
+		name     string
+		dsn      string
+		sslMode  string
+		expected string
+	}{
+		{
+			name:     "Unset",
+			dsn:      "host=db.example.com",
+			expected: "host=db.example.com",
+		},
+		{
+			name:     "Key/value DSN",
+			dsn:      "host=db.example.com",
+			sslMode:  "verify-full",
+			expected: "host=db.example.com sslmode=verify-full",
+		},
+		{
+			name:     "URL DSN",
+			dsn:      "postgres://health@db.example.com:5432/app?sslmode=disable",
+			sslMode:  "require",
+			expected: "postgres://health@db.example.com:5432/app?sslmode=require",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &postgres.Postgres{
+				Name:    "TestPostgresSSLMode",
+				DSN:     tt.dsn,
+				SSLMode: tt.sslMode,
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, ph.Status_HEALTHY, result.GetStatus())
+			assert.Equal(t, tt.expected, fake.lastDSN())
+		})
+	}
+}
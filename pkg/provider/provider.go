@@ -2,12 +2,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/utils"
 )
 
 // Instance is the interface that must be implemented by all providers.
@@ -22,20 +26,202 @@ type Instance interface {
 	SetDefaults()
 }
 
+// InstanceWithTimeout is implemented by instances with a configurable timeout.
+type InstanceWithTimeout interface {
+	// GetTimeout returns the configured timeout of the instance
+	GetTimeout() time.Duration
+}
+
+// TimeoutPolicy determines how an instance timeout exceeding the inherited deadline is handled.
+type TimeoutPolicy int
+
+const (
+	// TimeoutClamp leaves the inherited deadline in place, cutting the instance short.
+	TimeoutClamp TimeoutPolicy = iota
+	// TimeoutExtend detaches the instance from the inherited deadline and applies its own timeout.
+	TimeoutExtend
+)
+
+type timeoutPolicyKey struct{}
+
+func ContextWithTimeoutPolicy(ctx context.Context, policy TimeoutPolicy) context.Context {
+	return context.WithValue(ctx, timeoutPolicyKey{}, policy)
+}
+
+func TimeoutPolicyFromContext(ctx context.Context) TimeoutPolicy {
+	if policy, ok := ctx.Value(timeoutPolicyKey{}).(TimeoutPolicy); ok {
+		return policy
+	}
+	return TimeoutClamp
+}
+
+// timeoutTolerance is the margin by which the timeout of an instance may exceed
+// its budget without the TimeoutPolicy being applied, absorbing the latency of
+// the request, such that instances with the same timeout as the client are
+// checked within the inherited deadline without warning.
+const timeoutTolerance = time.Second
+
+type budgetKey struct{}
+
+// contextWithBudget records the time remaining until the deadline of ctx, if
+// any, as the budget of each instance checked with it, such that time spent
+// waiting for dependencies does not count against the instance.
+func contextWithBudget(ctx context.Context) context.Context {
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithValue(ctx, budgetKey{}, time.Until(deadline))
+	}
+	return ctx
+}
+
+// budget returns the time allowed to check an instance with ctx: that recorded
+// when the check started, or else the time remaining until the deadline.
+func budget(ctx context.Context) (time.Duration, bool) {
+	if budget, ok := ctx.Value(budgetKey{}).(time.Duration); ok {
+		return budget, true
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline), true
+	}
+	return 0, false
+}
+
+// attemptTimeout returns the timeout of a single check of instance, excluding
+// any retries, which are only given time if the check itself is extended.
+func attemptTimeout(instance Instance) (time.Duration, bool) {
+	for wrapped := unwrap(instance); wrapped != nil; wrapped = unwrap(instance) {
+		instance = wrapped
+	}
+	if i, ok := instance.(InstanceWithTimeout); ok {
+		return i.GetTimeout(), true
+	}
+	return 0, false
+}
+
+// unwrap returns the instance wrapped by a Dependent, Conditional, Cached or
+// Retrying instance, or nil if instance is not so wrapped.
+func unwrap(instance Instance) Instance {
+	switch w := instance.(type) {
+	case *Dependent:
+		return w.Instance
+	case *Conditional:
+		return w.Instance
+	case *Cached:
+		return w.Instance
+	case *Retrying:
+		return w.Instance
+	default:
+		return nil
+	}
+}
+
+// MessageIncomplete is the message of instances reported UNKNOWN because the check deadline was reached first.
+const MessageIncomplete = "deadline exceeded: check did not complete"
+
+type deadlineKey struct{}
+
+// ContextWithDeadline returns a copy of ctx cancelled at deadline, with which
+// Check returns at the deadline regardless of instance timeouts, reporting
+// instances that have not completed as UNKNOWN.
+func ContextWithDeadline(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	return context.WithValue(ctx, deadlineKey{}, deadline), cancel
+}
+
+// Incomplete returns the number of responses reported UNKNOWN because the check deadline was reached first.
+func Incomplete(responses []*ph.HealthCheckResponse) (count int) {
+	for _, response := range responses {
+		if response.GetStatus() == ph.Status_UNKNOWN && response.GetMessage() == MessageIncomplete {
+			count++
+		}
+	}
+	return count
+}
+
 // Config is the interface through which the provider configuration is retrieved.
 type Config interface {
 	GetInstances() []Instance
 }
 
+// Check checks all instances concurrently, returning their responses and the
+// worst status. Instances with dependencies are checked once all of their
+// dependencies have completed, and skipped with status UNKNOWN if any
+// dependency is not healthy. If ctx carries a deadline from
+// ContextWithDeadline, Check returns once it is reached.
 func Check(ctx context.Context, instances []Instance) (response []*ph.HealthCheckResponse, status ph.Status) {
+	ctx = contextWithBudget(ctx)
+
+	if _, err := SortByDependencies(instances); err != nil {
+		utils.ContextLogger(ctx).Error("invalid dependencies", slog.Any("error", err))
+		return checkUnordered(ctx, instances, err)
+	}
+
 	var wg sync.WaitGroup
-	instanceChan := make(chan *ph.HealthCheckResponse, len(instances))
+	instanceChan := make(chan indexedResponse, len(instances))
 
+	results := make(map[string]*result)
 	for _, instance := range instances {
+		r, ok := results[instance.GetName()]
+		if !ok {
+			r = &result{status: ph.Status_HEALTHY}
+			results[instance.GetName()] = r
+		}
+		r.wg.Add(1)
+	}
+
+	for n, instance := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := results[instance.GetName()]
+			defer r.wg.Done()
+
+			var health *ph.HealthCheckResponse
+			if skipped := waitForDependencies(instance, results); skipped != "" {
+				health = &ph.HealthCheckResponse{
+					Type:    instance.GetType(),
+					Name:    instance.GetName(),
+					Status:  ph.Status_UNKNOWN,
+					Message: skipped,
+				}
+			} else {
+				health = GetHealthWithDuration(ctx, instance)
+			}
+			annotateDependencies(instance, health)
+			r.record(health)
+			instanceChan <- indexedResponse{n, health}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(instanceChan)
+	}()
+
+	return collect(ctx, instanceChan, instances)
+}
+
+// checkUnordered checks instances without dependencies, reporting instances
+// with dependencies as UNKNOWN due to err.
+func checkUnordered(ctx context.Context, instances []Instance, err error) ([]*ph.HealthCheckResponse, ph.Status) {
+	var wg sync.WaitGroup
+	instanceChan := make(chan indexedResponse, len(instances))
+
+	for n, instance := range instances {
+		if len(dependencies(instance)) > 0 {
+			health := &ph.HealthCheckResponse{
+				Type:    instance.GetType(),
+				Name:    instance.GetName(),
+				Status:  ph.Status_UNKNOWN,
+				Message: err.Error(),
+			}
+			annotateDependencies(instance, health)
+			instanceChan <- indexedResponse{n, health}
+			continue
+		}
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			instanceChan <- GetHealthWithDuration(ctx, instance)
+			instanceChan <- indexedResponse{n, GetHealthWithDuration(ctx, instance)}
 		}()
 	}
 
@@ -44,25 +230,138 @@ func Check(ctx context.Context, instances []Instance) (response []*ph.HealthChec
 		close(instanceChan)
 	}()
 
-	response = make([]*ph.HealthCheckResponse, 0, len(instances))
+	return collect(ctx, instanceChan, instances)
+}
+
+// indexedResponse is the response of the nth instance
+type indexedResponse struct {
+	n      int
+	health *ph.HealthCheckResponse
+}
+
+// collect gathers the responses of all instances, returning them in instance
+// order. If ctx carries a deadline from ContextWithDeadline, collection stops
+// once it is reached, and instances yet to respond are reported as UNKNOWN.
+func collect(ctx context.Context, instanceChan <-chan indexedResponse, instances []Instance) (responses []*ph.HealthCheckResponse, status ph.Status) {
+	var deadline <-chan struct{}
+	if _, ok := ctx.Value(deadlineKey{}).(time.Time); ok {
+		deadline = ctx.Done()
+	}
+
+	responses = make([]*ph.HealthCheckResponse, len(instances))
 	status = ph.Status_HEALTHY
-	for instance := range instanceChan {
-		response = append(response, instance)
 
-		if instance.Status.Number() > status.Number() {
-			status = instance.Status
+	record := func(instance indexedResponse) {
+		responses[instance.n] = instance.health
+
+		if instance.health.Status.Number() > status.Number() {
+			status = instance.health.Status
 		}
 	}
 
-	return response, status
+	for {
+		select {
+		case instance, ok := <-instanceChan:
+			if !ok {
+				return responses, status
+			}
+			record(instance)
+		case <-deadline:
+			// keep responses that completed before the deadline
+			for drained := false; !drained; {
+				select {
+				case instance, ok := <-instanceChan:
+					if !ok {
+						return responses, status
+					}
+					record(instance)
+				default:
+					drained = true
+				}
+			}
+			for n, instance := range instances {
+				if responses[n] == nil {
+					responses[n] = &ph.HealthCheckResponse{
+						Type:    instance.GetType(),
+						Name:    instance.GetName(),
+						Status:  ph.Status_UNKNOWN,
+						Message: MessageIncomplete,
+					}
+				}
+			}
+			return responses, status
+		}
+	}
+}
+
+// result tracks the completion and combined status of all instances sharing a name
+type result struct {
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	status ph.Status
+}
+
+func (r *result) record(health *ph.HealthCheckResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case health == nil:
+		r.status = ph.Status_UNKNOWN
+	case health.Status != ph.Status_HEALTHY && r.status == ph.Status_HEALTHY:
+		r.status = health.Status
+	}
+}
+
+// waitForDependencies blocks until all dependencies of instance have completed,
+// returning the reason to skip the instance if any dependency is not healthy.
+func waitForDependencies(instance Instance, results map[string]*result) (skipped string) {
+	for _, dependency := range dependencies(instance) {
+		r := results[dependency]
+		r.wg.Wait()
+		r.mu.Lock()
+		status := r.status
+		r.mu.Unlock()
+		if status != ph.Status_HEALTHY && skipped == "" {
+			skipped = fmt.Sprintf("skipped: dependency %s %s", dependency, strings.ToLower(status.String()))
+		}
+	}
+	return skipped
 }
 
+// GetHealthWithDuration checks the instance, recording the duration of the check.
+//
+// If the timeout of a single check of the instance exceeds its budget, the time
+// remaining until the deadline inherited from ctx when the check started, by
+// more than a tolerance, a warning is logged and the TimeoutPolicy from ctx
+// applied: by default the instance is clamped to the inherited deadline; with
+// TimeoutExtend it is instead given its full timeout, including any retries,
+// independent of the inherited deadline and cancellation.
 func GetHealthWithDuration(ctx context.Context, instance Instance) *ph.HealthCheckResponse {
+	if timeout, ok := attemptTimeout(instance); ok {
+		if budget, ok := budget(ctx); ok && timeout > budget+timeoutTolerance {
+			policy := TimeoutPolicyFromContext(ctx)
+			utils.ContextLogger(ctx).Warn("instance timeout exceeds inherited deadline",
+				slog.String("provider", instance.GetType()),
+				slog.String("name", instance.GetName()),
+				slog.Any("timeout", timeout),
+				slog.Any("budget", budget.Round(time.Millisecond)),
+				slog.Bool("extended", policy == TimeoutExtend),
+			)
+			if policy == TimeoutExtend {
+				if i, ok := instance.(InstanceWithTimeout); ok {
+					timeout = i.GetTimeout()
+				}
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), timeout)
+				defer cancel()
+			}
+		}
+	}
+
 	start := time.Now()
 	response := instance.GetHealth(ctx)
 	if response != nil {
 		response.Duration = durationpb.New(time.Since(start))
 	}
 	return response
-
 }
@@ -1,11 +1,16 @@
 package provider_test
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	slogctx "github.com/veqryn/slog-context"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
 	"github.com/isometry/platform-health/pkg/provider"
@@ -84,3 +89,184 @@ func TestServiceWithDuration(t *testing.T) {
 	assert.Equal(t, instance.Health, result.GetStatus())
 	assert.NotZero(t, result.GetDuration())
 }
+
+// timeoutInstance records the deadline it was checked with
+type timeoutInstance struct {
+	timeout  time.Duration
+	deadline time.Time
+}
+
+func (i *timeoutInstance) GetType() string           { return "timeout" }
+func (i *timeoutInstance) GetName() string           { return "test" }
+func (i *timeoutInstance) GetTimeout() time.Duration { return i.timeout }
+func (i *timeoutInstance) SetDefaults()              {}
+
+func (i *timeoutInstance) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	i.deadline, _ = ctx.Deadline()
+	return &ph.HealthCheckResponse{Type: i.GetType(), Name: i.GetName(), Status: ph.Status_HEALTHY}
+}
+
+func TestGetHealthWithDurationTimeoutPolicy(t *testing.T) {
+	const parentTimeout = time.Second
+
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		parent       time.Duration
+		retry        *provider.RetryPolicy
+		policy       *provider.TimeoutPolicy
+		expectWarn   bool
+		expectExtend bool
+	}{
+		{
+			name:    "Within deadline",
+			timeout: parentTimeout / 2,
+		},
+		{
+			name:    "Default timeout, default client deadline",
+			timeout: 10 * time.Second,
+			parent:  10*time.Second - 50*time.Millisecond,
+			policy:  ptr(provider.TimeoutExtend),
+		},
+		{
+			name:    "Retried within deadline",
+			timeout: 10 * time.Second,
+			parent:  10*time.Second - 50*time.Millisecond,
+			retry:   &provider.RetryPolicy{Attempts: 3, Delay: time.Second, Backoff: 2},
+			policy:  ptr(provider.TimeoutExtend),
+		},
+		{
+			name:         "Retried exceeds deadline, extend",
+			timeout:      time.Minute,
+			retry:        &provider.RetryPolicy{Attempts: 3, Delay: time.Second, Backoff: 2},
+			policy:       ptr(provider.TimeoutExtend),
+			expectWarn:   true,
+			expectExtend: true,
+		},
+		{
+			name:       "Exceeds deadline, default clamp",
+			timeout:    time.Minute,
+			expectWarn: true,
+		},
+		{
+			name:       "Exceeds deadline, explicit clamp",
+			timeout:    time.Minute,
+			policy:     ptr(provider.TimeoutClamp),
+			expectWarn: true,
+		},
+		{
+			name:         "Exceeds deadline, extend",
+			timeout:      time.Minute,
+			policy:       ptr(provider.TimeoutExtend),
+			expectWarn:   true,
+			expectExtend: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			ctx := slogctx.NewCtx(context.Background(), slog.New(slog.NewTextHandler(&logs, nil)))
+			if tt.policy != nil {
+				ctx = provider.ContextWithTimeoutPolicy(ctx, *tt.policy)
+			}
+			parent := parentTimeout
+			if tt.parent > 0 {
+				parent = tt.parent
+			}
+			ctx, cancel := context.WithTimeout(ctx, parent)
+			defer cancel()
+			parentDeadline, _ := ctx.Deadline()
+
+			instance := &timeoutInstance{timeout: tt.timeout}
+			var checked provider.Instance = instance
+			if tt.retry != nil {
+				checked = &provider.Retrying{Instance: instance, Retry: *tt.retry}
+			}
+			result := provider.GetHealthWithDuration(ctx, checked)
+
+			assert.Equal(t, ph.Status_HEALTHY, result.GetStatus())
+			assert.Equal(t, tt.expectWarn, bytes.Contains(logs.Bytes(), []byte("instance timeout exceeds inherited deadline")))
+			if tt.expectExtend {
+				assert.True(t, instance.deadline.After(parentDeadline), "expected deadline to be extended")
+				if tt.retry != nil {
+					assert.Greater(t, time.Until(instance.deadline), 3*tt.timeout, "expected time for all attempts")
+				}
+			} else {
+				assert.Equal(t, parentDeadline, instance.deadline, "expected inherited deadline")
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCheckDeadline(t *testing.T) {
+	instances := []provider.Instance{
+		&mock.Mock{Name: "fast1", Health: ph.Status_HEALTHY, Sleep: time.Millisecond},
+		&mock.Mock{Name: "fast2", Health: ph.Status_UNHEALTHY, Sleep: time.Millisecond},
+	}
+	for n := range 10 {
+		instances = append(instances, &mock.Mock{Name: fmt.Sprintf("slow%d", n), Health: ph.Status_HEALTHY, Sleep: 5 * time.Second})
+	}
+	instances = append(instances, dependent(&mock.Mock{Name: "dependent", Health: ph.Status_HEALTHY, Sleep: time.Millisecond}, "slow0"))
+
+	ctx, cancel := provider.ContextWithDeadline(context.Background(), time.Now().Add(100*time.Millisecond))
+	defer cancel()
+
+	start := time.Now()
+	response, status := provider.Check(ctx, instances)
+	assert.Less(t, time.Since(start), time.Second)
+
+	require.Len(t, response, len(instances))
+	assert.Equal(t, ph.Status_UNHEALTHY, status)
+	assert.Equal(t, 11, provider.Incomplete(response))
+
+	for _, component := range response {
+		switch component.GetName() {
+		case "fast1":
+			assert.Equal(t, ph.Status_HEALTHY, component.GetStatus())
+		case "fast2":
+			assert.Equal(t, ph.Status_UNHEALTHY, component.GetStatus())
+		default:
+			assert.Equal(t, ph.Status_UNKNOWN, component.GetStatus())
+			assert.Equal(t, provider.MessageIncomplete, component.GetMessage())
+			assert.Equal(t, mock.TypeMock, component.GetType())
+		}
+	}
+}
+
+func TestCheckWithoutDeadline(t *testing.T) {
+	instances := []provider.Instance{
+		&mock.Mock{Name: "slow", Health: ph.Status_HEALTHY, Sleep: 200 * time.Millisecond},
+	}
+
+	// an ordinary deadline cancels instances, but does not abandon them
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	response, status := provider.Check(ctx, instances)
+	require.Len(t, response, 1)
+	assert.Equal(t, ph.Status_HEALTHY, status)
+	assert.Zero(t, provider.Incomplete(response))
+}
+
+func TestCheckOrder(t *testing.T) {
+	// instances completing in reverse are still reported in instance order
+	instances := []provider.Instance{
+		&mock.Mock{Name: "first", Health: ph.Status_HEALTHY, Sleep: 30 * time.Millisecond},
+		&mock.Mock{Name: "second", Health: ph.Status_UNHEALTHY, Sleep: 20 * time.Millisecond},
+		&mock.Mock{Name: "third", Health: ph.Status_HEALTHY, Sleep: 10 * time.Millisecond},
+		dependent(&mock.Mock{Name: "fourth", Health: ph.Status_HEALTHY}, "third"),
+	}
+
+	response, _ := provider.Check(context.Background(), instances)
+
+	names := make([]string, 0, len(response))
+	for _, component := range response {
+		names = append(names, component.GetName())
+	}
+	assert.Equal(t, []string{"first", "second", "third", "fourth"}, names)
+}
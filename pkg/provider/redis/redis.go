@@ -0,0 +1,252 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypeRedis = "redis"
+
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
+// clusterSlots is the number of hash slots of a redis cluster
+const clusterSlots = 16384
+
+type Redis struct {
+	Name     string        `mapstructure:"name"`
+	Addr     string        `mapstructure:"addr" default:"localhost:6379"`
+	Mode     string        `mapstructure:"mode" default:"standalone"`
+	Master   string        `mapstructure:"master" default:"mymaster"`
+	Username string        `mapstructure:"username"`
+	Password string        `mapstructure:"password"`
+	DB       int           `mapstructure:"db"`
+	Role     string        `mapstructure:"role"`
+	Timeout  time.Duration `mapstructure:"timeout" default:"5s"`
+	Detail   bool          `mapstructure:"detail"`
+}
+
+func init() {
+	provider.Register(TypeRedis, new(Redis))
+}
+
+func (i *Redis) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("addr", i.Addr),
+		slog.String("mode", i.Mode),
+		slog.String("master", i.Master),
+		slog.Int("db", i.DB),
+		slog.String("role", i.Role),
+		slog.Any("timeout", i.Timeout),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *Redis) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *Redis) GetType() string {
+	return TypeRedis
+}
+
+func (i *Redis) GetName() string {
+	return i.Name
+}
+
+func (i *Redis) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *Redis) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypeRedis), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypeRedis,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	switch i.Mode {
+	case ModeStandalone, ModeCluster:
+	case ModeSentinel:
+		return i.checkSentinel(ctx, component)
+	default:
+		return component.Unhealthy(fmt.Sprintf("unknown mode %q", i.Mode))
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:        i.Addr,
+		Username:    i.Username,
+		Password:    i.Password,
+		DB:          i.DB,
+		DialTimeout: i.Timeout,
+		MaxRetries:  -1,
+	})
+	defer client.Close()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	info, err := client.Info(ctx).Result()
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+	detail := parseInfo(info)
+
+	if i.Mode == ModeCluster {
+		clusterInfo, err := client.ClusterInfo(ctx).Result()
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		detail.Cluster = parseClusterInfo(clusterInfo)
+	}
+
+	if err := i.addDetail(component, detail); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	if i.Role != "" && detail.Role != i.Role {
+		return component.Unhealthy(fmt.Sprintf("expected role %s; actual role %s", i.Role, detail.Role))
+	}
+
+	if cluster := detail.Cluster; cluster != nil {
+		if cluster.State != "ok" {
+			return component.Unhealthy(fmt.Sprintf("cluster state %s", cluster.State))
+		}
+		if cluster.SlotsOk < clusterSlots {
+			return component.Unhealthy(fmt.Sprintf("%d of %d slots ok", cluster.SlotsOk, clusterSlots))
+		}
+	}
+
+	return component.Healthy()
+}
+
+// checkSentinel asks the sentinel for the address of the current master, and
+// whether the sentinels can reach the quorum needed to fail it over.
+func (i *Redis) checkSentinel(ctx context.Context, component *ph.HealthCheckResponse) *ph.HealthCheckResponse {
+	client := redis.NewSentinelClient(&redis.Options{
+		Addr:        i.Addr,
+		Username:    i.Username,
+		Password:    i.Password,
+		DialTimeout: i.Timeout,
+		MaxRetries:  -1,
+	})
+	defer client.Close()
+
+	addr, err := client.GetMasterAddrByName(ctx, i.Master).Result()
+	if err == redis.Nil {
+		return component.Unhealthy(fmt.Sprintf("unknown master %s", i.Master))
+	} else if err != nil {
+		return component.Unhealthy(err.Error())
+	} else if len(addr) != 2 {
+		return component.Unhealthy(fmt.Sprintf("invalid address of master %s: %v", i.Master, addr))
+	}
+
+	sentinel := &details.Detail_Redis_Sentinel{
+		Master:     i.Master,
+		MasterAddr: net.JoinHostPort(addr[0], addr[1]),
+	}
+	_, quorumErr := client.CkQuorum(ctx, i.Master).Result()
+	if quorumErr != nil && !redis.HasErrorPrefix(quorumErr, "NOQUORUM") {
+		return component.Unhealthy(quorumErr.Error())
+	}
+	sentinel.Quorum = quorumErr == nil
+
+	if err := i.addDetail(component, &details.Detail_Redis{Sentinel: sentinel}); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	if !sentinel.Quorum {
+		return component.Unhealthy(quorumErr.Error())
+	}
+
+	return component.Healthy()
+}
+
+// addDetail appends detail to the component details if enabled.
+func (i *Redis) addDetail(component *ph.HealthCheckResponse, detail *details.Detail_Redis) error {
+	if !i.Detail {
+		return nil
+	}
+	anyDetail, err := anypb.New(detail)
+	if err != nil {
+		return err
+	}
+	component.Details = append(component.Details, anyDetail)
+	return nil
+}
+
+// parseInfo extracts the replication role, connected clients and used memory
+// from the response to an INFO command.
+func parseInfo(info string) *details.Detail_Redis {
+	detail := &details.Detail_Redis{}
+
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "role":
+			detail.Role = value
+		case "connected_clients":
+			detail.ConnectedClients, _ = strconv.ParseInt(value, 10, 64)
+		case "used_memory":
+			detail.UsedMemory, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+
+	return detail
+}
+
+// parseClusterInfo extracts the state, slot coverage, known nodes and size of
+// the cluster from the response to a CLUSTER INFO command.
+func parseClusterInfo(info string) *details.Detail_Redis_Cluster {
+	cluster := &details.Detail_Redis_Cluster{}
+
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "cluster_state":
+			cluster.State = value
+		case "cluster_slots_ok":
+			cluster.SlotsOk, _ = strconv.ParseInt(value, 10, 64)
+		case "cluster_known_nodes":
+			cluster.KnownNodes, _ = strconv.ParseInt(value, 10, 64)
+		case "cluster_size":
+			cluster.Size, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+
+	return cluster
+}
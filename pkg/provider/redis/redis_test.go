@@ -0,0 +1,376 @@
+package redis_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/redis"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+// serve runs a minimal RESP2 server on a loopback port, answering PING, AUTH,
+// SELECT and INFO as a redis server with the given role and password.
+func serve(t *testing.T, role, password string) string {
+	t.Helper()
+
+	info := fmt.Sprintf("# Clients\r\nconnected_clients:3\r\n\r\n# Memory\r\nused_memory:1048576\r\n\r\n# Replication\r\nrole:%s\r\n", role)
+
+	return listen(t, func() func([]string) string {
+		authenticated := password == ""
+		return func(command []string) string {
+			switch strings.ToUpper(command[0]) {
+			case "AUTH":
+				if command[len(command)-1] != password {
+					return "-WRONGPASS invalid username-password pair\r\n"
+				}
+				authenticated = true
+				return "+OK\r\n"
+			case "SELECT":
+				return "+OK\r\n"
+			case "PING", "INFO":
+				if !authenticated {
+					return "-NOAUTH Authentication required.\r\n"
+				} else if strings.ToUpper(command[0]) == "PING" {
+					return "+PONG\r\n"
+				}
+				return bulk(info)
+			default:
+				return fmt.Sprintf("-ERR unknown command '%s'\r\n", command[0])
+			}
+		}
+	})
+}
+
+// serveReplies runs a minimal RESP2 server on a loopback port, answering PING
+// and each command in replies, keyed by the upper-cased command line.
+func serveReplies(t *testing.T, replies map[string]string) string {
+	t.Helper()
+
+	return listen(t, func() func([]string) string {
+		return func(command []string) string {
+			line := strings.ToUpper(strings.Join(command, " "))
+			if reply, ok := replies[line]; ok {
+				return reply
+			} else if line == "PING" {
+				return "+PONG\r\n"
+			}
+			return fmt.Sprintf("-ERR unknown command '%s'\r\n", command[0])
+		}
+	})
+}
+
+// listen accepts connections on a loopback port, answering each command with
+// the reply of a handler created for the connection.
+func listen(t *testing.T, handler func() func([]string) string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				handle := handler()
+				for {
+					command, err := readCommand(reader)
+					if err != nil {
+						return
+					}
+					if _, err := conn.Write([]byte(handle(command))); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// bulk encodes s as a RESP bulk string
+func bulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+// readCommand reads a RESP array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	command := make([]string, 0, count)
+	for range count {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		command = append(command, strings.TrimSuffix(arg, "\r\n"))
+	}
+	return command, nil
+}
+
+func TestRedis(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		password string
+		expected ph.Status
+		message  string
+		detail   bool
+	}{
+		{
+			name:     "Available",
+			expected: ph.Status_HEALTHY,
+			detail:   true,
+		},
+		{
+			name:     "Expected role",
+			role:     "master",
+			expected: ph.Status_HEALTHY,
+			detail:   true,
+		},
+		{
+			name:     "Unexpected role",
+			role:     "slave",
+			expected: ph.Status_UNHEALTHY,
+			message:  "expected role slave; actual role master",
+			detail:   true,
+		},
+		{
+			name:     "Authenticated",
+			password: "secret",
+			expected: ph.Status_HEALTHY,
+			detail:   true,
+		},
+		{
+			name:     "Wrong password",
+			password: "wrong",
+			expected: ph.Status_UNHEALTHY,
+			message:  "WRONGPASS invalid username-password pair",
+		},
+	}
+
+	addr := serve(t, "master", "")
+	authAddr := serve(t, "master", "secret")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &redis.Redis{
+				Name:     "TestRedis",
+				Addr:     addr,
+				Password: tt.password,
+				DB:       1,
+				Role:     tt.role,
+				Timeout:  time.Second,
+				Detail:   true,
+			}
+			if tt.password != "" {
+				instance.Addr = authAddr
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, redis.TypeRedis, result.GetType())
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+			if tt.detail {
+				require.Len(t, result.Details, 1)
+				detail := &details.Detail_Redis{}
+				require.NoError(t, result.Details[0].UnmarshalTo(detail))
+				assert.Equal(t, "master", detail.Role)
+				assert.EqualValues(t, 3, detail.ConnectedClients)
+				assert.EqualValues(t, 1048576, detail.UsedMemory)
+			}
+		})
+	}
+}
+
+func TestRedisConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	instance := &redis.Redis{
+		Name:    "TestRedisConnectionRefused",
+		Addr:    addr,
+		Timeout: time.Second,
+	}
+	instance.SetDefaults()
+
+	start := time.Now()
+	result := instance.GetHealth(context.Background())
+	require.NotNil(t, result)
+	assert.Equal(t, ph.Status_UNHEALTHY, result.GetStatus())
+	assert.Contains(t, result.GetMessage(), "connection refused")
+	assert.Less(t, time.Since(start), 2*instance.Timeout)
+}
+
+func TestRedisSentinel(t *testing.T) {
+	const masterAddr = "*2\r\n$8\r\n10.0.0.1\r\n$4\r\n6379\r\n"
+
+	tests := []struct {
+		name     string
+		master   string
+		replies  map[string]string
+		expected ph.Status
+		message  string
+		detail   *details.Detail_Redis_Sentinel
+	}{
+		{
+			name:   "Quorum",
+			master: "mymaster",
+			replies: map[string]string{
+				"SENTINEL GET-MASTER-ADDR-BY-NAME MYMASTER": masterAddr,
+				"SENTINEL CKQUORUM MYMASTER":                "+OK 3 usable Sentinels. Quorum and failover authorization can be reached\r\n",
+			},
+			expected: ph.Status_HEALTHY,
+			detail:   &details.Detail_Redis_Sentinel{Master: "mymaster", MasterAddr: "10.0.0.1:6379", Quorum: true},
+		},
+		{
+			name:   "No quorum",
+			master: "mymaster",
+			replies: map[string]string{
+				"SENTINEL GET-MASTER-ADDR-BY-NAME MYMASTER": masterAddr,
+				"SENTINEL CKQUORUM MYMASTER":                "-NOQUORUM 1 usable Sentinels. Not enough available Sentinels to reach the specified quorum for this master\r\n",
+			},
+			expected: ph.Status_UNHEALTHY,
+			message:  "NOQUORUM 1 usable Sentinels. Not enough available Sentinels to reach the specified quorum for this master",
+			detail:   &details.Detail_Redis_Sentinel{Master: "mymaster", MasterAddr: "10.0.0.1:6379"},
+		},
+		{
+			name:   "Unknown master",
+			master: "other",
+			replies: map[string]string{
+				"SENTINEL GET-MASTER-ADDR-BY-NAME OTHER": "*-1\r\n",
+			},
+			expected: ph.Status_UNHEALTHY,
+			message:  "unknown master other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &redis.Redis{
+				Name:    "TestRedisSentinel",
+				Addr:    serveReplies(t, tt.replies),
+				Mode:    redis.ModeSentinel,
+				Master:  tt.master,
+				Timeout: time.Second,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			if tt.detail == nil {
+				assert.Empty(t, result.Details)
+				return
+			}
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_Redis{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			require.NotNil(t, detail.Sentinel)
+			assert.Equal(t, tt.detail.Master, detail.Sentinel.Master)
+			assert.Equal(t, tt.detail.MasterAddr, detail.Sentinel.MasterAddr)
+			assert.Equal(t, tt.detail.Quorum, detail.Sentinel.Quorum)
+		})
+	}
+}
+
+func TestRedisCluster(t *testing.T) {
+	const info = "# Replication\r\nrole:master\r\n"
+
+	tests := []struct {
+		name        string
+		clusterInfo string
+		expected    ph.Status
+		message     string
+		slotsOk     int64
+	}{
+		{
+			name:        "All slots covered",
+			clusterInfo: "cluster_state:ok\r\ncluster_slots_assigned:16384\r\ncluster_slots_ok:16384\r\ncluster_known_nodes:6\r\ncluster_size:3\r\n",
+			expected:    ph.Status_HEALTHY,
+			slotsOk:     16384,
+		},
+		{
+			name:        "Uncovered slots",
+			clusterInfo: "cluster_state:ok\r\ncluster_slots_assigned:16384\r\ncluster_slots_ok:10923\r\ncluster_slots_pfail:5461\r\ncluster_known_nodes:6\r\ncluster_size:3\r\n",
+			expected:    ph.Status_UNHEALTHY,
+			message:     "10923 of 16384 slots ok",
+			slotsOk:     10923,
+		},
+		{
+			name:        "Failed cluster",
+			clusterInfo: "cluster_state:fail\r\ncluster_slots_assigned:10923\r\ncluster_slots_ok:10923\r\ncluster_known_nodes:4\r\ncluster_size:2\r\n",
+			expected:    ph.Status_UNHEALTHY,
+			message:     "cluster state fail",
+			slotsOk:     10923,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &redis.Redis{
+				Name: "TestRedisCluster",
+				Addr: serveReplies(t, map[string]string{
+					"INFO":         bulk(info),
+					"CLUSTER INFO": bulk(tt.clusterInfo),
+				}),
+				Mode:    redis.ModeCluster,
+				Timeout: time.Second,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_Redis{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, "master", detail.Role)
+			require.NotNil(t, detail.Cluster)
+			assert.Equal(t, tt.slotsOk, detail.Cluster.SlotsOk)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+// Requirements are the preconditions of the environment an instance is checked in.
+type Requirements struct {
+	// Env lists environment variables that must be set
+	Env []string `mapstructure:"env" json:"env,omitempty"`
+	// InCluster requires running within a Kubernetes pod
+	InCluster bool `mapstructure:"inCluster" json:"inCluster,omitempty"`
+	// Files lists paths that must exist
+	Files []string `mapstructure:"files" json:"files,omitempty"`
+}
+
+// Unmet returns the first unmet precondition, or an empty string if all are met.
+func (r Requirements) Unmet() string {
+	for _, name := range r.Env {
+		if _, ok := os.LookupEnv(name); !ok {
+			return fmt.Sprintf("environment variable %s not set", name)
+		}
+	}
+	if r.InCluster && !utils.InCluster() {
+		return "not running in a kubernetes cluster"
+	}
+	for _, path := range r.Files {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Sprintf("file %s not found", path)
+		}
+	}
+	return ""
+}
+
+// Conditional wraps an instance with the preconditions under which it is checked.
+type Conditional struct {
+	Instance
+	Requires Requirements
+}
+
+// GetHealth checks the wrapped instance if all preconditions are met, otherwise
+// skipping it with status UNKNOWN.
+func (c *Conditional) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	if unmet := c.Requires.Unmet(); unmet != "" {
+		return &ph.HealthCheckResponse{
+			Type:    c.GetType(),
+			Name:    c.GetName(),
+			Status:  ph.Status_UNKNOWN,
+			Message: "precondition not met: " + unmet,
+		}
+	}
+	return c.Instance.GetHealth(ctx)
+}
+
+func (c *Conditional) GetTimeout() time.Duration {
+	if i, ok := c.Instance.(InstanceWithTimeout); ok {
+		return i.GetTimeout()
+	}
+	return 0
+}
+
+func (c *Conditional) LogValue() slog.Value {
+	if v, ok := c.Instance.(slog.LogValuer); ok {
+		return v.LogValue()
+	}
+	return slog.AnyValue(c.Instance)
+}
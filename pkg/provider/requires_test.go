@@ -0,0 +1,87 @@
+package provider_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+)
+
+func TestConditional(t *testing.T) {
+	t.Setenv("PH_TEST_PRESENT", "1")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	tests := []struct {
+		name     string
+		requires provider.Requirements
+		checked  int32
+		status   ph.Status
+		message  string
+	}{
+		{
+			name:    "NoRequirements",
+			checked: 1,
+			status:  ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "EnvPresent",
+			requires: provider.Requirements{Env: []string{"PH_TEST_PRESENT"}},
+			checked:  1,
+			status:   ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "EnvAbsent",
+			requires: provider.Requirements{Env: []string{"PH_TEST_PRESENT", "PH_TEST_ABSENT"}},
+			checked:  0,
+			status:   ph.Status_UNKNOWN,
+			message:  "precondition not met: environment variable PH_TEST_ABSENT not set",
+		},
+		{
+			name:     "NotInCluster",
+			requires: provider.Requirements{InCluster: true},
+			checked:  0,
+			status:   ph.Status_UNKNOWN,
+			message:  "precondition not met: not running in a kubernetes cluster",
+		},
+		{
+			name:     "FileExists",
+			requires: provider.Requirements{Files: []string{"requires.go"}},
+			checked:  1,
+			status:   ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "FileMissing",
+			requires: provider.Requirements{Files: []string{filepath.Join(t.TempDir(), "missing")}},
+			checked:  0,
+			status:   ph.Status_UNKNOWN,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &countingInstance{Mock: mock.Mock{Name: "gated", Health: ph.Status_UNHEALTHY, Sleep: 1}}
+
+			response, status := provider.Check(context.Background(), []provider.Instance{
+				&provider.Conditional{Instance: instance, Requires: tt.requires},
+			})
+			assert.Equal(t, tt.checked, instance.checks.Load())
+
+			require.Len(t, response, 1)
+			assert.Equal(t, "gated", response[0].GetName())
+			assert.Equal(t, tt.status, response[0].GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, response[0].GetMessage())
+			}
+			// skipped components do not affect the overall status
+			if tt.checked == 0 {
+				assert.Equal(t, ph.Status_HEALTHY, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+// RetryPolicy determines how an unhealthy instance is rechecked.
+type RetryPolicy struct {
+	// Attempts is the maximum number of checks, including the first
+	Attempts int `mapstructure:"attempts" default:"3"`
+	// Delay is the wait before the first retry
+	Delay time.Duration `mapstructure:"delay" default:"1s"`
+	// Backoff multiplies the delay after each retry
+	Backoff float64 `mapstructure:"backoff" default:"2"`
+	// MaxDelay caps the delay between retries, if set
+	MaxDelay time.Duration `mapstructure:"maxDelay"`
+}
+
+func (r *RetryPolicy) SetDefaults() {
+	defaults.SetDefaults(r)
+}
+
+// delays returns the wait before each retry.
+func (r *RetryPolicy) delays() []time.Duration {
+	delays := make([]time.Duration, 0, max(r.Attempts-1, 0))
+	delay := r.Delay
+	for range r.Attempts - 1 {
+		if r.MaxDelay > 0 && delay > r.MaxDelay {
+			delay = r.MaxDelay
+		}
+		delays = append(delays, delay)
+		delay = time.Duration(float64(delay) * r.Backoff)
+	}
+	return delays
+}
+
+// Retrying wraps an instance with the policy under which it is rechecked while unhealthy.
+type Retrying struct {
+	Instance
+	Retry RetryPolicy
+}
+
+// GetHealth checks the wrapped instance, rechecking it after each delay while
+// it is UNHEALTHY and attempts remain. Other statuses, such as UNKNOWN or
+// LOOP_DETECTED, are not retried, nor is the instance once ctx is done. Only
+// the response of the final attempt is returned.
+func (r *Retrying) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	response := r.Instance.GetHealth(ctx)
+
+	attempts := 1
+	for _, delay := range r.Retry.delays() {
+		if response == nil || response.Status != ph.Status_UNHEALTHY {
+			break
+		}
+
+		utils.ContextLogger(ctx).Debug("retrying",
+			slog.String("provider", r.GetType()),
+			slog.String("name", r.GetName()),
+			slog.Int("attempt", attempts+1),
+			slog.Any("delay", delay),
+		)
+
+		select {
+		case <-ctx.Done():
+			return response
+		case <-time.After(delay):
+		}
+
+		response = r.Instance.GetHealth(ctx)
+		attempts++
+	}
+
+	if attempts > 1 && response != nil && response.Status == ph.Status_HEALTHY && response.Message == "" {
+		response.Message = fmt.Sprintf("healthy after %d attempts", attempts)
+	}
+
+	return response
+}
+
+// GetTimeout returns the time needed for all attempts, such that the retries
+// are not cut short when timeouts are extended.
+func (r *Retrying) GetTimeout() time.Duration {
+	var timeout time.Duration
+	if i, ok := r.Instance.(InstanceWithTimeout); ok {
+		timeout = i.GetTimeout() * time.Duration(max(r.Retry.Attempts, 1))
+	}
+	for _, delay := range r.Retry.delays() {
+		timeout += delay
+	}
+	return timeout
+}
+
+func (r *Retrying) LogValue() slog.Value {
+	if v, ok := r.Instance.(slog.LogValuer); ok {
+		return v.LogValue()
+	}
+	return slog.AnyValue(r.Instance)
+}
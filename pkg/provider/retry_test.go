@@ -0,0 +1,127 @@
+package provider_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+)
+
+// flakyInstance reports its configured status only once it has failed the given number of checks
+type flakyInstance struct {
+	mock.Mock
+	failures int32
+	checks   atomic.Int32
+}
+
+func (i *flakyInstance) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	response := i.Mock.GetHealth(ctx)
+	if i.checks.Add(1) <= i.failures {
+		response.Status = ph.Status_UNHEALTHY
+		response.Message = "transient failure"
+	}
+	return response
+}
+
+func TestRetrying(t *testing.T) {
+	tests := []struct {
+		name     string
+		health   ph.Status
+		failures int32
+		attempts int
+		timeout  time.Duration
+		checked  int32
+		status   ph.Status
+		message  string
+	}{
+		{
+			name:     "HealthyFirstTime",
+			health:   ph.Status_HEALTHY,
+			attempts: 3,
+			checked:  1,
+			status:   ph.Status_HEALTHY,
+		},
+		{
+			name:     "HealthyAfterRetry",
+			health:   ph.Status_HEALTHY,
+			failures: 2,
+			attempts: 3,
+			checked:  3,
+			status:   ph.Status_HEALTHY,
+			message:  "healthy after 3 attempts",
+		},
+		{
+			name:     "AttemptsExhausted",
+			health:   ph.Status_HEALTHY,
+			failures: 5,
+			attempts: 3,
+			checked:  3,
+			status:   ph.Status_UNHEALTHY,
+			message:  "transient failure",
+		},
+		{
+			name:     "UnknownNotRetried",
+			health:   ph.Status_UNKNOWN,
+			attempts: 3,
+			checked:  1,
+			status:   ph.Status_UNKNOWN,
+		},
+		{
+			name:     "LoopDetectedNotRetried",
+			health:   ph.Status_LOOP_DETECTED,
+			attempts: 3,
+			checked:  1,
+			status:   ph.Status_LOOP_DETECTED,
+		},
+		{
+			name:     "ContextDone",
+			health:   ph.Status_HEALTHY,
+			failures: 5,
+			attempts: 10,
+			timeout:  30 * time.Millisecond,
+			checked:  2,
+			status:   ph.Status_UNHEALTHY,
+			message:  "transient failure",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			instance := &flakyInstance{Mock: mock.Mock{Name: "flaky", Health: tt.health, Sleep: 1}, failures: tt.failures}
+			retrying := &provider.Retrying{
+				Instance: instance,
+				Retry:    provider.RetryPolicy{Attempts: tt.attempts, Delay: 20 * time.Millisecond, Backoff: 1},
+			}
+
+			response := retrying.GetHealth(ctx)
+			require.NotNil(t, response)
+			assert.Equal(t, tt.checked, instance.checks.Load())
+			assert.Equal(t, tt.status, response.GetStatus())
+			assert.Equal(t, tt.message, response.GetMessage())
+		})
+	}
+}
+
+func TestRetryPolicyTimeout(t *testing.T) {
+	retrying := &provider.Retrying{
+		Instance: &provider.Conditional{Instance: &mock.Mock{Name: "slow"}},
+		Retry:    provider.RetryPolicy{Attempts: 4, Delay: time.Second, Backoff: 2, MaxDelay: 3 * time.Second},
+	}
+
+	// the delays of 1s, 2s and 3s (capped from 4s) between four attempts
+	assert.Equal(t, 6*time.Second, retrying.GetTimeout())
+}
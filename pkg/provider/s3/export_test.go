@@ -0,0 +1,13 @@
+package s3
+
+// SignV4 exposes the request signer to tests
+var SignV4 = signV4
+
+// URL exposes the bucket and object URL construction to tests
+func (i *S3) URL(key string) (string, error) {
+	target, err := i.url(key)
+	if err != nil {
+		return "", err
+	}
+	return target.String(), nil
+}
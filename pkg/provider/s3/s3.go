@@ -0,0 +1,395 @@
+package s3
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypeS3 = "s3"
+
+type S3 struct {
+	Name              string        `mapstructure:"name"`
+	Endpoint          string        `mapstructure:"endpoint"`
+	Region            string        `mapstructure:"region" default:"us-east-1"`
+	Bucket            string        `mapstructure:"bucket"`
+	Key               string        `mapstructure:"key"`
+	AccessKeyID       string        `mapstructure:"accessKeyId"`
+	SecretAccessKey   string        `mapstructure:"secretAccessKey"`
+	SessionToken      string        `mapstructure:"sessionToken"`
+	PathStyle         bool          `mapstructure:"pathStyle"`
+	Timeout           time.Duration `mapstructure:"timeout" default:"5s"`
+	Insecure          bool          `mapstructure:"insecure"`
+	Digest            string        `mapstructure:"digest"`
+	MaxSize           int64         `mapstructure:"maxSize"`
+	Encryption        bool          `mapstructure:"encryption"`
+	PublicAccessBlock bool          `mapstructure:"publicAccessBlock"`
+	Versioning        bool          `mapstructure:"versioning"`
+	Detail            bool          `mapstructure:"detail"`
+}
+
+var certPool *x509.CertPool = nil
+
+func init() {
+	provider.Register(TypeS3, new(S3))
+	if systemCertPool, err := x509.SystemCertPool(); err == nil {
+		certPool = systemCertPool
+	}
+}
+
+func (i *S3) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("endpoint", i.Endpoint),
+		slog.String("region", i.Region),
+		slog.String("bucket", i.Bucket),
+		slog.String("key", i.Key),
+		slog.String("digest", i.Digest),
+		slog.Int64("maxSize", i.MaxSize),
+		slog.Bool("pathStyle", i.PathStyle),
+		slog.Bool("encryption", i.Encryption),
+		slog.Bool("publicAccessBlock", i.PublicAccessBlock),
+		slog.Bool("versioning", i.Versioning),
+		slog.Any("timeout", i.Timeout),
+		slog.Bool("insecure", i.Insecure),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *S3) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *S3) GetType() string {
+	return TypeS3
+}
+
+func (i *S3) GetName() string {
+	return i.Name
+}
+
+func (i *S3) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *S3) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypeS3), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypeS3,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	client := &http.Client{
+		Timeout: i.Timeout,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:            certPool,
+			InsecureSkipVerify: i.Insecure,
+		}},
+	}
+
+	if _, err := i.head(ctx, client, ""); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	bucket := &details.Detail_S3{Bucket: i.Bucket}
+	if err := i.getSettings(ctx, client, bucket); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+	noncompliance := i.checkSettings(bucket)
+	if noncompliance != nil || i.Key == "" {
+		if err := i.addDetail(component, bucket, nil, nil); err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		if noncompliance != nil {
+			return component.Unhealthy(noncompliance.Error())
+		}
+		return component.Healthy()
+	}
+
+	if i.Digest == "" {
+		response, err := i.head(ctx, client, i.Key)
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		if err := i.addDetail(component, bucket, response, nil); err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		return component.Healthy()
+	}
+
+	digest, err := utils.ParseDigest(i.Digest)
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	response, err := i.do(ctx, client, http.MethodGet, i.Key, "")
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+	defer response.Body.Close()
+
+	var body io.Reader = response.Body
+	if i.MaxSize > 0 {
+		if response.ContentLength > i.MaxSize {
+			return component.Unhealthy(fmt.Sprintf("object size exceeds %d bytes", i.MaxSize))
+		}
+		body = io.LimitReader(body, i.MaxSize+1)
+	}
+	if _, err := io.Copy(digest, body); err != nil {
+		return component.Unhealthy(fmt.Sprintf("failed to read object %s/%s: %v", i.Bucket, i.Key, err))
+	}
+	if i.MaxSize > 0 && digest.Size > i.MaxSize {
+		return component.Unhealthy(fmt.Sprintf("object size exceeds %d bytes", i.MaxSize))
+	}
+
+	if err := i.addDetail(component, bucket, response, digest); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	if err := digest.Verify(); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	return component.Healthy()
+}
+
+// checksSettings reports whether any bucket settings are selected for checking.
+func (i *S3) checksSettings() bool {
+	return i.Encryption || i.PublicAccessBlock || i.Versioning
+}
+
+// getSettings fetches the bucket settings selected for checking into detail.
+func (i *S3) getSettings(ctx context.Context, client *http.Client, detail *details.Detail_S3) error {
+	if i.Encryption {
+		var configuration struct {
+			Rules []struct {
+				Algorithm string `xml:"ApplyServerSideEncryptionByDefault>SSEAlgorithm"`
+			} `xml:"Rule"`
+		}
+		if err := i.getSubresource(ctx, client, "encryption", &configuration); err != nil {
+			return err
+		}
+		for _, rule := range configuration.Rules {
+			detail.Encrypted = detail.Encrypted || rule.Algorithm != ""
+		}
+	}
+
+	if i.PublicAccessBlock {
+		// a bucket without a public access block configuration blocks nothing
+		var configuration struct {
+			BlockPublicAcls       bool
+			IgnorePublicAcls      bool
+			BlockPublicPolicy     bool
+			RestrictPublicBuckets bool
+		}
+		if err := i.getSubresource(ctx, client, "publicAccessBlock", &configuration); err != nil {
+			return err
+		}
+		detail.Public = !(configuration.BlockPublicAcls && configuration.IgnorePublicAcls &&
+			configuration.BlockPublicPolicy && configuration.RestrictPublicBuckets)
+	}
+
+	if i.Versioning {
+		var configuration struct {
+			Status string
+		}
+		if err := i.getSubresource(ctx, client, "versioning", &configuration); err != nil {
+			return err
+		}
+		detail.Versioning = configuration.Status == "Enabled"
+	}
+
+	return nil
+}
+
+// checkSettings returns an error describing the first bucket setting in
+// detail that does not comply with those selected for checking.
+func (i *S3) checkSettings(detail *details.Detail_S3) error {
+	switch {
+	case i.Encryption && !detail.Encrypted:
+		return fmt.Errorf("bucket %s is not encrypted", i.Bucket)
+	case i.PublicAccessBlock && detail.Public:
+		return fmt.Errorf("bucket %s does not block public access", i.Bucket)
+	case i.Versioning && !detail.Versioning:
+		return fmt.Errorf("bucket %s is not versioned", i.Bucket)
+	}
+	return nil
+}
+
+// getSubresource decodes the configuration of a bucket subresource into v,
+// leaving v empty if the bucket has no such configuration.
+func (i *S3) getSubresource(ctx context.Context, client *http.Client, subresource string, v any) error {
+	response, err := i.do(ctx, client, http.MethodGet, "", subresource)
+	if errors.Is(err, errNotConfigured) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if err := xml.NewDecoder(response.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s configuration of bucket %s: %w", subresource, i.Bucket, err)
+	}
+	return nil
+}
+
+// addDetail appends the bucket settings in detail if checked, the object
+// metadata of response if any, and its digest if computed, to the component
+// details if enabled.
+func (i *S3) addDetail(component *ph.HealthCheckResponse, detail *details.Detail_S3, response *http.Response, digest *utils.Digest) error {
+	if !i.Detail || (response == nil && !i.checksSettings()) {
+		return nil
+	}
+
+	if response != nil {
+		detail.Key = i.Key
+		detail.Size = response.ContentLength
+		detail.Etag = strings.Trim(response.Header.Get("ETag"), `"`)
+		if lastModified, err := http.ParseTime(response.Header.Get("Last-Modified")); err == nil {
+			detail.LastModified = timestamppb.New(lastModified)
+		}
+	}
+	if anyDetail, err := anypb.New(detail); err != nil {
+		return err
+	} else {
+		component.Details = append(component.Details, anyDetail)
+	}
+
+	if digest != nil {
+		if anyDetail, err := anypb.New(&details.Detail_Digest{Algorithm: digest.Algorithm, Digest: digest.Actual(), Size: digest.Size}); err != nil {
+			return err
+		} else {
+			component.Details = append(component.Details, anyDetail)
+		}
+	}
+
+	return nil
+}
+
+// head sends a signed HEAD request for the bucket, or for the object key
+// within it, returning the response if it exists and is accessible.
+func (i *S3) head(ctx context.Context, client *http.Client, key string) (*http.Response, error) {
+	response, err := i.do(ctx, client, http.MethodHead, key, "")
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	return response, nil
+}
+
+// errNotConfigured is returned by do for a bucket subresource that has no
+// configuration.
+var errNotConfigured = errors.New("not configured")
+
+// do sends a signed request for the bucket, or for the object key within it,
+// or for a subresource of the bucket, such as its encryption configuration,
+// returning the response, whose body the caller must close, if it exists and
+// is accessible.
+func (i *S3) do(ctx context.Context, client *http.Client, method, key, subresource string) (*http.Response, error) {
+	target, err := i.url(key)
+	if err != nil {
+		return nil, err
+	}
+	target.RawQuery = subresource
+
+	request, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	accessKeyID, secretAccessKey, sessionToken := i.credentials()
+	if accessKeyID != "" {
+		signV4(request, accessKeyID, secretAccessKey, sessionToken, i.Region, time.Now())
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusOK {
+		return response, nil
+	}
+	response.Body.Close()
+
+	resource := "bucket " + i.Bucket
+	if key != "" {
+		resource = fmt.Sprintf("object %s/%s", i.Bucket, key)
+	} else if subresource != "" {
+		resource = fmt.Sprintf("%s configuration of bucket %s", subresource, i.Bucket)
+	}
+
+	switch response.StatusCode {
+	case http.StatusNotFound:
+		if subresource != "" {
+			return nil, errNotConfigured
+		}
+		return nil, fmt.Errorf("%s not found", resource)
+	case http.StatusForbidden:
+		return nil, fmt.Errorf("access denied to %s", resource)
+	case http.StatusMovedPermanently:
+		return nil, fmt.Errorf("%s is in region %s", resource, response.Header.Get("X-Amz-Bucket-Region"))
+	default:
+		return nil, fmt.Errorf("%s: status %d", resource, response.StatusCode)
+	}
+}
+
+// url returns the URL of the bucket, or of the object key within it, in
+// either virtual-hosted or path style.
+func (i *S3) url(key string) (*url.URL, error) {
+	endpoint := i.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", i.Region)
+	}
+
+	target, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if target.Scheme == "" || target.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q", endpoint)
+	}
+
+	if i.PathStyle {
+		target.Path = "/" + i.Bucket + "/" + key
+	} else {
+		target.Host = i.Bucket + "." + target.Host
+		target.Path = "/" + key
+	}
+
+	return target, nil
+}
+
+// credentials returns the configured credentials, falling back to the
+// standard AWS environment variables. Requests are sent unsigned when no
+// access key is available.
+func (i *S3) credentials() (accessKeyID, secretAccessKey, sessionToken string) {
+	if i.AccessKeyID != "" {
+		return i.AccessKeyID, i.SecretAccessKey, i.SessionToken
+	}
+	return os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_SESSION_TOKEN")
+}
@@ -0,0 +1,415 @@
+package s3_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/s3"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+const (
+	testAccessKeyID     = "AKIDEXAMPLE"
+	testSecretAccessKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+// manifest is the content of the releases/v1.2.3/manifest.txt object
+const manifest = "platform-health artifact\n"
+
+// serve runs a path-style S3-compatible endpoint holding the "assets" bucket
+// with two objects, refusing requests not signed with the test credentials.
+func serve(t *testing.T) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead && r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if !authorized(r) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/assets", "/assets/":
+		case "/assets/releases/v1.2.3/app.tar.gz":
+			w.Header().Set("Content-Length", "1048576")
+			w.Header().Set("ETag", `"9b2cf535f27731c974343645a3985328"`)
+			w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		case "/assets/releases/v1.2.3/manifest.txt":
+			w.Write([]byte(manifest))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+// authorized reports whether r is signed with the test credentials
+func authorized(r *http.Request) bool {
+	authorization := r.Header.Get("Authorization")
+	return strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential="+testAccessKeyID+"/") &&
+		strings.Contains(authorization, "/eu-west-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=")
+}
+
+// serveSettings runs a path-style S3-compatible endpoint holding the
+// "compliant" bucket, which is encrypted, blocks public access and is
+// versioned, and the "exposed" bucket, which has none of these settings.
+func serveSettings(t *testing.T) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path + "?" + r.URL.RawQuery {
+		case "/compliant/?", "/exposed/?":
+		case "/compliant/?encryption":
+			w.Write([]byte(`<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`))
+		case "/compliant/?publicAccessBlock":
+			w.Write([]byte(`<PublicAccessBlockConfiguration><BlockPublicAcls>true</BlockPublicAcls><IgnorePublicAcls>true</IgnorePublicAcls><BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>true</RestrictPublicBuckets></PublicAccessBlockConfiguration>`))
+		case "/compliant/?versioning":
+			w.Write([]byte(`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`))
+		case "/exposed/?publicAccessBlock":
+			w.Write([]byte(`<PublicAccessBlockConfiguration><BlockPublicAcls>true</BlockPublicAcls><IgnorePublicAcls>false</IgnorePublicAcls><BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>true</RestrictPublicBuckets></PublicAccessBlockConfiguration>`))
+		case "/exposed/?versioning":
+			w.Write([]byte(`<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestS3(t *testing.T) {
+	tests := []struct {
+		name      string
+		bucket    string
+		key       string
+		accessKey string
+		status    ph.Status
+		message   string
+		detail    bool
+	}{
+		{
+			name:      "Bucket exists",
+			bucket:    "assets",
+			accessKey: testAccessKeyID,
+			status:    ph.Status_HEALTHY,
+		},
+		{
+			name:      "Object exists",
+			bucket:    "assets",
+			key:       "releases/v1.2.3/app.tar.gz",
+			accessKey: testAccessKeyID,
+			status:    ph.Status_HEALTHY,
+			detail:    true,
+		},
+		{
+			name:      "Missing bucket",
+			bucket:    "missing",
+			accessKey: testAccessKeyID,
+			status:    ph.Status_UNHEALTHY,
+			message:   "bucket missing not found",
+		},
+		{
+			name:      "Missing object",
+			bucket:    "assets",
+			key:       "releases/v9.9.9/app.tar.gz",
+			accessKey: testAccessKeyID,
+			status:    ph.Status_UNHEALTHY,
+			message:   "object assets/releases/v9.9.9/app.tar.gz not found",
+		},
+		{
+			name:      "Wrong credentials",
+			bucket:    "assets",
+			accessKey: "AKIDWRONG",
+			status:    ph.Status_UNHEALTHY,
+			message:   "access denied to bucket assets",
+		},
+	}
+
+	endpoint := serve(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &s3.S3{
+				Name:            "TestS3",
+				Endpoint:        endpoint,
+				Region:          "eu-west-1",
+				Bucket:          tt.bucket,
+				Key:             tt.key,
+				AccessKeyID:     tt.accessKey,
+				SecretAccessKey: testSecretAccessKey,
+				PathStyle:       true,
+				Timeout:         time.Second,
+				Detail:          true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, s3.TypeS3, result.GetType())
+			assert.Equal(t, tt.status, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+
+			if !tt.detail {
+				assert.Empty(t, result.Details)
+				return
+			}
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_S3{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, "assets", detail.Bucket)
+			assert.Equal(t, tt.key, detail.Key)
+			assert.EqualValues(t, 1048576, detail.Size)
+			assert.Equal(t, "9b2cf535f27731c974343645a3985328", detail.Etag)
+			assert.Equal(t, time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC), detail.LastModified.AsTime())
+		})
+	}
+}
+
+func TestS3URL(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		pathStyle bool
+		key       string
+		expected  string
+	}{
+		{
+			name:     "AWS bucket",
+			expected: "https://assets.s3.eu-west-1.amazonaws.com/",
+		},
+		{
+			name:     "AWS object",
+			key:      "releases/app.tar.gz",
+			expected: "https://assets.s3.eu-west-1.amazonaws.com/releases/app.tar.gz",
+		},
+		{
+			name:      "Path style endpoint",
+			endpoint:  "http://minio.example.com:9000",
+			pathStyle: true,
+			key:       "releases/app.tar.gz",
+			expected:  "http://minio.example.com:9000/assets/releases/app.tar.gz",
+		},
+		{
+			name:     "Virtual-hosted endpoint",
+			endpoint: "https://storage.example.com",
+			expected: "https://assets.storage.example.com/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &s3.S3{
+				Endpoint:  tt.endpoint,
+				Region:    "eu-west-1",
+				Bucket:    "assets",
+				PathStyle: tt.pathStyle,
+			}
+			url, err := instance.URL(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, url)
+		})
+	}
+}
+
+func TestS3Digest(t *testing.T) {
+	const sha256 = "c53f5254ace8f233ecdf726415d27b20d57d24d011155dfb82a188e16eb2ea3a"
+
+	tests := []struct {
+		name    string
+		key     string
+		digest  string
+		maxSize int64
+		status  ph.Status
+		message string
+		detail  bool
+	}{
+		{
+			name:   "Matching digest",
+			key:    "releases/v1.2.3/manifest.txt",
+			digest: "sha256:" + sha256,
+			status: ph.Status_HEALTHY,
+			detail: true,
+		},
+		{
+			name:    "Mismatched digest",
+			key:     "releases/v1.2.3/manifest.txt",
+			digest:  "md5:00000000000000000000000000000000",
+			status:  ph.Status_UNHEALTHY,
+			message: "expected md5 digest 00000000000000000000000000000000; actual 45df4b91279da77d8131d76d5cd38393",
+			detail:  true,
+		},
+		{
+			name:    "Object exceeding maximum",
+			key:     "releases/v1.2.3/manifest.txt",
+			digest:  "sha256:" + sha256,
+			maxSize: 8,
+			status:  ph.Status_UNHEALTHY,
+			message: "object size exceeds 8 bytes",
+		},
+		{
+			name:    "Missing object",
+			key:     "releases/v9.9.9/manifest.txt",
+			digest:  "sha256:" + sha256,
+			status:  ph.Status_UNHEALTHY,
+			message: "object assets/releases/v9.9.9/manifest.txt not found",
+		},
+	}
+
+	endpoint := serve(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &s3.S3{
+				Name:            "TestS3Digest",
+				Endpoint:        endpoint,
+				Region:          "eu-west-1",
+				Bucket:          "assets",
+				Key:             tt.key,
+				AccessKeyID:     testAccessKeyID,
+				SecretAccessKey: testSecretAccessKey,
+				PathStyle:       true,
+				Digest:          tt.digest,
+				MaxSize:         tt.maxSize,
+				Timeout:         time.Second,
+				Detail:          true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.status, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+
+			if !tt.detail {
+				assert.Empty(t, result.Details)
+				return
+			}
+			require.Len(t, result.Details, 2)
+			detail := &details.Detail_Digest{}
+			require.NoError(t, result.Details[1].UnmarshalTo(detail))
+			assert.EqualValues(t, len(manifest), detail.Size)
+			if tt.status == ph.Status_HEALTHY {
+				assert.Equal(t, sha256, detail.Digest)
+			}
+		})
+	}
+}
+
+func TestS3Settings(t *testing.T) {
+	tests := []struct {
+		name              string
+		bucket            string
+		encryption        bool
+		publicAccessBlock bool
+		versioning        bool
+		status            ph.Status
+		message           string
+		expected          *details.Detail_S3
+	}{
+		{
+			name:              "Compliant bucket",
+			bucket:            "compliant",
+			encryption:        true,
+			publicAccessBlock: true,
+			versioning:        true,
+			status:            ph.Status_HEALTHY,
+			expected:          &details.Detail_S3{Bucket: "compliant", Encrypted: true, Versioning: true},
+		},
+		{
+			name:       "Unencrypted bucket",
+			bucket:     "exposed",
+			encryption: true,
+			status:     ph.Status_UNHEALTHY,
+			message:    "bucket exposed is not encrypted",
+			expected:   &details.Detail_S3{Bucket: "exposed"},
+		},
+		{
+			name:              "Public bucket",
+			bucket:            "exposed",
+			publicAccessBlock: true,
+			status:            ph.Status_UNHEALTHY,
+			message:           "bucket exposed does not block public access",
+			expected:          &details.Detail_S3{Bucket: "exposed", Public: true},
+		},
+		{
+			name:       "Unversioned bucket",
+			bucket:     "exposed",
+			versioning: true,
+			status:     ph.Status_UNHEALTHY,
+			message:    "bucket exposed is not versioned",
+			expected:   &details.Detail_S3{Bucket: "exposed"},
+		},
+		{
+			name:              "Non-compliant bucket",
+			bucket:            "exposed",
+			encryption:        true,
+			publicAccessBlock: true,
+			versioning:        true,
+			status:            ph.Status_UNHEALTHY,
+			message:           "bucket exposed is not encrypted",
+			expected:          &details.Detail_S3{Bucket: "exposed", Public: true},
+		},
+	}
+
+	endpoint := serveSettings(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &s3.S3{
+				Name:              "TestS3Settings",
+				Endpoint:          endpoint,
+				Region:            "eu-west-1",
+				Bucket:            tt.bucket,
+				AccessKeyID:       testAccessKeyID,
+				SecretAccessKey:   testSecretAccessKey,
+				PathStyle:         true,
+				Encryption:        tt.encryption,
+				PublicAccessBlock: tt.publicAccessBlock,
+				Versioning:        tt.versioning,
+				Timeout:           time.Second,
+				Detail:            true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.status, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_S3{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.expected.Bucket, detail.Bucket)
+			assert.Equal(t, tt.expected.Encrypted, detail.Encrypted)
+			assert.Equal(t, tt.expected.Public, detail.Public)
+			assert.Equal(t, tt.expected.Versioning, detail.Versioning)
+		})
+	}
+}
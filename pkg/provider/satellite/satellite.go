@@ -6,14 +6,18 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/mcuadros/go-defaults"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	"github.com/isometry/platform-health/pkg/provider"
 	"github.com/isometry/platform-health/pkg/server"
 	"github.com/isometry/platform-health/pkg/utils"
@@ -22,12 +26,16 @@ import (
 const TypeSatellite = "satellite"
 
 type Satellite struct {
-	Name     string        `mapstructure:"name"`
-	Host     string        `mapstructure:"host"`
-	Port     int           `mapstructure:"port"`
-	TLS      bool          `mapstructure:"tls"`
-	Insecure bool          `mapstructure:"insecure"`
-	Timeout  time.Duration `mapstructure:"timeout" default:"30s"`
+	Name           string        `mapstructure:"name"`
+	Host           string        `mapstructure:"host"`
+	Hosts          []string      `mapstructure:"hosts"`
+	Port           int           `mapstructure:"port"`
+	TLS            bool          `mapstructure:"tls"`
+	Insecure       bool          `mapstructure:"insecure"`
+	MaxHops        int           `mapstructure:"maxHops"`
+	MinHealthy     int           `mapstructure:"minHealthy"`
+	MaxConcurrency int           `mapstructure:"maxConcurrency" default:"8"`
+	Timeout        time.Duration `mapstructure:"timeout" default:"30s"`
 }
 
 func init() {
@@ -38,7 +46,10 @@ func (i *Satellite) LogValue() slog.Value {
 	logAttr := []slog.Attr{
 		slog.String("name", i.Name),
 		slog.String("host", i.Host),
+		slog.Any("hosts", i.Hosts),
 		slog.Int("port", i.Port),
+		slog.Int("maxHops", i.MaxHops),
+		slog.Int("minHealthy", i.MinHealthy),
 		slog.Any("timeout", i.Timeout),
 	}
 	return slog.GroupValue(logAttr...)
@@ -56,6 +67,10 @@ func (i *Satellite) GetName() string {
 	return i.Name
 }
 
+func (i *Satellite) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
 func (i *Satellite) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	log := utils.ContextLogger(ctx, slog.String("provider", TypeSatellite), slog.Any("instance", i))
 	log.Debug("checking")
@@ -69,14 +84,115 @@ func (i *Satellite) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
 	defer cancel()
 
-	if i.Port == 443 || i.Port == 8443 {
-		i.TLS = true
+	// Refuse to extend a chain of satellites beyond the limit, cycle or not
+	hops := server.HopsFromContext(ctx)
+	if i.MaxHops > 0 && len(hops) > i.MaxHops {
+		component.Status = ph.Status_LOOP_DETECTED
+		component.Message = fmt.Sprintf("maximum of %d hops exceeded", i.MaxHops)
+		if detail, err := anypb.New(&details.Detail_Loop{ServerIds: hops}); err == nil {
+			component.Details = append(component.Details, detail)
+		}
+		return component
+	}
+
+	if len(i.Hosts) > 0 {
+		return i.fanOut(ctx, component, hops)
+	}
+
+	status := i.query(ctx, i.Host, i.Port, hops)
+
+	// If a loop was detected, expose serverId to assist debugging
+	if status.Status == ph.Status_LOOP_DETECTED {
+		component.ServerId = status.ServerId
+	}
+
+	// Preserve downstream annotations unmodified so end-to-end context isn't lost
+	component.Status = status.Status
+	component.Message = status.Message
+	component.Details = status.Details
+	component.Warnings = status.Warnings
+	component.Components = status.Components
+
+	return component
+}
+
+// fanOut queries every host concurrently, with at most MaxConcurrency queries
+// in flight, reporting each as a child component. The satellite is healthy if
+// at least MinHealthy hosts are, or, if MinHealthy is unset, all of them.
+func (i *Satellite) fanOut(ctx context.Context, component *ph.HealthCheckResponse, hops []string) *ph.HealthCheckResponse {
+	component.Components = make([]*ph.HealthCheckResponse, len(i.Hosts))
+	slots := make(chan struct{}, max(i.MaxConcurrency, 1))
+
+	var wg sync.WaitGroup
+	for n, address := range i.Hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			host, port := address, i.Port
+			if h, p, err := net.SplitHostPort(address); err == nil {
+				host = h
+				port, _ = strconv.Atoi(p)
+			}
+
+			child := &ph.HealthCheckResponse{
+				Type: TypeSatellite,
+				Name: address,
+			}
+			component.Components[n] = child
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				child.Unhealthy("timeout")
+				return
+			}
+
+			status := i.query(ctx, host, port, hops)
+			if status.Status == ph.Status_UNHEALTHY && ctx.Err() != nil {
+				status.Message = "timeout"
+			}
+			child.Status = status.Status
+			child.Message = status.Message
+			child.Details = status.Details
+			child.Warnings = status.Warnings
+			child.Components = status.Components
+			child.ServerId = status.ServerId
+		}()
+	}
+	wg.Wait()
+
+	healthy := 0
+	worst := ph.Status_UNHEALTHY
+	for _, child := range component.Components {
+		if child.Status == ph.Status_HEALTHY {
+			healthy++
+		} else if child.Status.Number() > worst.Number() {
+			worst = child.Status
+		}
+	}
+
+	required := len(i.Hosts)
+	if i.MinHealthy > 0 {
+		required = min(i.MinHealthy, required)
+	}
+	if healthy >= required {
+		return component.Healthy()
 	}
 
+	component.Status = worst
+	component.Message = fmt.Sprintf("%d of %d hosts healthy; %d required", healthy, len(i.Hosts), required)
+	return component
+}
+
+// query checks the platform-health server at host:port, returning its response.
+func (i *Satellite) query(ctx context.Context, host string, port int, hops []string) *ph.HealthCheckResponse {
+	useTLS := i.TLS || port == 443 || port == 8443
+
 	dialOptions := []grpc.DialOption{}
-	if i.TLS {
+	if useTLS {
 		tlsConf := &tls.Config{
-			ServerName: i.Host,
+			ServerName: host,
 		}
 		if i.Insecure {
 			tlsConf.InsecureSkipVerify = true
@@ -86,31 +202,22 @@ func (i *Satellite) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	address := net.JoinHostPort(i.Host, fmt.Sprint(i.Port))
+	address := net.JoinHostPort(host, fmt.Sprint(port))
 	conn, err := grpc.NewClient(address, dialOptions...)
 	if err != nil {
-		return component.Unhealthy(err.Error())
+		return (&ph.HealthCheckResponse{}).Unhealthy(err.Error())
 	}
+	defer conn.Close()
 
 	// Propagate already visited serverIds from context to enable loop detection
 	request := &ph.HealthCheckRequest{
-		Hops: server.HopsFromContext(ctx),
+		Hops: hops,
 	}
 
 	status, err := ph.NewHealthClient(conn).Check(ctx, request)
-
 	if err != nil {
-		return component.Unhealthy(err.Error())
+		return (&ph.HealthCheckResponse{}).Unhealthy(err.Error())
 	}
 
-	// If a loop was detected, expose serverId to assist debugging
-	if status.Status == ph.Status_LOOP_DETECTED {
-		component.ServerId = status.ServerId
-	}
-
-	component.Status = status.Status
-	component.Details = status.Details
-	component.Components = status.Components
-
-	return component
+	return status
 }
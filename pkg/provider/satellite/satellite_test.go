@@ -8,8 +8,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	"github.com/isometry/platform-health/pkg/provider"
 	"github.com/isometry/platform-health/pkg/provider/mock"
 	"github.com/isometry/platform-health/pkg/provider/satellite"
@@ -117,3 +122,227 @@ func TestSatelliteGetHealth(t *testing.T) {
 		})
 	}
 }
+
+type cannedHealthServer struct {
+	ph.UnimplementedHealthServer
+	response *ph.HealthCheckResponse
+}
+
+func (s *cannedHealthServer) Check(context.Context, *ph.HealthCheckRequest) (*ph.HealthCheckResponse, error) {
+	return s.response, nil
+}
+
+func TestSatellitePreservesAnnotations(t *testing.T) {
+	tlsDetail, err := anypb.New(&details.Detail_TLS{CommonName: "downstream.example.com"})
+	require.NoError(t, err)
+	loopDetail, err := anypb.New(&details.Detail_Loop{ServerIds: []string{"a", "b"}})
+	require.NoError(t, err)
+
+	downstream := &ph.HealthCheckResponse{
+		Status:   ph.Status_UNHEALTHY,
+		Message:  "downstream degraded",
+		Details:  []*anypb.Any{loopDetail},
+		Warnings: []string{"2 components with warnings"},
+		Components: []*ph.HealthCheckResponse{
+			{
+				Type:    "tls",
+				Name:    "cert",
+				Status:  ph.Status_UNHEALTHY,
+				Message: "certificate expires soon",
+				Details: []*anypb.Any{tlsDetail},
+			},
+			{
+				Type:     "helm",
+				Name:     "release",
+				Status:   ph.Status_HEALTHY,
+				Warnings: []string{"chart example 1.0.0 is deprecated"},
+			},
+		},
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	ph.RegisterHealthServer(grpcServer, &cannedHealthServer{response: downstream})
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	component := &satellite.Satellite{
+		Name:    "TestSatellite",
+		Host:    "localhost",
+		Port:    listener.Addr().(*net.TCPAddr).Port,
+		Timeout: time.Second,
+	}
+	component.SetDefaults()
+
+	result := component.GetHealth(context.Background())
+
+	require.NotNil(t, result)
+	assert.Equal(t, satellite.TypeSatellite, result.GetType())
+	assert.Equal(t, component.Name, result.GetName())
+	assert.Equal(t, downstream.Status, result.GetStatus())
+	assert.Equal(t, downstream.Message, result.GetMessage())
+	assert.True(t, proto.Equal(downstream.Details[0], result.Details[0]), "expected details to be preserved")
+	assert.Equal(t, downstream.Warnings, result.GetWarnings(), "expected warnings to be preserved")
+
+	require.Len(t, result.Components, len(downstream.Components))
+	for n, expected := range downstream.Components {
+		assert.True(t, proto.Equal(expected, result.Components[n]), "expected component %q to be preserved", expected.Name)
+	}
+}
+
+func TestSatelliteMaxHops(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	ph.RegisterHealthServer(grpcServer, &cannedHealthServer{response: &ph.HealthCheckResponse{Status: ph.Status_HEALTHY}})
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	tests := []struct {
+		name     string
+		maxHops  int
+		hops     []string
+		expected ph.Status
+	}{
+		{
+			name:     "Unlimited",
+			hops:     []string{"a", "b", "c"},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Within limit",
+			maxHops:  2,
+			hops:     []string{"a", "b"},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Limit exceeded",
+			maxHops:  2,
+			hops:     []string{"a", "b", "c"},
+			expected: ph.Status_LOOP_DETECTED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := &satellite.Satellite{
+				Name:    "TestSatellite",
+				Host:    "localhost",
+				Port:    listener.Addr().(*net.TCPAddr).Port,
+				MaxHops: tt.maxHops,
+				Timeout: time.Second,
+			}
+			component.SetDefaults()
+
+			result := component.GetHealth(server.ContextWithHops(context.Background(), tt.hops))
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.expected != ph.Status_LOOP_DETECTED {
+				return
+			}
+
+			assert.Equal(t, "maximum of 2 hops exceeded", result.GetMessage())
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_Loop{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.hops, detail.ServerIds)
+		})
+	}
+}
+
+// slowHealthServer responds only once the request is cancelled.
+type slowHealthServer struct {
+	ph.UnimplementedHealthServer
+}
+
+func (s *slowHealthServer) Check(ctx context.Context, _ *ph.HealthCheckRequest) (*ph.HealthCheckResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestSatelliteHosts(t *testing.T) {
+	serve := func(service ph.HealthServer) string {
+		listener, err := net.Listen("tcp", "localhost:0")
+		require.NoError(t, err)
+		grpcServer := grpc.NewServer()
+		ph.RegisterHealthServer(grpcServer, service)
+		go grpcServer.Serve(listener)
+		t.Cleanup(grpcServer.Stop)
+		return listener.Addr().String()
+	}
+
+	healthy := serve(&cannedHealthServer{response: &ph.HealthCheckResponse{Status: ph.Status_HEALTHY, Warnings: []string{"chart example 1.0.0 is deprecated"}}})
+	unhealthy := serve(&cannedHealthServer{response: &ph.HealthCheckResponse{Status: ph.Status_UNHEALTHY, Message: "degraded"}})
+	slow := serve(&slowHealthServer{})
+
+	tests := []struct {
+		name       string
+		hosts      []string
+		minHealthy int
+		expected   ph.Status
+		message    string
+		children   []ph.Status
+	}{
+		{
+			name:     "All healthy",
+			hosts:    []string{healthy, healthy},
+			expected: ph.Status_HEALTHY,
+			children: []ph.Status{ph.Status_HEALTHY, ph.Status_HEALTHY},
+		},
+		{
+			name:     "All required",
+			hosts:    []string{healthy, unhealthy},
+			expected: ph.Status_UNHEALTHY,
+			message:  "1 of 2 hosts healthy; 2 required",
+			children: []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY},
+		},
+		{
+			name:       "Quorum met",
+			hosts:      []string{healthy, unhealthy, healthy},
+			minHealthy: 2,
+			expected:   ph.Status_HEALTHY,
+			children:   []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY, ph.Status_HEALTHY},
+		},
+		{
+			name:       "Quorum missed with timeout",
+			hosts:      []string{healthy, slow, unhealthy},
+			minHealthy: 2,
+			expected:   ph.Status_UNHEALTHY,
+			message:    "1 of 3 hosts healthy; 2 required",
+			children:   []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY, ph.Status_UNHEALTHY},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := &satellite.Satellite{
+				Name:       "TestSatellite",
+				Hosts:      tt.hosts,
+				MinHealthy: tt.minHealthy,
+				Timeout:    200 * time.Millisecond,
+			}
+			component.SetDefaults()
+
+			result := component.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			require.Len(t, result.Components, len(tt.hosts))
+			for n, child := range result.Components {
+				assert.Equal(t, tt.hosts[n], child.GetName())
+				assert.Equal(t, tt.children[n], child.GetStatus())
+				if tt.hosts[n] == slow {
+					assert.Equal(t, "timeout", child.GetMessage())
+				}
+				if tt.hosts[n] == healthy {
+					assert.Equal(t, []string{"chart example 1.0.0 is deprecated"}, child.GetWarnings())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package snmp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/mcuadros/go-defaults"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/utils"
+)
+
+const TypeSNMP = "snmp"
+
+type SNMP struct {
+	Name      string        `mapstructure:"name"`
+	Host      string        `mapstructure:"host"`
+	Port      int           `mapstructure:"port" default:"161"`
+	Version   string        `mapstructure:"version" default:"2c"`
+	Community string        `mapstructure:"community" default:"public"`
+	V3        *V3           `mapstructure:"v3"`
+	OIDs      []OID         `mapstructure:"oids"`
+	Timeout   time.Duration `mapstructure:"timeout" default:"5s"`
+}
+
+// V3 configures SNMPv3 user-based security; the security level is derived
+// from the configured protocols.
+type V3 struct {
+	Username       string `mapstructure:"username"`
+	AuthProtocol   string `mapstructure:"authProtocol"`
+	AuthPassphrase string `mapstructure:"authPassphrase"`
+	PrivProtocol   string `mapstructure:"privProtocol"`
+	PrivPassphrase string `mapstructure:"privPassphrase"`
+}
+
+// OID is a queried object, optionally constrained to an exact value or numeric range.
+type OID struct {
+	Name  string   `mapstructure:"name"`
+	OID   string   `mapstructure:"oid"`
+	Value *string  `mapstructure:"value"`
+	Min   *float64 `mapstructure:"min"`
+	Max   *float64 `mapstructure:"max"`
+}
+
+var versions = map[string]gosnmp.SnmpVersion{
+	"1":  gosnmp.Version1,
+	"2c": gosnmp.Version2c,
+	"3":  gosnmp.Version3,
+}
+
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+var privProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"DES":     gosnmp.DES,
+	"AES":     gosnmp.AES,
+	"AES192":  gosnmp.AES192,
+	"AES256":  gosnmp.AES256,
+	"AES192C": gosnmp.AES192C,
+	"AES256C": gosnmp.AES256C,
+}
+
+func init() {
+	provider.Register(TypeSNMP, new(SNMP))
+}
+
+func (i *SNMP) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.String("name", i.Name),
+		slog.String("host", i.Host),
+		slog.Int("port", i.Port),
+		slog.String("version", i.Version),
+		slog.Int("oids", len(i.OIDs)),
+		slog.Any("timeout", i.Timeout),
+	}
+	if i.V3 != nil {
+		logAttr = append(logAttr, slog.String("username", i.V3.Username))
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+func (i *SNMP) SetDefaults() {
+	defaults.SetDefaults(i)
+}
+
+func (i *SNMP) GetType() string {
+	return TypeSNMP
+}
+
+func (i *SNMP) GetName() string {
+	return i.Name
+}
+
+func (i *SNMP) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
+func (i *SNMP) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
+	log := utils.ContextLogger(ctx, slog.String("provider", TypeSNMP), slog.Any("instance", i))
+	log.Debug("checking")
+
+	ctx, cancel := context.WithTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	component := &ph.HealthCheckResponse{
+		Type: TypeSNMP,
+		Name: i.Name,
+	}
+	defer component.LogStatus(log)
+
+	if len(i.OIDs) == 0 {
+		return component.Unhealthy("no oids configured")
+	}
+
+	client, err := i.client(ctx)
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+
+	if err := client.Connect(); err != nil {
+		return component.Unhealthy(err.Error())
+	}
+	defer client.Conn.Close()
+
+	oids := make([]string, 0, len(i.OIDs))
+	for _, oid := range i.OIDs {
+		oids = append(oids, normalize(oid.OID))
+	}
+
+	result, err := client.Get(oids)
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+	if result.Error != gosnmp.NoError {
+		return component.Unhealthy(fmt.Sprintf("agent returned error %s", result.Error))
+	}
+
+	values := make(map[string]gosnmp.SnmpPDU, len(result.Variables))
+	for _, variable := range result.Variables {
+		values[normalize(variable.Name)] = variable
+	}
+
+	for _, oid := range i.OIDs {
+		if msg := oid.check(values[normalize(oid.OID)]); msg != "" {
+			return component.Unhealthy(msg)
+		}
+	}
+
+	return component.Healthy()
+}
+
+// client returns an SNMP client configured for the instance version and security.
+func (i *SNMP) client(ctx context.Context) (*gosnmp.GoSNMP, error) {
+	version, ok := versions[i.Version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported version %q", i.Version)
+	}
+
+	// the deadline of ctx bounds the overall request, including retries
+	timeout := i.Timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	client := &gosnmp.GoSNMP{
+		Context:   ctx,
+		Target:    i.Host,
+		Port:      uint16(i.Port),
+		Version:   version,
+		Community: i.Community,
+		Timeout:   timeout,
+		MaxOids:   gosnmp.MaxOids,
+	}
+
+	if version == gosnmp.Version3 {
+		if i.V3 == nil || i.V3.Username == "" {
+			return nil, fmt.Errorf("v3 username required")
+		}
+
+		params := &gosnmp.UsmSecurityParameters{UserName: i.V3.Username}
+		client.MsgFlags = gosnmp.NoAuthNoPriv
+
+		if i.V3.AuthProtocol != "" {
+			auth, ok := authProtocols[strings.ToUpper(i.V3.AuthProtocol)]
+			if !ok {
+				return nil, fmt.Errorf("unsupported auth protocol %q", i.V3.AuthProtocol)
+			}
+			params.AuthenticationProtocol = auth
+			params.AuthenticationPassphrase = i.V3.AuthPassphrase
+			client.MsgFlags = gosnmp.AuthNoPriv
+
+			if i.V3.PrivProtocol != "" {
+				priv, ok := privProtocols[strings.ToUpper(i.V3.PrivProtocol)]
+				if !ok {
+					return nil, fmt.Errorf("unsupported priv protocol %q", i.V3.PrivProtocol)
+				}
+				params.PrivacyProtocol = priv
+				params.PrivacyPassphrase = i.V3.PrivPassphrase
+				client.MsgFlags = gosnmp.AuthPriv
+			}
+		} else if i.V3.PrivProtocol != "" {
+			return nil, fmt.Errorf("priv protocol requires auth protocol")
+		}
+
+		client.SecurityModel = gosnmp.UserSecurityModel
+		client.SecurityParameters = params
+	}
+
+	return client, nil
+}
+
+// check validates the value returned for the OID against its constraints.
+func (o *OID) check(pdu gosnmp.SnmpPDU) string {
+	name := o.Name
+	if name == "" {
+		name = o.OID
+	}
+
+	switch pdu.Type {
+	case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView, gosnmp.Null, gosnmp.UnknownType:
+		return fmt.Sprintf("%s: no such object", name)
+	}
+
+	if o.Value != nil {
+		if value := format(pdu); value != *o.Value {
+			return fmt.Sprintf("%s is %q; expected %q", name, value, *o.Value)
+		}
+	}
+
+	if o.Min != nil || o.Max != nil {
+		value, err := numeric(pdu)
+		if err != nil {
+			return fmt.Sprintf("%s: %v", name, err)
+		}
+		if o.Min != nil && value < *o.Min {
+			return fmt.Sprintf("%s is %s; expected at least %s", name, formatFloat(value), formatFloat(*o.Min))
+		}
+		if o.Max != nil && value > *o.Max {
+			return fmt.Sprintf("%s is %s; expected at most %s", name, formatFloat(value), formatFloat(*o.Max))
+		}
+	}
+
+	return ""
+}
+
+// format returns the string representation of a returned value
+func format(pdu gosnmp.SnmpPDU) string {
+	switch value := pdu.Value.(type) {
+	case []byte:
+		return string(value)
+	case string:
+		return value
+	default:
+		if pdu.Type == gosnmp.OctetString {
+			return fmt.Sprint(value)
+		}
+		return gosnmp.ToBigInt(value).String()
+	}
+}
+
+// numeric returns the numeric value of a returned value, parsing strings where necessary
+func numeric(pdu gosnmp.SnmpPDU) (float64, error) {
+	switch value := pdu.Value.(type) {
+	case []byte, string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(format(pdu)), 64)
+		if err != nil {
+			return 0, fmt.Errorf("value %q is not numeric", format(pdu))
+		}
+		return parsed, nil
+	default:
+		f, _ := new(big.Float).SetInt(gosnmp.ToBigInt(value)).Float64()
+		return f, nil
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func normalize(oid string) string {
+	return "." + strings.TrimPrefix(oid, ".")
+}
@@ -0,0 +1,279 @@
+package snmp_test
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider/snmp"
+)
+
+func init() {
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+// serve runs a minimal SNMPv2c agent answering Get requests from values,
+// ignoring requests with any other community.
+func serve(t *testing.T, community string, values map[string]gosnmp.SnmpPDU) int {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		decoder := &gosnmp.GoSNMP{Logger: gosnmp.NewLogger(nil)}
+		buf := make([]byte, 65535)
+		for {
+			n, peer, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			request, err := decoder.SnmpDecodePacket(buf[:n])
+			if err != nil || request.Community != community {
+				continue
+			}
+
+			response := &gosnmp.SnmpPacket{
+				Version:   request.Version,
+				Community: request.Community,
+				PDUType:   gosnmp.GetResponse,
+				RequestID: request.RequestID,
+			}
+			for _, variable := range request.Variables {
+				if value, ok := values[variable.Name]; ok {
+					value.Name = variable.Name
+					response.Variables = append(response.Variables, value)
+				} else {
+					response.Variables = append(response.Variables, gosnmp.SnmpPDU{Name: variable.Name, Type: gosnmp.NoSuchObject})
+				}
+			}
+
+			msg, err := response.MarshalMsg()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(msg, peer)
+		}
+	}()
+
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+	p, _ := strconv.Atoi(port)
+	return p
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestSNMP(t *testing.T) {
+	const (
+		sysDescr = ".1.3.6.1.2.1.1.1.0"
+		cpuLoad  = ".1.3.6.1.4.1.2021.10.1.5.1"
+		loadAvg  = ".1.3.6.1.4.1.2021.10.1.3.1"
+		ifCount  = ".1.3.6.1.2.1.2.1.0"
+	)
+
+	port := serve(t, "public", map[string]gosnmp.SnmpPDU{
+		sysDescr: {Type: gosnmp.OctetString, Value: []byte("Test Router")},
+		cpuLoad:  {Type: gosnmp.Integer, Value: 42},
+		loadAvg:  {Type: gosnmp.OctetString, Value: []byte("0.75")},
+		ifCount:  {Type: gosnmp.Integer, Value: 24},
+	})
+
+	tests := []struct {
+		name      string
+		community string
+		version   string
+		oids      []snmp.OID
+		timeout   time.Duration
+		expected  ph.Status
+		message   string
+	}{
+		{
+			name:     "String OID present",
+			oids:     []snmp.OID{{Name: "sysDescr", OID: sysDescr}},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "String OID expected value",
+			oids:     []snmp.OID{{Name: "sysDescr", OID: sysDescr, Value: ptr("Test Router")}},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "String OID unexpected value",
+			oids:     []snmp.OID{{Name: "sysDescr", OID: sysDescr, Value: ptr("Other Router")}},
+			expected: ph.Status_UNHEALTHY,
+			message:  `sysDescr is "Test Router"; expected "Other Router"`,
+		},
+		{
+			name: "Numeric OIDs within range",
+			oids: []snmp.OID{
+				{Name: "cpu_load", OID: cpuLoad, Max: ptr(80.0)},
+				{Name: "interfaces", OID: ifCount, Min: ptr(1.0), Value: ptr("24")},
+			},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Numeric OID above maximum",
+			oids:     []snmp.OID{{Name: "cpu_load", OID: cpuLoad, Max: ptr(40.0)}},
+			expected: ph.Status_UNHEALTHY,
+			message:  "cpu_load is 42; expected at most 40",
+		},
+		{
+			name:     "Numeric OID below minimum",
+			oids:     []snmp.OID{{Name: "interfaces", OID: ifCount, Min: ptr(48.0)}},
+			expected: ph.Status_UNHEALTHY,
+			message:  "interfaces is 24; expected at least 48",
+		},
+		{
+			name:     "Numeric string OID within range",
+			oids:     []snmp.OID{{Name: "load", OID: loadAvg, Max: ptr(1.5)}},
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Non-numeric string OID with range",
+			oids:     []snmp.OID{{Name: "sysDescr", OID: sysDescr, Max: ptr(1.0)}},
+			expected: ph.Status_UNHEALTHY,
+			message:  `sysDescr: value "Test Router" is not numeric`,
+		},
+		{
+			name:     "Missing OID",
+			oids:     []snmp.OID{{Name: "missing", OID: ".1.3.6.1.2.1.99.0"}},
+			expected: ph.Status_UNHEALTHY,
+			message:  "missing: no such object",
+		},
+		{
+			name:      "Wrong community",
+			community: "private",
+			oids:      []snmp.OID{{Name: "sysDescr", OID: sysDescr}},
+			timeout:   200 * time.Millisecond,
+			expected:  ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "No OIDs",
+			expected: ph.Status_UNHEALTHY,
+			message:  "no oids configured",
+		},
+		{
+			name:     "Unsupported version",
+			version:  "4",
+			oids:     []snmp.OID{{Name: "sysDescr", OID: sysDescr}},
+			expected: ph.Status_UNHEALTHY,
+			message:  `unsupported version "4"`,
+		},
+		{
+			name:     "Version 3 without user",
+			version:  "3",
+			oids:     []snmp.OID{{Name: "sysDescr", OID: sysDescr}},
+			expected: ph.Status_UNHEALTHY,
+			message:  "v3 username required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = time.Second
+			}
+
+			instance := &snmp.SNMP{
+				Name:      "TestSNMP",
+				Host:      "127.0.0.1",
+				Port:      port,
+				Version:   tt.version,
+				Community: tt.community,
+				OIDs:      tt.oids,
+				Timeout:   timeout,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.NotNil(t, result)
+			assert.Equal(t, snmp.TypeSNMP, result.GetType())
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+		})
+	}
+}
+
+func TestSNMPUnreachable(t *testing.T) {
+	// reserve a port with no agent listening
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, portStr, _ := net.SplitHostPort(conn.LocalAddr().String())
+	port, _ := strconv.Atoi(portStr)
+	conn.Close()
+
+	instance := &snmp.SNMP{
+		Name:    "TestSNMPUnreachable",
+		Host:    "127.0.0.1",
+		Port:    port,
+		OIDs:    []snmp.OID{{OID: ".1.3.6.1.2.1.1.1.0"}},
+		Timeout: 200 * time.Millisecond,
+	}
+	instance.SetDefaults()
+
+	start := time.Now()
+	result := instance.GetHealth(context.Background())
+
+	assert.Equal(t, ph.Status_UNHEALTHY, result.GetStatus())
+	assert.NotEmpty(t, result.GetMessage())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestSNMPv3Configuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		v3      *snmp.V3
+		message string
+	}{
+		{
+			name:    "Unsupported auth protocol",
+			v3:      &snmp.V3{Username: "monitor", AuthProtocol: "SHA3", AuthPassphrase: "secret123"},
+			message: `unsupported auth protocol "SHA3"`,
+		},
+		{
+			name:    "Unsupported priv protocol",
+			v3:      &snmp.V3{Username: "monitor", AuthProtocol: "SHA256", AuthPassphrase: "secret123", PrivProtocol: "3DES", PrivPassphrase: "secret456"},
+			message: `unsupported priv protocol "3DES"`,
+		},
+		{
+			name:    "Priv without auth",
+			v3:      &snmp.V3{Username: "monitor", PrivProtocol: "AES", PrivPassphrase: "secret456"},
+			message: "priv protocol requires auth protocol",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &snmp.SNMP{
+				Name:    "TestSNMPv3",
+				Host:    "127.0.0.1",
+				Version: "3",
+				V3:      tt.v3,
+				OIDs:    []snmp.OID{{OID: ".1.3.6.1.2.1.1.1.0"}},
+				Timeout: time.Second,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			assert.Equal(t, ph.Status_UNHEALTHY, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+//go:build linux
+
+package tcp_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/tcp"
+)
+
+// listenBacklog opens a loopback listener with the given accept backlog which
+// never accepts, so connections beyond the backlog are not established.
+func listenBacklog(t *testing.T, backlog int) int {
+	t.Helper()
+
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_STREAM, 0)
+	require.NoError(t, err)
+	require.NoError(t, syscall.Bind(fd, &syscall.SockaddrInet4{Addr: [4]byte{127, 0, 0, 1}}))
+	require.NoError(t, syscall.Listen(fd, backlog))
+
+	file := os.NewFile(uintptr(fd), "backlog")
+	listener, err := net.FileListener(file)
+	require.NoError(t, err)
+	file.Close()
+	t.Cleanup(func() { listener.Close() })
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestTCPBacklog(t *testing.T) {
+	tests := []struct {
+		name        string
+		backlog     int
+		connections int
+		expected    ph.Status
+	}{
+		{
+			name:        "Within backlog",
+			backlog:     16,
+			connections: 4,
+			expected:    ph.Status_HEALTHY,
+		},
+		{
+			name:        "Backlog saturated",
+			backlog:     1,
+			connections: 8,
+			expected:    ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &tcp.TCP{
+				Name:        "TestTCPBacklog",
+				Host:        "127.0.0.1",
+				Port:        listenBacklog(t, tt.backlog),
+				Connections: tt.connections,
+				Timeout:     250 * time.Millisecond,
+				Detail:      true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_TCP{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+
+			assert.Equal(t, int32(tt.connections), detail.Accepted+detail.Refused)
+			if tt.expected == ph.Status_HEALTHY {
+				assert.Zero(t, detail.Refused)
+			} else {
+				assert.Positive(t, detail.Refused)
+				assert.Positive(t, detail.Accepted)
+				assert.Contains(t, result.GetMessage(), "connections refused")
+			}
+		})
+	}
+}
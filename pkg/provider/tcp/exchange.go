@@ -0,0 +1,74 @@
+package tcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// escapes are the escape sequences interpreted in Send
+var escapes = strings.NewReplacer(`\r`, "\r", `\n`, "\n", `\t`, "\t", `\0`, "\x00", `\\`, `\`)
+
+// exchange opens a connection to address, writes the configured payload and,
+// if a response pattern is configured, reads up to MaxBytes until the pattern
+// matches. It returns the first line read, and an error if the exchange fails
+// or the response does not match.
+func (i *TCP) exchange(ctx context.Context, address string) (banner string, err error) {
+	var expect *regexp.Regexp
+	if i.Expect != "" {
+		if expect, err = regexp.Compile(i.Expect); err != nil {
+			return "", fmt.Errorf("invalid expect: %w", err)
+		}
+	}
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if i.Send != "" {
+		if _, err := conn.Write([]byte(escapes.Replace(i.Send))); err != nil {
+			return "", fmt.Errorf("send: %w", err)
+		}
+	}
+
+	if expect == nil {
+		return "", nil
+	}
+
+	var response []byte
+	buf := make([]byte, max(i.MaxBytes, 1))
+	for len(response) < len(buf) && !expect.Match(response) {
+		n, err := conn.Read(buf[len(response):])
+		response = buf[:len(response)+n]
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, os.ErrDeadlineExceeded) {
+				return firstLine(response), fmt.Errorf("receive: %w", err)
+			}
+			break
+		}
+	}
+
+	banner = firstLine(response)
+	if !expect.Match(response) {
+		return banner, fmt.Errorf("expected response matching %q; actual %q", i.Expect, banner)
+	}
+	return banner, nil
+}
+
+func firstLine(response []byte) string {
+	line, _, _ := bytes.Cut(response, []byte("\n"))
+	return string(bytes.TrimRight(line, "\r"))
+}
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	"github.com/isometry/platform-health/pkg/provider"
 	"github.com/isometry/platform-health/pkg/utils"
 )
@@ -17,11 +21,17 @@ import (
 const TypeTCP = "tcp"
 
 type TCP struct {
-	Name    string        `mapstructure:"name"`
-	Host    string        `mapstructure:"host"`
-	Port    int           `mapstructure:"port" default:"80"`
-	Closed  bool          `mapstructure:"closed" default:"false"`
-	Timeout time.Duration `mapstructure:"timeout" default:"1s"`
+	Name           string        `mapstructure:"name"`
+	Host           string        `mapstructure:"host"`
+	Port           int           `mapstructure:"port" default:"80"`
+	Closed         bool          `mapstructure:"closed" default:"false"`
+	Connections    int           `mapstructure:"connections" default:"1"`
+	MaxConnectTime time.Duration `mapstructure:"maxConnectTime"`
+	Send           string        `mapstructure:"send"`
+	Expect         string        `mapstructure:"expect"`
+	MaxBytes       int           `mapstructure:"maxBytes" default:"4096"`
+	Timeout        time.Duration `mapstructure:"timeout" default:"1s"`
+	Detail         bool          `mapstructure:"detail"`
 }
 
 func init() {
@@ -34,6 +44,11 @@ func (i *TCP) LogValue() slog.Value {
 		slog.String("host", i.Host),
 		slog.Int("port", i.Port),
 		slog.Bool("closed", i.Closed),
+		slog.Int("connections", i.Connections),
+		slog.Any("maxConnectTime", i.MaxConnectTime),
+		slog.String("send", i.Send),
+		slog.String("expect", i.Expect),
+		slog.Int("maxBytes", i.MaxBytes),
 		slog.Any("timeout", i.Timeout),
 	}
 	return slog.GroupValue(logAttr...)
@@ -51,6 +66,10 @@ func (i *TCP) GetName() string {
 	return i.Name
 }
 
+func (i *TCP) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
 func (i *TCP) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	log := utils.ContextLogger(ctx, slog.String("provider", TypeTCP), slog.Any("instance", i))
 	log.Debug("checking")
@@ -65,20 +84,90 @@ func (i *TCP) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	defer component.LogStatus(log)
 
 	address := net.JoinHostPort(i.Host, fmt.Sprint(i.Port))
-	dialer := &net.Dialer{}
-	conn, err := dialer.DialContext(ctx, "tcp", address)
-	if err != nil {
-		if i.Closed {
+
+	if i.Closed {
+		dialer := &net.Dialer{}
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
 			return component.Healthy()
-		} else {
-			return component.Unhealthy(err.Error())
 		}
-	} else {
 		_ = conn.Close()
-		if i.Closed {
-			return component.Unhealthy("port open")
+		return component.Unhealthy("port open")
+	}
+
+	detail, err := connectAll(ctx, address, max(i.Connections, 1))
+
+	var exchangeErr error
+	if err == nil && (i.Send != "" || i.Expect != "") {
+		detail.Banner, exchangeErr = i.exchange(ctx, address)
+	}
+
+	if i.Detail {
+		if anyDetail, err := anypb.New(detail); err != nil {
+			return component.Unhealthy(err.Error())
 		} else {
-			return component.Healthy()
+			component.Details = append(component.Details, anyDetail)
 		}
 	}
+
+	switch {
+	case detail.Refused > 0 && detail.Accepted+detail.Refused == 1:
+		return component.Unhealthy(err.Error())
+	case detail.Refused > 0:
+		return component.Unhealthy(fmt.Sprintf("%d of %d connections refused: %v", detail.Refused, detail.Accepted+detail.Refused, err))
+	case i.MaxConnectTime > 0 && detail.MaxConnectTime.AsDuration() > i.MaxConnectTime:
+		return component.Unhealthy(fmt.Sprintf("connect time %s exceeds %s", detail.MaxConnectTime.AsDuration(), i.MaxConnectTime))
+	case exchangeErr != nil:
+		return component.Unhealthy(exchangeErr.Error())
+	}
+
+	return component.Healthy()
+}
+
+// connectAll concurrently opens the given number of connections to address,
+// holding each open until all have completed so that together they load the
+// listener's backlog. It returns the number of connections accepted and
+// refused (or timed out), the slowest connect time, and the first error.
+func connectAll(ctx context.Context, address string, connections int) (*details.Detail_TCP, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		conns    []net.Conn
+		firstErr error
+		slowest  time.Duration
+		detail   = &details.Detail_TCP{}
+	)
+
+	for range connections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			dialer := &net.Dialer{}
+			conn, err := dialer.DialContext(ctx, "tcp", address)
+			elapsed := time.Since(start)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				detail.Refused++
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			detail.Accepted++
+			conns = append(conns, conn)
+			slowest = max(slowest, elapsed)
+		}()
+	}
+	wg.Wait()
+
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+
+	detail.MaxConnectTime = durationpb.New(slowest)
+	return detail, firstErr
 }
@@ -1,15 +1,19 @@
 package tcp_test
 
 import (
+	"bufio"
 	"context"
 	"log/slog"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	"github.com/isometry/platform-health/pkg/provider/tcp"
 )
 
@@ -28,11 +32,13 @@ func TestTCP(t *testing.T) {
 	port := listener.Addr().(*net.TCPAddr).Port
 
 	tests := []struct {
-		name     string
-		port     int
-		closed   bool
-		timeout  time.Duration
-		expected ph.Status
+		name           string
+		port           int
+		closed         bool
+		connections    int
+		maxConnectTime time.Duration
+		timeout        time.Duration
+		expected       ph.Status
 	}{
 		{
 			name:     "Port open",
@@ -63,16 +69,36 @@ func TestTCP(t *testing.T) {
 			timeout:  time.Nanosecond,
 			expected: ph.Status_HEALTHY,
 		},
+		{
+			name:        "Concurrent connections",
+			port:        port,
+			connections: 4,
+			expected:    ph.Status_HEALTHY,
+		},
+		{
+			name:           "Within connect time",
+			port:           port,
+			maxConnectTime: time.Second,
+			expected:       ph.Status_HEALTHY,
+		},
+		{
+			name:           "Slow connect",
+			port:           port,
+			maxConnectTime: time.Nanosecond,
+			expected:       ph.Status_UNHEALTHY,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			instance := &tcp.TCP{
-				Name:    tt.name,
-				Host:    "localhost",
-				Port:    tt.port,
-				Closed:  tt.closed,
-				Timeout: tt.timeout,
+				Name:           tt.name,
+				Host:           "localhost",
+				Port:           tt.port,
+				Closed:         tt.closed,
+				Connections:    tt.connections,
+				MaxConnectTime: tt.maxConnectTime,
+				Timeout:        tt.timeout,
 			}
 			instance.SetDefaults()
 
@@ -85,3 +111,152 @@ func TestTCP(t *testing.T) {
 		})
 	}
 }
+
+// serve runs a line protocol server on a random port, answering each
+// connection with handle.
+func serve(t *testing.T, handle func(conn net.Conn)) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestTCPExchange(t *testing.T) {
+	smtp := serve(t, func(conn net.Conn) {
+		conn.Write([]byte("220 mail.example.test ESMTP\r\n"))
+	})
+	redis := serve(t, func(conn net.Conn) {
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			return
+		}
+		if line == "PING\r\n" {
+			conn.Write([]byte("+PONG\r\n"))
+		} else {
+			conn.Write([]byte("-ERR unknown command\r\n"))
+		}
+	})
+	silent := serve(t, func(conn net.Conn) {
+		time.Sleep(time.Second)
+	})
+	stream := serve(t, func(conn net.Conn) {
+		conn.Write([]byte(strings.Repeat("x", 1024)))
+		time.Sleep(time.Second)
+	})
+
+	tests := []struct {
+		name     string
+		port     int
+		send     string
+		expect   string
+		maxBytes int
+		expected ph.Status
+		banner   string
+		message  string
+	}{
+		{
+			name:     "Banner matches",
+			port:     smtp,
+			expect:   "^220 ",
+			expected: ph.Status_HEALTHY,
+			banner:   "220 mail.example.test ESMTP",
+		},
+		{
+			name:     "Banner does not match",
+			port:     smtp,
+			expect:   "^421 ",
+			expected: ph.Status_UNHEALTHY,
+			banner:   "220 mail.example.test ESMTP",
+			message:  `expected response matching "^421 "; actual "220 mail.example.test ESMTP"`,
+		},
+		{
+			name:     "Send and expect",
+			port:     redis,
+			send:     `PING\r\n`,
+			expect:   `^\+PONG`,
+			expected: ph.Status_HEALTHY,
+			banner:   "+PONG",
+		},
+		{
+			name:     "Send unexpected command",
+			port:     redis,
+			send:     `PONG\r\n`,
+			expect:   `^\+PONG`,
+			expected: ph.Status_UNHEALTHY,
+			banner:   "-ERR unknown command",
+		},
+		{
+			name:     "Send only",
+			port:     redis,
+			send:     `PING\r\n`,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "No response before timeout",
+			port:     silent,
+			expect:   "^220 ",
+			expected: ph.Status_UNHEALTHY,
+			message:  `expected response matching "^220 "; actual ""`,
+		},
+		{
+			name:     "Response exceeds maxBytes",
+			port:     stream,
+			expect:   "done",
+			maxBytes: 16,
+			expected: ph.Status_UNHEALTHY,
+			banner:   strings.Repeat("x", 16),
+		},
+		{
+			name:     "Invalid expect",
+			port:     smtp,
+			expect:   "(",
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &tcp.TCP{
+				Name:     tt.name,
+				Host:     "localhost",
+				Port:     tt.port,
+				Send:     tt.send,
+				Expect:   tt.expect,
+				MaxBytes: tt.maxBytes,
+				Timeout:  200 * time.Millisecond,
+				Detail:   true,
+			}
+			instance.SetDefaults()
+
+			start := time.Now()
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Less(t, time.Since(start), 2*instance.Timeout)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, result.GetMessage())
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_TCP{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.banner, detail.Banner)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package tls_test
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider/tls"
+)
+
+func TestTLSAllBackends(t *testing.T) {
+	ca := newAuthority(t)
+	tls.SetCertPool(t, ca.pool())
+
+	good := ca.issue(t, "backend.test")
+	bad := ca.issue(t, "other.test")
+
+	tests := []struct {
+		name     string
+		certs    []gotls.Certificate
+		expected ph.Status
+		backends []ph.Status
+		message  string
+	}{
+		{
+			name:     "All backends matching",
+			certs:    []gotls.Certificate{good, good},
+			expected: ph.Status_HEALTHY,
+			backends: []ph.Status{ph.Status_HEALTHY, ph.Status_HEALTHY},
+		},
+		{
+			name:     "One backend mismatched",
+			certs:    []gotls.Certificate{good, bad},
+			expected: ph.Status_UNHEALTHY,
+			backends: []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY},
+			message:  "1 of 2 backends unhealthy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, backends := serveBackends(t, tt.certs...)
+			tls.SetResolver(t, func(_ context.Context, host string) ([]string, error) {
+				assert.Equal(t, "backend.test", host)
+				return backends, nil
+			})
+
+			instance := &tls.TLS{
+				Name:        "TestTLSAllBackends",
+				Host:        "backend.test",
+				Port:        port,
+				Timeout:     time.Second,
+				AllBackends: true,
+				Detail:      true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			require.Len(t, result.GetComponents(), len(tt.backends))
+			for n, backend := range result.GetComponents() {
+				assert.Equal(t, backends[n], backend.GetName())
+				assert.Equal(t, tt.backends[n], backend.GetStatus())
+				if backend.GetStatus() == ph.Status_HEALTHY {
+					assert.Len(t, backend.GetDetails(), 1)
+				} else {
+					assert.Equal(t, "hostname mismatch", backend.GetMessage())
+				}
+			}
+		})
+	}
+}
+
+func TestTLSAllBackendsResolutionFailure(t *testing.T) {
+	tls.SetResolver(t, func(context.Context, string) ([]string, error) {
+		return nil, &net.DNSError{Err: "no such host", Name: "backend.test", IsNotFound: true}
+	})
+
+	instance := &tls.TLS{
+		Name:        "TestTLSAllBackends",
+		Host:        "backend.test",
+		Timeout:     time.Second,
+		AllBackends: true,
+	}
+	instance.SetDefaults()
+
+	result := instance.GetHealth(context.Background())
+	assert.Equal(t, ph.Status_UNHEALTHY, result.GetStatus())
+	assert.Empty(t, result.GetComponents())
+}
@@ -0,0 +1,159 @@
+package tls_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	gotls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type authority struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newECDSAKey(t *testing.T, curve elliptic.Curve) crypto.Signer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newRSAKey(t *testing.T, bits int) crypto.Signer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	return key
+}
+
+// newAuthority creates a self-signed ECDSA certificate authority
+func newAuthority(t *testing.T) *authority {
+	t.Helper()
+
+	return newAuthorityWithKey(t, newECDSAKey(t, elliptic.P256()))
+}
+
+// newAuthorityWithKey creates a self-signed certificate authority using key
+func newAuthorityWithKey(t *testing.T, key crypto.Signer) *authority {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &authority{cert: cert, key: key}
+}
+
+func (a *authority) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(a.cert)
+	return pool
+}
+
+// issue creates an ECDSA leaf certificate for dnsNames signed by the authority
+func (a *authority) issue(t *testing.T, dnsNames ...string) gotls.Certificate {
+	t.Helper()
+
+	return a.issueWithKey(t, newECDSAKey(t, elliptic.P256()), x509.UnknownSignatureAlgorithm, dnsNames...)
+}
+
+// issueWithKey creates a leaf certificate for dnsNames with key, signed by the
+// authority using signatureAlgorithm (or the default for the authority key)
+func (a *authority) issueWithKey(t *testing.T, key crypto.Signer, signatureAlgorithm x509.SignatureAlgorithm, dnsNames ...string) gotls.Certificate {
+	t.Helper()
+
+	return a.issueUntil(t, key, signatureAlgorithm, time.Now().Add(90*24*time.Hour), dnsNames...)
+}
+
+// issueUntil creates a leaf certificate for dnsNames with key, signed by the
+// authority using signatureAlgorithm, valid until notAfter
+func (a *authority) issueUntil(t *testing.T, key crypto.Signer, signatureAlgorithm x509.SignatureAlgorithm, notAfter time.Time, dnsNames ...string) gotls.Certificate {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SignatureAlgorithm: signatureAlgorithm,
+		SerialNumber:       big.NewInt(time.Now().UnixNano()),
+		Subject:            pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:           dnsNames,
+		NotBefore:          time.Now().Add(-48 * time.Hour),
+		NotAfter:           notAfter,
+		KeyUsage:           x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, key.Public(), a.key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return gotls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// serveTLS accepts TLS connections on addr, presenting cert
+func serveTLS(t *testing.T, addr string, cert gotls.Certificate) string {
+	return serveTLSConfig(t, addr, &gotls.Config{Certificates: []gotls.Certificate{cert}})
+}
+
+// serveTLSConfig accepts TLS connections on addr, configured by config
+func serveTLSConfig(t *testing.T, addr string, config *gotls.Config) string {
+	t.Helper()
+
+	listener, err := gotls.Listen("tcp", addr, config)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.(*gotls.Conn).Handshake()
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// serveBackends starts one TLS server per certificate on distinct loopback
+// addresses sharing a port, returning the port and backend addresses.
+func serveBackends(t *testing.T, certs ...gotls.Certificate) (port int, backends []string) {
+	t.Helper()
+
+	for n, cert := range certs {
+		ip := "127.0.0." + strconv.Itoa(n+1)
+		addr := serveTLS(t, net.JoinHostPort(ip, strconv.Itoa(port)), cert)
+		if port == 0 {
+			_, portStr, _ := net.SplitHostPort(addr)
+			port, _ = strconv.Atoi(portStr)
+		}
+		backends = append(backends, ip)
+	}
+
+	return port, backends
+}
@@ -0,0 +1,265 @@
+package tls_test
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/rand"
+	gotls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/tls"
+)
+
+// intermediate creates an ECDSA intermediate authority signed by the
+// authority, valid until notAfter
+func (a *authority) intermediate(t *testing.T, notAfter time.Time) *authority {
+	t.Helper()
+
+	key := newECDSAKey(t, elliptic.P256())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, key.Public(), a.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &authority{cert: cert, key: key}
+}
+
+// issueWithOCSP creates a leaf certificate for localhost naming ocspServer as
+// its OCSP responder, presented together with the issuing intermediate
+func (a *authority) issueWithOCSP(t *testing.T, ocspServer string) gotls.Certificate {
+	t.Helper()
+
+	key := newECDSAKey(t, elliptic.P256())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if ocspServer != "" {
+		template.OCSPServer = []string{ocspServer}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, key.Public(), a.key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return gotls.Certificate{Certificate: [][]byte{der, a.cert.Raw}, PrivateKey: key, Leaf: leaf}
+}
+
+// ocspResponse signs an OCSP response with the given status for leaf
+func (a *authority) ocspResponse(t *testing.T, leaf *x509.Certificate, status int) []byte {
+	t.Helper()
+
+	template := ocsp.Response{
+		Status:       status,
+		SerialNumber: leaf.SerialNumber,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   time.Now().Add(time.Hour),
+	}
+	if status == ocsp.Revoked {
+		template.RevokedAt = time.Now().Add(-time.Minute)
+	}
+	response, err := ocsp.CreateResponse(a.cert, a.cert, template, a.key)
+	require.NoError(t, err)
+	return response
+}
+
+// serveOCSP runs an OCSP responder for certificates issued by the authority,
+// answering every request with status
+func (a *authority) serveOCSP(t *testing.T, status int) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		request, err := ocsp.ParseRequest(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		w.Write(a.ocspResponse(t, &x509.Certificate{SerialNumber: request.SerialNumber}, status))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestTLSChain(t *testing.T) {
+	root := newAuthority(t)
+	tls.SetCertPool(t, root.pool())
+
+	tests := []struct {
+		name             string
+		expires          time.Duration
+		chainMinValidity time.Duration
+		expected         ph.Status
+	}{
+		{
+			name:     "Chain without minimum validity",
+			expires:  48 * time.Hour,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:             "Intermediate within minimum validity",
+			expires:          48 * time.Hour,
+			chainMinValidity: 24 * time.Hour,
+			expected:         ph.Status_HEALTHY,
+		},
+		{
+			name:             "Intermediate expiring",
+			expires:          48 * time.Hour,
+			chainMinValidity: 30 * 24 * time.Hour,
+			expected:         ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intermediate := root.intermediate(t, time.Now().Add(tt.expires))
+			port, _ := serveBackends(t, intermediate.issueWithOCSP(t, ""))
+
+			instance := &tls.TLS{
+				Name:             "TestTLSChain",
+				Host:             "localhost",
+				Port:             port,
+				Timeout:          time.Second,
+				MinValidity:      time.Hour,
+				ChainMinValidity: tt.chainMinValidity,
+				Detail:           true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_TLS{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			require.Len(t, detail.Certificates, 2)
+
+			leaf, ca := detail.Certificates[0], detail.Certificates[1]
+			assert.Equal(t, "CN=localhost", leaf.Subject)
+			assert.Equal(t, "CN=Test Intermediate CA", leaf.Issuer)
+			assert.False(t, leaf.IsCA)
+			assert.Equal(t, "CN=Test Intermediate CA", ca.Subject)
+			assert.Equal(t, "CN=Test CA", ca.Issuer)
+			assert.True(t, ca.IsCA)
+			assert.WithinDuration(t, time.Now().Add(tt.expires), ca.NotAfter.AsTime(), time.Minute)
+			assert.Empty(t, detail.OcspStatus)
+		})
+	}
+}
+
+func TestTLSOCSP(t *testing.T) {
+	root := newAuthority(t)
+	tls.SetCertPool(t, root.pool())
+	intermediate := root.intermediate(t, time.Now().Add(365*24*time.Hour))
+
+	tests := []struct {
+		name      string
+		responder func() string
+		staple    int
+		expected  ph.Status
+		ocsp      string
+		warning   bool
+	}{
+		{
+			name:      "Responder reports good",
+			responder: func() string { return intermediate.serveOCSP(t, ocsp.Good) },
+			expected:  ph.Status_HEALTHY,
+			ocsp:      tls.OCSPGood,
+		},
+		{
+			name:      "Responder reports revoked",
+			responder: func() string { return intermediate.serveOCSP(t, ocsp.Revoked) },
+			expected:  ph.Status_UNHEALTHY,
+			ocsp:      tls.OCSPRevoked,
+		},
+		{
+			name:      "Responder unreachable",
+			responder: func() string { return "http://127.0.0.1:1/" },
+			expected:  ph.Status_HEALTHY,
+			ocsp:      tls.OCSPUnknown,
+			warning:   true,
+		},
+		{
+			name:      "No responder",
+			responder: func() string { return "" },
+			expected:  ph.Status_HEALTHY,
+			ocsp:      tls.OCSPUnknown,
+			warning:   true,
+		},
+		{
+			name:      "Stapled revoked",
+			responder: func() string { return "" },
+			staple:    ocsp.Revoked,
+			expected:  ph.Status_UNHEALTHY,
+			ocsp:      tls.OCSPRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := intermediate.issueWithOCSP(t, tt.responder())
+			if tt.staple != 0 {
+				cert.OCSPStaple = intermediate.ocspResponse(t, cert.Leaf, tt.staple)
+			}
+			port, _ := serveBackends(t, cert)
+
+			instance := &tls.TLS{
+				Name:    "TestTLSOCSP",
+				Host:    "localhost",
+				Port:    port,
+				Timeout: time.Second,
+				OCSP:    true,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.warning {
+				assert.Len(t, result.GetWarnings(), 1)
+			} else {
+				assert.Empty(t, result.GetWarnings())
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_TLS{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.ocsp, detail.OcspStatus)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package tls
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+)
+
+type resolverFunc func(ctx context.Context, host string) ([]string, error)
+
+func (f resolverFunc) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return f(ctx, host)
+}
+
+// SetResolver replaces the backend resolver for the duration of a test
+func SetResolver(t *testing.T, lookup func(ctx context.Context, host string) ([]string, error)) {
+	t.Helper()
+
+	original := resolver
+	resolver = resolverFunc(lookup)
+	t.Cleanup(func() { resolver = original })
+}
+
+// SetCertPool replaces the trusted root certificates for the duration of a test
+func SetCertPool(t *testing.T, pool *x509.CertPool) {
+	t.Helper()
+
+	original := certPool
+	certPool = pool
+	t.Cleanup(func() { certPool = original })
+}
@@ -0,0 +1,101 @@
+package tls
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	OCSPGood    = "good"
+	OCSPRevoked = "revoked"
+	OCSPUnknown = "unknown"
+)
+
+// maxOCSPResponseSize is the maximum number of bytes of an OCSP response read
+const maxOCSPResponseSize = 1 << 20
+
+// ocspStatus returns the revocation status of the leaf certificate presented
+// in state, from the stapled OCSP response if any, else from the first OCSP
+// responder named in the certificate. If no status can be obtained, it
+// returns OCSPUnknown with the reason.
+func ocspStatus(ctx context.Context, state *tls.ConnectionState) (string, error) {
+	leaf := state.PeerCertificates[0]
+	issuer := issuerOf(state)
+	if issuer == nil {
+		return OCSPUnknown, errors.New("issuer certificate not presented")
+	}
+
+	raw := state.OCSPResponse
+	if len(raw) == 0 {
+		var err error
+		if raw, err = fetchOCSP(ctx, leaf, issuer); err != nil {
+			return OCSPUnknown, err
+		}
+	}
+
+	response, err := ocsp.ParseResponseForCert(raw, leaf, issuer)
+	if err != nil {
+		return OCSPUnknown, fmt.Errorf("invalid response: %w", err)
+	}
+
+	switch response.Status {
+	case ocsp.Good:
+		return OCSPGood, nil
+	case ocsp.Revoked:
+		return OCSPRevoked, nil
+	default:
+		return OCSPUnknown, nil
+	}
+}
+
+// issuerOf returns the issuer of the leaf certificate, preferring the verified
+// chain to the certificates presented by the peer.
+func issuerOf(state *tls.ConnectionState) *x509.Certificate {
+	for _, chain := range state.VerifiedChains {
+		if len(chain) > 1 {
+			return chain[1]
+		}
+	}
+	if len(state.PeerCertificates) > 1 {
+		return state.PeerCertificates[1]
+	}
+	return nil
+}
+
+func fetchOCSP(ctx context.Context, leaf, issuer *x509.Certificate) ([]byte, error) {
+	if len(leaf.OCSPServer) == 0 {
+		return nil, errors.New("no responder in certificate")
+	}
+
+	body, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, leaf.OCSPServer[0], bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/ocsp-request")
+	request.Header.Set("Accept", "application/ocsp-response")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("responder returned status %d", response.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, maxOCSPResponseSize))
+}
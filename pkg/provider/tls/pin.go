@@ -0,0 +1,48 @@
+package tls
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// PinSPKI pins the SHA-256 fingerprint of the certificate's public key
+	PinSPKI = "spki"
+	// PinCert pins the SHA-256 fingerprint of the whole certificate
+	PinCert = "cert"
+)
+
+// fingerprint returns the hex-encoded SHA-256 fingerprint of the certificate
+// or of its public key, according to mode.
+func fingerprint(cert *x509.Certificate, mode string) (string, error) {
+	var sum [sha256.Size]byte
+	switch strings.ToLower(mode) {
+	case PinSPKI:
+		sum = sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	case PinCert:
+		sum = sha256.Sum256(cert.Raw)
+	default:
+		return "", fmt.Errorf("unsupported pin mode %q", mode)
+	}
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// normalizeFingerprint lowercases a hex fingerprint, removing any colon separators.
+func normalizeFingerprint(pin string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(pin), ":", ""))
+}
+
+// pinMatched reports whether the fingerprint of cert matches any of pins.
+func pinMatched(cert *x509.Certificate, mode string, pins []string) (bool, error) {
+	actual, err := fingerprint(cert, mode)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(pins, func(pin string) bool {
+		return normalizeFingerprint(pin) == actual
+	}), nil
+}
@@ -0,0 +1,62 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// Policy constrains the key strength and signature algorithm of presented certificates.
+type Policy struct {
+	MinRSABits                int      `mapstructure:"minRSABits" default:"2048"`
+	Curves                    []string `mapstructure:"curves" default:"[P-256,P-384,P-521]"`
+	BannedSignatureAlgorithms []string `mapstructure:"bannedSignatureAlgorithms" default:"[MD2-RSA,MD5-RSA,SHA1-RSA,DSA-SHA1,ECDSA-SHA1]"`
+}
+
+func (p *Policy) LogValue() slog.Value {
+	logAttr := []slog.Attr{
+		slog.Int("minRSABits", p.MinRSABits),
+		slog.Any("curves", p.Curves),
+		slog.Any("bannedSignatureAlgorithms", p.BannedSignatureAlgorithms),
+	}
+	return slog.GroupValue(logAttr...)
+}
+
+// Check validates the certificate against the policy, returning the first violation.
+func (p *Policy) Check(cert *x509.Certificate) error {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if bits := key.N.BitLen(); bits < p.MinRSABits {
+			return fmt.Errorf("RSA key size %d bits is less than %d bits", bits, p.MinRSABits)
+		}
+	case *ecdsa.PublicKey:
+		if curve := key.Curve.Params().Name; !slices.Contains(p.Curves, curve) {
+			return fmt.Errorf("ECDSA curve %s is not allowed", curve)
+		}
+	}
+
+	if algorithm := cert.SignatureAlgorithm.String(); slices.Contains(p.BannedSignatureAlgorithms, algorithm) {
+		return fmt.Errorf("signature algorithm %s is not allowed", algorithm)
+	}
+
+	return nil
+}
+
+// keyBits returns the size in bits of the public key of the certificate, or 0
+// if the key type is not supported.
+func keyBits(cert *x509.Certificate) int32 {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return int32(key.N.BitLen())
+	case *ecdsa.PublicKey:
+		return int32(key.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		return int32(len(key) * 8)
+	default:
+		return 0
+	}
+}
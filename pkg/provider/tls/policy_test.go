@@ -0,0 +1,157 @@
+package tls_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/elliptic"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider/tls"
+)
+
+func TestPolicy(t *testing.T) {
+	ecdsaCA := newAuthority(t)
+	rsaCA := newAuthorityWithKey(t, newRSAKey(t, 2048))
+
+	tests := []struct {
+		name     string
+		ca       *authority
+		key      func(t *testing.T) crypto.Signer
+		sigAlg   x509.SignatureAlgorithm
+		policy   tls.Policy
+		expected string
+	}{
+		{
+			name:   "RSA 2048 with SHA256",
+			ca:     rsaCA,
+			key:    func(t *testing.T) crypto.Signer { return newRSAKey(t, 2048) },
+			sigAlg: x509.SHA256WithRSA,
+		},
+		{
+			name:     "RSA 1024",
+			ca:       rsaCA,
+			key:      func(t *testing.T) crypto.Signer { return newRSAKey(t, 1024) },
+			sigAlg:   x509.SHA256WithRSA,
+			expected: "RSA key size 1024 bits is less than 2048 bits",
+		},
+		{
+			name:     "RSA 2048 below raised minimum",
+			ca:       rsaCA,
+			key:      func(t *testing.T) crypto.Signer { return newRSAKey(t, 2048) },
+			sigAlg:   x509.SHA256WithRSA,
+			policy:   tls.Policy{MinRSABits: 3072},
+			expected: "RSA key size 2048 bits is less than 3072 bits",
+		},
+		{
+			name:     "SHA1 signature",
+			ca:       rsaCA,
+			key:      func(t *testing.T) crypto.Signer { return newRSAKey(t, 2048) },
+			sigAlg:   x509.SHA1WithRSA,
+			expected: "signature algorithm SHA1-RSA is not allowed",
+		},
+		{
+			name:   "ECDSA P-256",
+			ca:     ecdsaCA,
+			key:    func(t *testing.T) crypto.Signer { return newECDSAKey(t, elliptic.P256()) },
+			sigAlg: x509.ECDSAWithSHA256,
+		},
+		{
+			name:     "ECDSA P-224",
+			ca:       ecdsaCA,
+			key:      func(t *testing.T) crypto.Signer { return newECDSAKey(t, elliptic.P224()) },
+			sigAlg:   x509.ECDSAWithSHA256,
+			expected: "ECDSA curve P-224 is not allowed",
+		},
+		{
+			name:     "ECDSA P-256 excluded from allowed curves",
+			ca:       ecdsaCA,
+			key:      func(t *testing.T) crypto.Signer { return newECDSAKey(t, elliptic.P256()) },
+			sigAlg:   x509.ECDSAWithSHA256,
+			policy:   tls.Policy{Curves: []string{"P-384"}},
+			expected: "ECDSA curve P-256 is not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := tt.ca.issueWithKey(t, tt.key(t), tt.sigAlg, "policy.test")
+
+			policy := tt.policy
+			defaults.SetDefaults(&policy)
+
+			err := policy.Check(cert.Leaf)
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPolicyEnforcement(t *testing.T) {
+	ca := newAuthorityWithKey(t, newRSAKey(t, 2048))
+	tls.SetCertPool(t, ca.pool())
+
+	tests := []struct {
+		name     string
+		bits     int
+		policy   *tls.Policy
+		expected ph.Status
+		policyOk bool
+	}{
+		{
+			name:     "Weak key without policy",
+			bits:     1024,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Weak key with policy",
+			bits:     1024,
+			policy:   &tls.Policy{},
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Strong key with policy",
+			bits:     2048,
+			policy:   &tls.Policy{},
+			expected: ph.Status_HEALTHY,
+			policyOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, _ := serveBackends(t, ca.issueWithKey(t, newRSAKey(t, tt.bits), x509.SHA256WithRSA, "localhost"))
+
+			instance := &tls.TLS{
+				Name:    "TestPolicyEnforcement",
+				Host:    "localhost",
+				Port:    port,
+				Timeout: time.Second,
+				Policy:  tt.policy,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_TLS{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.EqualValues(t, tt.bits, detail.KeyBits)
+			assert.Equal(t, "SHA256-RSA", detail.SignatureAlgorithm)
+			assert.Equal(t, tt.policyOk, detail.PolicyOk)
+		})
+	}
+}
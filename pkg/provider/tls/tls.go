@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/mcuadros/go-defaults"
@@ -24,16 +26,30 @@ import (
 const TypeTLS = "tls"
 
 type TLS struct {
-	Name        string        `mapstructure:"name"`
-	Host        string        `mapstructure:"host"`
-	Port        int           `mapstructure:"port" default:"443"`
-	Timeout     time.Duration `mapstructure:"timeout" default:"5s"`
-	Insecure    bool          `mapstructure:"insecure"`
-	MinValidity time.Duration `mapstructure:"minValidity" default:"24h"`
-	SANs        []string      `mapstructure:"subjectAltNames"`
-	Detail      bool          `mapstructure:"detail"`
+	Name             string        `mapstructure:"name"`
+	Host             string        `mapstructure:"host"`
+	Port             int           `mapstructure:"port" default:"443"`
+	Timeout          time.Duration `mapstructure:"timeout" default:"5s"`
+	Insecure         bool          `mapstructure:"insecure"`
+	MinValidity      time.Duration `mapstructure:"minValidity" default:"24h"`
+	ChainMinValidity time.Duration `mapstructure:"chainMinValidity"`
+	SANs             []string      `mapstructure:"subjectAltNames"`
+	ALPN             []string      `mapstructure:"alpn"`
+	Pins             []string      `mapstructure:"pinnedFingerprints"`
+	PinMode          string        `mapstructure:"pinMode" default:"spki"`
+	AllBackends      bool          `mapstructure:"allBackends"`
+	Policy           *Policy       `mapstructure:"policy"`
+	OCSP             bool          `mapstructure:"ocsp"`
+	Detail           bool          `mapstructure:"detail"`
 }
 
+// hostResolver is the interface through which the backends of a host are resolved.
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+var resolver hostResolver = net.DefaultResolver
+
 type VerificationStatus struct {
 	UnknownAuthority bool
 	HostnameMismatch bool
@@ -54,12 +70,23 @@ func (i *TLS) LogValue() slog.Value {
 		slog.String("host", i.Host),
 		slog.Int("port", i.Port),
 		slog.Any("timeout", i.Timeout),
+		slog.Bool("allBackends", i.AllBackends),
+		slog.Any("alpn", i.ALPN),
+		slog.Any("pinnedFingerprints", i.Pins),
+		slog.String("pinMode", i.PinMode),
+		slog.Bool("ocsp", i.OCSP),
+	}
+	if i.Policy != nil {
+		logAttr = append(logAttr, slog.Any("policy", i.Policy))
 	}
 	return slog.GroupValue(logAttr...)
 }
 
 func (i *TLS) SetDefaults() {
 	defaults.SetDefaults(i)
+	if i.Policy != nil {
+		defaults.SetDefaults(i.Policy)
+	}
 }
 
 func (i *TLS) GetType() string {
@@ -70,6 +97,10 @@ func (i *TLS) GetName() string {
 	return i.Name
 }
 
+func (i *TLS) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
 func (i *TLS) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	log := utils.ContextLogger(ctx, slog.String("provider", TypeTLS), slog.Any("instance", i))
 	log.Debug("checking")
@@ -83,9 +114,55 @@ func (i *TLS) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	}
 	defer component.LogStatus(log)
 
+	if !i.AllBackends {
+		return i.check(ctx, component, i.Host)
+	}
+
+	addrs, err := resolver.LookupHost(ctx, i.Host)
+	if err != nil {
+		return component.Unhealthy(err.Error())
+	}
+	slices.Sort(addrs)
+	addrs = slices.Compact(addrs)
+
+	component.Components = make([]*ph.HealthCheckResponse, len(addrs))
+
+	var wg sync.WaitGroup
+	for n, addr := range addrs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			backend := &ph.HealthCheckResponse{
+				Type: TypeTLS,
+				Name: addr,
+			}
+			component.Components[n] = i.check(ctx, backend, addr)
+		}()
+	}
+	wg.Wait()
+
+	unhealthy := 0
+	component.Status = ph.Status_HEALTHY
+	for _, backend := range component.Components {
+		if backend.Status != ph.Status_HEALTHY {
+			unhealthy++
+		}
+		if backend.Status.Number() > component.Status.Number() {
+			component.Status = backend.Status
+		}
+	}
+	if unhealthy > 0 {
+		component.Message = fmt.Sprintf("%d of %d backends unhealthy", unhealthy, len(addrs))
+	}
+
+	return component
+}
+
+// check performs the TLS handshake against addr, validating the certificate presented for Host.
+func (i *TLS) check(ctx context.Context, component *ph.HealthCheckResponse, addr string) *ph.HealthCheckResponse {
 	dialer := &net.Dialer{}
 
-	address := net.JoinHostPort(i.Host, fmt.Sprint(i.Port))
+	address := net.JoinHostPort(addr, fmt.Sprint(i.Port))
 	conn, err := dialer.DialContext(ctx, "tcp", address)
 	if err != nil {
 		return component.Unhealthy(err.Error())
@@ -95,6 +172,7 @@ func (i *TLS) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	tlsConf := &tls.Config{
 		ServerName: i.Host,
 		RootCAs:    certPool,
+		NextProtos: i.ALPN,
 	}
 	if i.Insecure {
 		tlsConf.InsecureSkipVerify = true
@@ -117,18 +195,69 @@ func (i *TLS) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	defer tlsConn.Close()
 
 	connectionState := tlsConn.ConnectionState()
+
+	var revocation string
+	if i.OCSP {
+		var err error
+		if revocation, err = ocspStatus(ctx, &connectionState); err != nil {
+			component.Warn(fmt.Sprintf("ocsp: %v", err))
+		}
+	}
+
+	var pinned bool
+	if len(i.Pins) > 0 {
+		var err error
+		if pinned, err = pinMatched(connectionState.PeerCertificates[0], i.PinMode, i.Pins); err != nil {
+			return component.Unhealthy(err.Error())
+		}
+	}
+
+	var policyErr error
+	if i.Policy != nil {
+		policyErr = i.Policy.Check(connectionState.PeerCertificates[0])
+	}
+
 	if i.Detail {
-		if detail, err := anypb.New(Detail(&connectionState)); err != nil {
+		detail := Detail(&connectionState)
+		detail.OcspStatus = revocation
+		detail.Alpn = i.ALPN
+		detail.PinMatched = pinned
+		detail.PolicyOk = i.Policy != nil && policyErr == nil
+		if detail, err := anypb.New(detail); err != nil {
 			return component.Unhealthy(err.Error())
 		} else {
 			component.Details = append(component.Details, detail)
 		}
 	}
 
+	if len(i.Pins) > 0 && !pinned {
+		return component.Unhealthy(fmt.Sprintf("certificate does not match any pinned %s fingerprint", i.PinMode))
+	}
+
+	if len(i.ALPN) > 0 && connectionState.NegotiatedProtocol == "" {
+		return component.Unhealthy(fmt.Sprintf("no application protocol negotiated; offered %v", i.ALPN))
+	}
+
 	if time.Until(connectionState.PeerCertificates[0].NotAfter) < i.MinValidity {
 		return component.Unhealthy(fmt.Sprintf("certificate expires: %s", connectionState.PeerCertificates[0].NotAfter))
 	}
 
+	if i.ChainMinValidity > 0 {
+		for _, cert := range connectionState.PeerCertificates[1:] {
+			if time.Until(cert.NotAfter) < i.ChainMinValidity {
+				return component.Unhealthy(fmt.Sprintf("intermediate certificate %s expires: %s", cert.Subject.CommonName, cert.NotAfter))
+			}
+		}
+	}
+
+	if revocation == OCSPRevoked {
+		return component.Unhealthy("certificate revoked")
+	}
+
+	if policyErr != nil {
+		return component.Unhealthy(policyErr.Error())
+	}
+
 	if len(i.SANs) > 0 {
 		for _, san := range i.SANs {
 			if !slices.Contains[[]string, string](connectionState.PeerCertificates[0].DNSNames, san) {
@@ -145,8 +274,10 @@ func Detail(state *tls.ConnectionState) (detail *details.Detail_TLS) {
 		CommonName:         state.PeerCertificates[0].Subject.CommonName,
 		SubjectAltNames:    state.PeerCertificates[0].DNSNames,
 		ValidUntil:         timestamppb.New(state.PeerCertificates[0].NotAfter),
+		DaysUntilExpiry:    daysUntil(state.PeerCertificates[0].NotAfter),
 		SignatureAlgorithm: state.PeerCertificates[0].SignatureAlgorithm.String(),
 		PublicKeyAlgorithm: state.PeerCertificates[0].PublicKeyAlgorithm.String(),
+		KeyBits:            keyBits(state.PeerCertificates[0]),
 		Version:            tls.VersionName(state.Version),
 		CipherSuite:        tls.CipherSuiteName(state.CipherSuite),
 		Protocol:           state.NegotiatedProtocol,
@@ -154,8 +285,21 @@ func Detail(state *tls.ConnectionState) (detail *details.Detail_TLS) {
 	chain := make([]string, 0, len(state.PeerCertificates))
 	for _, cert := range state.PeerCertificates {
 		chain = append(chain, cert.Issuer.CommonName)
+		detail.Certificates = append(detail.Certificates, &details.Detail_TLS_Certificate{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			NotBefore: timestamppb.New(cert.NotBefore),
+			NotAfter:  timestamppb.New(cert.NotAfter),
+			IsCA:      cert.IsCA,
+		})
 	}
 	detail.Chain = chain
 
 	return detail
 }
+
+// daysUntil returns the number of whole days until t, rounded down such that
+// any time in the past is negative.
+func daysUntil(t time.Time) int32 {
+	return int32(math.Floor(time.Until(t).Hours() / 24))
+}
@@ -2,13 +2,23 @@ package tls_test
 
 import (
 	"context"
+	"crypto/elliptic"
+	"crypto/sha256"
+	gotls "crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"log/slog"
+	"net"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	"github.com/isometry/platform-health/pkg/provider/tls"
 )
 
@@ -103,3 +113,243 @@ func TestTLS(t *testing.T) {
 		})
 	}
 }
+
+func TestDaysUntilExpiry(t *testing.T) {
+	ca := newAuthority(t)
+	tls.SetCertPool(t, ca.pool())
+
+	tests := []struct {
+		name     string
+		expires  time.Duration
+		days     int32
+		expected ph.Status
+	}{
+		{
+			name:     "Expires in 45 days",
+			expires:  45*24*time.Hour + time.Hour,
+			days:     45,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Expires within a day",
+			expires:  time.Hour,
+			days:     0,
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Expired an hour ago",
+			expires:  -time.Hour,
+			days:     -1,
+			expected: ph.Status_UNHEALTHY,
+		},
+		{
+			name:     "Expired a day ago",
+			expires:  -25 * time.Hour,
+			days:     -2,
+			expected: ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := ca.issueUntil(t, newECDSAKey(t, elliptic.P256()), x509.UnknownSignatureAlgorithm, time.Now().Add(tt.expires), "localhost")
+			port, _ := serveBackends(t, cert)
+
+			instance := &tls.TLS{
+				Name:     "TestDaysUntilExpiry",
+				Host:     "localhost",
+				Port:     port,
+				Timeout:  time.Second,
+				Insecure: true, // allow the handshake with expired certificates
+				Detail:   true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_TLS{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.days, detail.DaysUntilExpiry)
+		})
+	}
+}
+
+func TestTLSALPN(t *testing.T) {
+	ca := newAuthority(t)
+	tls.SetCertPool(t, ca.pool())
+	cert := ca.issue(t, "localhost")
+
+	tests := []struct {
+		name       string
+		serverALPN []string
+		clientALPN []string
+		expected   ph.Status
+		protocol   string
+	}{
+		{
+			name:     "Not requested",
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:       "Preferred protocol negotiated",
+			serverALPN: []string{"h2", "http/1.1"},
+			clientALPN: []string{"h2", "http/1.1"},
+			expected:   ph.Status_HEALTHY,
+			protocol:   "h2",
+		},
+		{
+			name:       "Fallback protocol negotiated",
+			serverALPN: []string{"http/1.1"},
+			clientALPN: []string{"h2", "http/1.1"},
+			expected:   ph.Status_HEALTHY,
+			protocol:   "http/1.1",
+		},
+		{
+			name:       "No common protocol",
+			serverALPN: []string{"http/1.1"},
+			clientALPN: []string{"h2"},
+			expected:   ph.Status_UNHEALTHY,
+		},
+		{
+			name:       "Server without ALPN",
+			clientALPN: []string{"h2"},
+			expected:   ph.Status_UNHEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := serveTLSConfig(t, "127.0.0.1:0", &gotls.Config{
+				Certificates: []gotls.Certificate{cert},
+				NextProtos:   tt.serverALPN,
+			})
+			_, portStr, _ := net.SplitHostPort(addr)
+			port, _ := strconv.Atoi(portStr)
+
+			instance := &tls.TLS{
+				Name:    "TestTLSALPN",
+				Host:    "localhost",
+				Port:    port,
+				Timeout: time.Second,
+				ALPN:    tt.clientALPN,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			if tt.expected != ph.Status_HEALTHY {
+				return
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_TLS{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.protocol, detail.Protocol)
+			assert.Equal(t, tt.clientALPN, detail.Alpn)
+		})
+	}
+}
+
+func TestTLSPinning(t *testing.T) {
+	ca := newAuthority(t)
+	tls.SetCertPool(t, ca.pool())
+	cert := ca.issue(t, "localhost")
+	other := ca.issue(t, "localhost")
+	port, _ := serveBackends(t, cert)
+
+	fingerprint := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+	spki := fingerprint(cert.Leaf.RawSubjectPublicKeyInfo)
+	full := fingerprint(cert.Leaf.Raw)
+
+	// colon-separated uppercase, as displayed by openssl
+	var pairs []string
+	for n := 0; n < len(spki); n += 2 {
+		pairs = append(pairs, strings.ToUpper(spki[n:n+2]))
+	}
+	displayed := strings.Join(pairs, ":")
+
+	tests := []struct {
+		name     string
+		mode     string
+		pins     []string
+		expected ph.Status
+		matched  bool
+		message  string
+	}{
+		{
+			name:     "SPKI pin matched",
+			pins:     []string{fingerprint(other.Leaf.RawSubjectPublicKeyInfo), spki},
+			expected: ph.Status_HEALTHY,
+			matched:  true,
+		},
+		{
+			name:     "Displayed SPKI pin matched",
+			pins:     []string{displayed},
+			expected: ph.Status_HEALTHY,
+			matched:  true,
+		},
+		{
+			name:     "SPKI pin mismatched",
+			pins:     []string{fingerprint(other.Leaf.RawSubjectPublicKeyInfo)},
+			expected: ph.Status_UNHEALTHY,
+			message:  "certificate does not match any pinned spki fingerprint",
+		},
+		{
+			name:     "Certificate pin matched",
+			mode:     tls.PinCert,
+			pins:     []string{full},
+			expected: ph.Status_HEALTHY,
+			matched:  true,
+		},
+		{
+			name:     "SPKI pin in certificate mode",
+			mode:     tls.PinCert,
+			pins:     []string{spki},
+			expected: ph.Status_UNHEALTHY,
+			message:  "certificate does not match any pinned cert fingerprint",
+		},
+		{
+			name:     "Unsupported mode",
+			mode:     "sha1",
+			pins:     []string{spki},
+			expected: ph.Status_UNHEALTHY,
+			message:  `unsupported pin mode "sha1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &tls.TLS{
+				Name:    "TestTLSPinning",
+				Host:    "localhost",
+				Port:    port,
+				Timeout: time.Second,
+				Pins:    tt.pins,
+				PinMode: tt.mode,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			if strings.HasPrefix(tt.message, "unsupported") {
+				return
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_TLS{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, tt.matched, detail.PinMatched)
+		})
+	}
+}
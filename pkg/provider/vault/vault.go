@@ -2,13 +2,20 @@ package vault
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"strings"
 	"time"
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/mcuadros/go-defaults"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	"github.com/isometry/platform-health/pkg/provider"
 	"github.com/isometry/platform-health/pkg/utils"
 )
@@ -16,10 +23,22 @@ import (
 const TypeVault = "vault"
 
 type Vault struct {
-	Name     string        `mapstructure:"name"`
-	Address  string        `mapstructure:"address"`
-	Timeout  time.Duration `mapstructure:"timeout" default:"1s"`
-	Insecure bool          `mapstructure:"insecure"`
+	Name        string        `mapstructure:"name"`
+	Address     string        `mapstructure:"address"`
+	Token       string        `mapstructure:"token"`
+	MinTokenTTL time.Duration `mapstructure:"minTokenTTL"`
+	Mounts      []Mount       `mapstructure:"mounts"`
+	Timeout     time.Duration `mapstructure:"timeout" default:"1s"`
+	Insecure    bool          `mapstructure:"insecure"`
+	Detail      bool          `mapstructure:"detail"`
+}
+
+// Mount is a secret engine mount that must exist. Mounts are listed rather
+// than keyed by path, as configuration keys are case-insensitive.
+type Mount struct {
+	Path string `mapstructure:"path"`
+	// Type is the expected secret engine type, or empty for any type
+	Type string `mapstructure:"type"`
 }
 
 func init() {
@@ -30,6 +49,8 @@ func (i *Vault) LogValue() slog.Value {
 	logAttr := []slog.Attr{
 		slog.String("name", i.Name),
 		slog.String("address", i.Address),
+		slog.Any("minTokenTTL", i.MinTokenTTL),
+		slog.Any("mounts", i.Mounts),
 		slog.Any("timeout", i.Timeout),
 		slog.Bool("insecure", i.Insecure),
 	}
@@ -48,6 +69,10 @@ func (i *Vault) GetName() string {
 	return i.Name
 }
 
+func (i *Vault) GetTimeout() time.Duration {
+	return i.Timeout
+}
+
 func (i *Vault) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	log := utils.ContextLogger(ctx, slog.String("provider", TypeVault), slog.Any("instance", i))
 	log.Debug("checking")
@@ -70,6 +95,9 @@ func (i *Vault) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 	if err != nil {
 		return component.Unhealthy(err.Error())
 	}
+	if i.Token != "" {
+		client.SetToken(i.Token)
+	}
 
 	health, err := client.Sys().HealthWithContext(ctx)
 	if err != nil {
@@ -84,5 +112,90 @@ func (i *Vault) GetHealth(ctx context.Context) *ph.HealthCheckResponse {
 		return component.Unhealthy("vault is sealed")
 	}
 
+	detail := &details.Detail_Vault{
+		Version:     health.Version,
+		ClusterName: health.ClusterName,
+		Standby:     health.Standby,
+	}
+
+	if i.MinTokenTTL > 0 {
+		ttl, err := tokenTTL(ctx, client)
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		if ttl > 0 {
+			detail.TokenTtl = durationpb.New(ttl)
+			if ttl < i.MinTokenTTL {
+				return component.Unhealthy(fmt.Sprintf("token ttl %v is less than %v", ttl, i.MinTokenTTL))
+			}
+		}
+	}
+
+	if len(i.Mounts) > 0 {
+		mounts, err := listMounts(ctx, client)
+		if err != nil {
+			return component.Unhealthy(err.Error())
+		}
+		detail.Mounts = mounts
+		if msg := checkMounts(mounts, i.Mounts); msg != "" {
+			return component.Unhealthy(msg)
+		}
+	}
+
+	if i.Detail {
+		if anyDetail, err := anypb.New(detail); err != nil {
+			return component.Unhealthy(err.Error())
+		} else {
+			component.Details = append(component.Details, anyDetail)
+		}
+	}
+
 	return component.Healthy()
 }
+
+// tokenTTL looks up the remaining ttl of the client token, which is zero if
+// the token never expires.
+func tokenTTL(ctx context.Context, client *vault.Client) (time.Duration, error) {
+	secret, err := client.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up token: %w", err)
+	}
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up token: %w", err)
+	}
+	return ttl, nil
+}
+
+// listMounts returns the secret engine type of every mount path.
+func listMounts(ctx context.Context, client *vault.Client) (map[string]string, error) {
+	output, err := client.Sys().ListMountsWithContext(ctx)
+	if err != nil {
+		var responseError *vault.ResponseError
+		if errors.As(err, &responseError) && responseError.StatusCode == http.StatusForbidden {
+			return nil, errors.New("permission denied listing mounts: token requires read on sys/mounts")
+		}
+		return nil, fmt.Errorf("failed to list mounts: %w", err)
+	}
+
+	mounts := make(map[string]string, len(output))
+	for path, mount := range output {
+		mounts[path] = mount.Type
+	}
+	return mounts, nil
+}
+
+// checkMounts validates that every expected mount path exists, with the
+// expected secret engine type unless empty.
+func checkMounts(mounts map[string]string, expected []Mount) string {
+	for _, mount := range expected {
+		engine, ok := mounts[strings.TrimSuffix(mount.Path, "/")+"/"]
+		if !ok {
+			return fmt.Sprintf("mount %s not found", mount.Path)
+		}
+		if mount.Type != "" && engine != mount.Type {
+			return fmt.Sprintf("mount %s is %s; expected %s", mount.Path, engine, mount.Type)
+		}
+	}
+	return ""
+}
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
 	vaultProvider "github.com/isometry/platform-health/pkg/provider/vault"
 )
 
@@ -87,3 +89,184 @@ func TestVaultGetHealth(t *testing.T) {
 		})
 	}
 }
+
+func TestVaultToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		lookup   string
+		expected ph.Status
+		message  string
+		ttl      time.Duration
+	}{
+		{
+			name:     "Token ttl sufficient",
+			status:   http.StatusOK,
+			lookup:   `{"data":{"ttl":7200}}`,
+			expected: ph.Status_HEALTHY,
+			ttl:      2 * time.Hour,
+		},
+		{
+			name:     "Token ttl insufficient",
+			status:   http.StatusOK,
+			lookup:   `{"data":{"ttl":1800}}`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "token ttl 30m0s is less than 1h0m0s",
+		},
+		{
+			name:     "Token never expires",
+			status:   http.StatusOK,
+			lookup:   `{"data":{"ttl":0}}`,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Permission denied",
+			status:   http.StatusForbidden,
+			lookup:   `{"errors":["permission denied"]}`,
+			expected: ph.Status_UNHEALTHY,
+			message:  "failed to look up token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/sys/health", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"initialized":true,"sealed":false,"standby":true,"version":"1.15.2","cluster_name":"vault-cluster"}`))
+			})
+			mux.HandleFunc("/v1/auth/token/lookup-self", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "test-token", r.Header.Get("X-Vault-Token"))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.lookup))
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			instance := &vaultProvider.Vault{
+				Name:        "TestVaultToken",
+				Address:     server.URL,
+				Token:       "test-token",
+				MinTokenTTL: time.Hour,
+				Timeout:     time.Second,
+				Detail:      true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Contains(t, result.GetMessage(), tt.message)
+			if tt.expected != ph.Status_HEALTHY {
+				return
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_Vault{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, "1.15.2", detail.Version)
+			assert.Equal(t, "vault-cluster", detail.ClusterName)
+			assert.True(t, detail.Standby)
+			assert.Equal(t, tt.ttl, detail.GetTokenTtl().AsDuration())
+		})
+	}
+}
+
+func TestVaultMounts(t *testing.T) {
+	const mountTable = `{"secret/":{"type":"kv","options":{"version":"2"}},"database/":{"type":"database"},"KV-Prod/":{"type":"kv"},"sys/":{"type":"system"}}`
+
+	tests := []struct {
+		name     string
+		mounts   []vaultProvider.Mount
+		status   int
+		expected ph.Status
+		message  string
+	}{
+		{
+			name:     "Mounts present",
+			mounts:   []vaultProvider.Mount{{Path: "secret/", Type: "kv"}, {Path: "database", Type: "database"}},
+			status:   http.StatusOK,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Any engine type",
+			mounts:   []vaultProvider.Mount{{Path: "secret"}},
+			status:   http.StatusOK,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Mixed case path",
+			mounts:   []vaultProvider.Mount{{Path: "KV-Prod/", Type: "kv"}},
+			status:   http.StatusOK,
+			expected: ph.Status_HEALTHY,
+		},
+		{
+			name:     "Mount missing",
+			mounts:   []vaultProvider.Mount{{Path: "pki/", Type: "pki"}},
+			status:   http.StatusOK,
+			expected: ph.Status_UNHEALTHY,
+			message:  "mount pki/ not found",
+		},
+		{
+			name:     "Unexpected engine type",
+			mounts:   []vaultProvider.Mount{{Path: "database/", Type: "kv"}},
+			status:   http.StatusOK,
+			expected: ph.Status_UNHEALTHY,
+			message:  "mount database/ is database; expected kv",
+		},
+		{
+			name:     "Permission denied",
+			mounts:   []vaultProvider.Mount{{Path: "secret/", Type: "kv"}},
+			status:   http.StatusForbidden,
+			expected: ph.Status_UNHEALTHY,
+			message:  "permission denied listing mounts: token requires read on sys/mounts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/sys/health", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"initialized":true,"sealed":false,"standby":false}`))
+			})
+			mux.HandleFunc("/v1/sys/mounts", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					w.Write([]byte(`{"data":` + mountTable + `}`))
+				} else {
+					w.Write([]byte(`{"errors":["permission denied"]}`))
+				}
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			instance := &vaultProvider.Vault{
+				Name:    "TestVaultMounts",
+				Address: server.URL,
+				Token:   "test-token",
+				Mounts:  tt.mounts,
+				Timeout: time.Second,
+				Detail:  true,
+			}
+			instance.SetDefaults()
+
+			result := instance.GetHealth(context.Background())
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.GetStatus())
+			assert.Equal(t, tt.message, result.GetMessage())
+			if tt.expected != ph.Status_HEALTHY {
+				return
+			}
+
+			require.Len(t, result.Details, 1)
+			detail := &details.Detail_Vault{}
+			require.NoError(t, result.Details[0].UnmarshalTo(detail))
+			assert.Equal(t, map[string]string{"secret/": "kv", "database/": "database", "KV-Prod/": "kv", "sys/": "system"}, detail.Mounts)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+)
+
+func TestDeadline(t *testing.T) {
+	conf := mockConfig{
+		&mock.Mock{Name: "fast", Health: ph.Status_HEALTHY, Sleep: time.Millisecond},
+		&mock.Mock{Name: "slow1", Health: ph.Status_HEALTHY, Sleep: 5 * time.Second},
+		&mock.Mock{Name: "slow2", Health: ph.Status_HEALTHY, Sleep: 5 * time.Second},
+	}
+
+	tests := []struct {
+		name       string
+		options    []Option
+		incomplete int
+		message    string
+		status     ph.Status
+	}{
+		{
+			name:       "Deadline reached",
+			options:    []Option{WithDeadline(100 * time.Millisecond)},
+			incomplete: 2,
+			message:    "deadline exceeded: 2 of 3 components did not complete",
+			status:     ph.Status_UNHEALTHY,
+		},
+		{
+			name:    "Deadline not reached",
+			options: []Option{WithDeadline(time.Minute)},
+			status:  ph.Status_HEALTHY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := conf
+			if tt.incomplete == 0 {
+				conf = conf[:1]
+			}
+
+			serverId := "test"
+			server, err := NewPlatformHealthServer(&serverId, conf, tt.options...)
+			require.NoError(t, err)
+
+			start := time.Now()
+			response, err := server.Check(context.Background(), &ph.HealthCheckRequest{})
+			require.NoError(t, err)
+			assert.Less(t, time.Since(start), time.Second)
+
+			assert.Len(t, response.GetComponents(), len(conf))
+			assert.Equal(t, tt.incomplete, provider.Incomplete(response.GetComponents()))
+			assert.Equal(t, tt.message, response.GetMessage())
+			assert.Equal(t, tt.status, response.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+// flapDetector tracks status transitions of components across checks,
+// flagging components transitioning at least threshold times within window.
+type flapDetector struct {
+	window    time.Duration
+	threshold int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	history map[string]*statusHistory
+}
+
+type statusHistory struct {
+	status      ph.Status
+	seen        time.Time
+	transitions []time.Time
+}
+
+func newFlapDetector(window time.Duration, threshold int) *flapDetector {
+	return &flapDetector{
+		window:    window,
+		threshold: threshold,
+		now:       time.Now,
+		history:   make(map[string]*statusHistory),
+	}
+}
+
+// observe records the status of each component (recursively), attaching a
+// Detail_Flapping annotation to components that are flapping.
+func (d *flapDetector) observe(components []*ph.HealthCheckResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.walk(now, "", components)
+
+	// forget components that have not been seen within the window
+	for key, history := range d.history {
+		if now.Sub(history.seen) > d.window {
+			delete(d.history, key)
+		}
+	}
+}
+
+func (d *flapDetector) walk(now time.Time, parent string, components []*ph.HealthCheckResponse) {
+	for _, component := range components {
+		key := strings.TrimPrefix(fmt.Sprintf("%s/%s/%s", parent, component.GetType(), component.GetName()), "/")
+		if transitions := d.record(now, key, component.GetStatus()); transitions >= d.threshold {
+			detail := &details.Detail_Flapping{
+				Transitions: int32(transitions),
+				Window:      durationpb.New(d.window),
+			}
+			if anyDetail, err := anypb.New(detail); err == nil {
+				component.Details = append(component.Details, anyDetail)
+			}
+		}
+		d.walk(now, key, component.GetComponents())
+	}
+}
+
+// record notes the status of the component identified by key, returning the
+// number of transitions within the window.
+func (d *flapDetector) record(now time.Time, key string, status ph.Status) int {
+	history, ok := d.history[key]
+	if !ok {
+		d.history[key] = &statusHistory{status: status, seen: now}
+		return 0
+	}
+
+	if status != history.status {
+		history.transitions = append(history.transitions, now)
+		history.status = status
+	}
+	history.seen = now
+
+	cutoff := now.Add(-d.window)
+	for len(history.transitions) > 0 && !history.transitions[0].After(cutoff) {
+		history.transitions = history.transitions[1:]
+	}
+
+	return len(history.transitions)
+}
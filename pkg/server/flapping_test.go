@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+// scriptedInstance reports each status of its script in turn, repeating the last
+type scriptedInstance struct {
+	script []ph.Status
+	checks int
+}
+
+func (i *scriptedInstance) GetType() string { return "scripted" }
+func (i *scriptedInstance) GetName() string { return "app" }
+func (i *scriptedInstance) SetDefaults()    {}
+
+func (i *scriptedInstance) GetHealth(context.Context) *ph.HealthCheckResponse {
+	status := i.script[min(i.checks, len(i.script)-1)]
+	i.checks++
+	return &ph.HealthCheckResponse{Type: i.GetType(), Name: i.GetName(), Status: status}
+}
+
+func flappingDetail(t *testing.T, component *ph.HealthCheckResponse) *details.Detail_Flapping {
+	t.Helper()
+
+	for _, detail := range component.GetDetails() {
+		flapping := &details.Detail_Flapping{}
+		if detail.MessageIs(flapping) {
+			require.NoError(t, detail.UnmarshalTo(flapping))
+			return flapping
+		}
+	}
+	return nil
+}
+
+func TestFlapDetection(t *testing.T) {
+	const (
+		H = ph.Status_HEALTHY
+		U = ph.Status_UNHEALTHY
+	)
+
+	instance := &scriptedInstance{script: []ph.Status{H, U, H, U, H, H, H, H}}
+
+	serverId := "test"
+	server, err := NewPlatformHealthServer(&serverId, mockConfig{instance}, WithFlapDetection(time.Minute, 3))
+	require.NoError(t, err)
+
+	now := time.Now()
+	server.flapDetector.now = func() time.Time { return now }
+
+	steps := []struct {
+		advance     time.Duration
+		flapping    bool
+		transitions int32
+	}{
+		{advance: 0, flapping: false},                          // H
+		{advance: time.Second, flapping: false},                // U: 1 transition
+		{advance: time.Second, flapping: false},                // H: 2 transitions
+		{advance: time.Second, flapping: true, transitions: 3}, // U: 3 transitions
+		{advance: time.Second, flapping: true, transitions: 4}, // H: 4 transitions
+		{advance: time.Second, flapping: true, transitions: 4}, // H: stable, transitions still within window
+		{advance: time.Minute, flapping: false},                // H: transitions aged out of window
+		{advance: time.Second, flapping: false},                // H
+	}
+
+	for n, step := range steps {
+		now = now.Add(step.advance)
+
+		response, err := server.Check(context.Background(), &ph.HealthCheckRequest{})
+		require.NoError(t, err)
+		require.Len(t, response.GetComponents(), 1)
+
+		flapping := flappingDetail(t, response.GetComponents()[0])
+		if !step.flapping {
+			assert.Nil(t, flapping, "step %d", n)
+			continue
+		}
+		if assert.NotNil(t, flapping, "step %d", n) {
+			assert.Equal(t, step.transitions, flapping.GetTransitions(), "step %d", n)
+			assert.Equal(t, time.Minute, flapping.GetWindow().AsDuration(), "step %d", n)
+		}
+	}
+}
+
+func TestFlapDetectionDisabled(t *testing.T) {
+	instance := &scriptedInstance{script: []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY, ph.Status_HEALTHY, ph.Status_UNHEALTHY}}
+
+	serverId := "test"
+	server, err := NewPlatformHealthServer(&serverId, mockConfig{instance})
+	require.NoError(t, err)
+
+	for range instance.script {
+		response, err := server.Check(context.Background(), &ph.HealthCheckRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, response.GetComponents()[0].GetDetails())
+	}
+}
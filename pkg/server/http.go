@@ -0,0 +1,84 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+// HTTPHandler returns the handler for the HTTP endpoints of the server:
+//
+//   - /badge: a shields.io endpoint badge of the overall status
+//   - /healthz: the health check response as protojson, with status 200 if
+//     healthy and 503 otherwise, optionally restricted with ?component=
+func (s *PlatformHealthServer) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /badge", s.serveBadge)
+	mux.HandleFunc("GET /healthz", s.serveHealthz)
+	return mux
+}
+
+// ServeHTTP serves the HTTP endpoints of the server on lis.
+func (s *PlatformHealthServer) ServeHTTP(lis net.Listener) error {
+	return http.Serve(lis, s.HTTPHandler())
+}
+
+func (s *PlatformHealthServer) serveBadge(w http.ResponseWriter, r *http.Request) {
+	status, err := s.Check(r.Context(), &ph.HealthCheckRequest{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setCacheControl(w, status)
+
+	_ = formatter.Badge{}.Format(w, status)
+}
+
+func (s *PlatformHealthServer) serveHealthz(w http.ResponseWriter, r *http.Request) {
+	response, err := s.Check(r.Context(), &ph.HealthCheckRequest{Component: r.URL.Query().Get("component")})
+	if status.Code(err) == codes.NotFound {
+		http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body, err := protojson.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setCacheControl(w, response)
+	if response.GetStatus() == ph.Status_HEALTHY {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_, _ = w.Write(body)
+}
+
+// setCacheControl advertises the validity of response to HTTP caches.
+func setCacheControl(w http.ResponseWriter, response *ph.HealthCheckResponse) {
+	for _, detail := range response.GetDetails() {
+		expiry := &details.Detail_Expiry{}
+		if detail.MessageIs(expiry) && detail.UnmarshalTo(expiry) == nil {
+			w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(expiry.GetValidFor().AsDuration().Seconds())))
+			return
+		}
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+}
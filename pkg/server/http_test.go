@@ -0,0 +1,149 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+)
+
+func TestBadgeEndpoint(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       ph.Status
+		options      []Option
+		cacheTTL     time.Duration
+		expected     string
+		cacheControl string
+	}{
+		{
+			name:         "Healthy",
+			status:       ph.Status_HEALTHY,
+			expected:     `{"schemaVersion":1,"label":"health","message":"healthy","color":"green"}`,
+			cacheControl: "no-cache",
+		},
+		{
+			name:         "Unhealthy with validity",
+			status:       ph.Status_UNHEALTHY,
+			options:      []Option{WithValidity(30 * time.Second)},
+			expected:     `{"schemaVersion":1,"label":"health","message":"unhealthy","color":"red"}`,
+			cacheControl: "max-age=30",
+		},
+		{
+			name:         "Healthy with cached component",
+			status:       ph.Status_HEALTHY,
+			cacheTTL:     20 * time.Second,
+			expected:     `{"schemaVersion":1,"label":"health","message":"healthy","color":"green"}`,
+			cacheControl: "max-age=20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var instance provider.Instance = &scriptedInstance{script: []ph.Status{tt.status}}
+			if tt.cacheTTL > 0 {
+				instance = &provider.Cached{Instance: instance, TTL: tt.cacheTTL}
+			}
+
+			serverId := "test"
+			srv, err := NewPlatformHealthServer(&serverId, mockConfig{instance}, tt.options...)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			srv.HTTPHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/badge", nil))
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			assert.Equal(t, tt.cacheControl, recorder.Header().Get("Cache-Control"))
+			assert.JSONEq(t, tt.expected, recorder.Body.String())
+		})
+	}
+}
+
+func TestHealthzEndpoint(t *testing.T) {
+	conf := mockConfig{
+		&mock.Mock{Name: "web", Health: ph.Status_HEALTHY, Sleep: 1},
+		&mock.Mock{Name: "db", Health: ph.Status_UNHEALTHY, Sleep: 1},
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		code       int
+		status     ph.Status
+		components []string
+	}{
+		{
+			name:       "All components",
+			target:     "/healthz",
+			code:       http.StatusServiceUnavailable,
+			status:     ph.Status_UNHEALTHY,
+			components: []string{"web", "db"},
+		},
+		{
+			name:       "Healthy component",
+			target:     "/healthz?component=web",
+			code:       http.StatusOK,
+			status:     ph.Status_HEALTHY,
+			components: []string{"web"},
+		},
+		{
+			name:       "Unhealthy component by type and name",
+			target:     "/healthz?component=mock/db",
+			code:       http.StatusServiceUnavailable,
+			status:     ph.Status_UNHEALTHY,
+			components: []string{"db"},
+		},
+		{
+			name:   "Unknown component",
+			target: "/healthz?component=cache",
+			code:   http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverId := "test"
+			srv, err := NewPlatformHealthServer(&serverId, conf)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			srv.HTTPHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.code, recorder.Code)
+			if tt.code == http.StatusNotFound {
+				assert.Equal(t, "unknown component \"cache\"\n", recorder.Body.String())
+				return
+			}
+
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			response := &ph.HealthCheckResponse{}
+			require.NoError(t, protojson.Unmarshal(recorder.Body.Bytes(), response))
+			assert.Equal(t, tt.status, response.GetStatus())
+
+			names := make([]string, 0, len(response.Components))
+			for _, component := range response.Components {
+				names = append(names, component.GetName())
+			}
+			assert.Equal(t, tt.components, names)
+		})
+	}
+}
+
+func TestHTTPHandlerNotFound(t *testing.T) {
+	serverId := "test"
+	srv, err := NewPlatformHealthServer(&serverId, mockConfig{})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	srv.HTTPHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
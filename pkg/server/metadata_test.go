@@ -0,0 +1,91 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isometry/platform-health/pkg/formatter"
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+)
+
+func TestMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		expected map[string]string
+	}{
+		{
+			name: "No metadata",
+		},
+		{
+			name:    "Empty metadata",
+			options: []Option{WithMetadata(map[string]string{})},
+		},
+		{
+			name:     "Deploy metadata",
+			options:  []Option{WithMetadata(map[string]string{"commit": "abc123", "environment": "production"})},
+			expected: map[string]string{"commit": "abc123", "environment": "production"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &scriptedInstance{script: []ph.Status{ph.Status_HEALTHY}}
+
+			serverId := "test"
+			server, err := NewPlatformHealthServer(&serverId, mockConfig{instance}, tt.options...)
+			require.NoError(t, err)
+
+			// metadata is static, so must be present on every response
+			for range 2 {
+				response, err := server.Check(context.Background(), &ph.HealthCheckRequest{})
+				require.NoError(t, err)
+
+				var labels map[string]string
+				for _, detail := range response.GetDetails() {
+					metadata := &details.Detail_Metadata{}
+					if detail.MessageIs(metadata) {
+						require.NoError(t, detail.UnmarshalTo(metadata))
+						labels = metadata.GetLabels()
+					}
+				}
+				assert.Equal(t, tt.expected, labels)
+
+				for _, component := range response.GetComponents() {
+					assert.Empty(t, component.GetDetails(), "metadata is only attached to the top-level response")
+				}
+			}
+		})
+	}
+}
+
+func TestMetadataFormatted(t *testing.T) {
+	instance := &scriptedInstance{script: []ph.Status{ph.Status_HEALTHY}}
+
+	serverId := "test"
+	server, err := NewPlatformHealthServer(&serverId, mockConfig{instance}, WithMetadata(map[string]string{"commit": "abc123"}))
+	require.NoError(t, err)
+
+	response, err := server.Check(context.Background(), &ph.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, formatter.JSON{}.Format(&buf, response))
+
+	var output struct {
+		Details []struct {
+			Type   string            `json:"@type"`
+			Labels map[string]string `json:"labels"`
+		} `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	require.Len(t, output.Details, 1)
+	assert.Equal(t, "type.googleapis.com/platform_health.detail.v1.Detail_Metadata", output.Details[0].Type)
+	assert.Equal(t, map[string]string{"commit": "abc123"}, output.Details[0].Labels)
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const metricsNamespace = "platform_health"
+
+// metrics records the outcome of every check in a Prometheus registry.
+type metrics struct {
+	registry *prometheus.Registry
+	status   prometheus.Gauge
+	checks   *prometheus.CounterVec
+	last     *prometheus.GaugeVec
+	duration *prometheus.HistogramVec
+
+	mu   sync.Mutex
+	seen map[[2]string]bool
+}
+
+func newMetrics() *metrics {
+	labels := []string{"type", "name"}
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		status: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "status",
+			Help:      "Overall status of the last check (0=UNKNOWN, 1=HEALTHY, 2=UNHEALTHY, 3=LOOP_DETECTED).",
+		}),
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "component_checks_total",
+			Help:      "Number of component checks, by status.",
+		}, append(labels, "status")),
+		last: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "component_status",
+			Help:      "Status of the last check of the component (0=UNKNOWN, 1=HEALTHY, 2=UNHEALTHY, 3=LOOP_DETECTED).",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "component_check_duration_seconds",
+			Help:      "Duration of component checks.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+		seen: map[[2]string]bool{},
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.status, m.checks, m.last, m.duration,
+	)
+
+	return m
+}
+
+// observe records the responses of a check of every component, forgetting
+// the last status of components no longer checked, such as after the
+// configuration is reloaded.
+func (m *metrics) observe(status ph.Status, components []*ph.HealthCheckResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.status.Set(float64(status.Number()))
+
+	seen := make(map[[2]string]bool, len(components))
+	for _, component := range components {
+		seen[[2]string{component.GetType(), component.GetName()}] = true
+		m.record(component)
+	}
+
+	for labels := range m.seen {
+		if !seen[labels] {
+			m.last.DeleteLabelValues(labels[0], labels[1])
+		}
+	}
+	m.seen = seen
+}
+
+// observeComponents records the responses of a check restricted to some
+// components, leaving the overall status and all other components untouched.
+func (m *metrics) observeComponents(components []*ph.HealthCheckResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, component := range components {
+		m.seen[[2]string{component.GetType(), component.GetName()}] = true
+		m.record(component)
+	}
+}
+
+// record updates the series of a single component. The caller must hold m.mu.
+func (m *metrics) record(component *ph.HealthCheckResponse) {
+	typ, name := component.GetType(), component.GetName()
+
+	m.checks.WithLabelValues(typ, name, component.GetStatus().String()).Inc()
+	m.last.WithLabelValues(typ, name).Set(float64(component.GetStatus().Number()))
+	if component.Duration != nil {
+		m.duration.WithLabelValues(typ, name).Observe(component.Duration.AsDuration().Seconds())
+	}
+}
+
+// WithMetrics records the outcome of every check as Prometheus metrics,
+// served by MetricsHandler.
+func WithMetrics() Option {
+	return func(s *PlatformHealthServer) {
+		s.metrics = newMetrics()
+	}
+}
+
+// MetricsHandler returns the handler serving the Prometheus metrics of the
+// server, or nil if metrics are not enabled.
+func (s *PlatformHealthServer) MetricsHandler() http.Handler {
+	if s.metrics == nil {
+		return nil
+	}
+	return promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{})
+}
+
+// ServeMetrics serves the Prometheus metrics of the server at /metrics on lis,
+// failing unless the server was created WithMetrics.
+func (s *PlatformHealthServer) ServeMetrics(lis net.Listener) error {
+	if s.metrics == nil {
+		return errors.New("metrics not enabled")
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", s.MetricsHandler())
+	return http.Serve(lis, mux)
+}
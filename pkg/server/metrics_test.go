@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+)
+
+func scrape(t *testing.T, srv *PlatformHealthServer) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	srv.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
+
+func TestMetrics(t *testing.T) {
+	instance := &scriptedInstance{script: []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY}}
+	conf := mockConfig{instance}
+
+	serverId := "test"
+	srv, err := NewPlatformHealthServer(&serverId, conf, WithMetrics())
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err := srv.Check(context.Background(), &ph.HealthCheckRequest{})
+		require.NoError(t, err)
+	}
+
+	body := scrape(t, srv)
+	assert.Contains(t, body, "platform_health_status 2\n")
+	assert.Contains(t, body, `platform_health_component_checks_total{name="app",status="HEALTHY",type="scripted"} 1`)
+	assert.Contains(t, body, `platform_health_component_checks_total{name="app",status="UNHEALTHY",type="scripted"} 1`)
+	assert.Contains(t, body, `platform_health_component_status{name="app",type="scripted"} 2`)
+	assert.Contains(t, body, `platform_health_component_check_duration_seconds_count{name="app",type="scripted"} 2`)
+
+	// Components no longer configured lose their last status
+	srv.Config = mockConfig{}
+	_, err = srv.Check(context.Background(), &ph.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	body = scrape(t, srv)
+	assert.Contains(t, body, "platform_health_status 1\n")
+	assert.NotContains(t, body, "platform_health_component_status{")
+}
+
+func TestMetricsDisabled(t *testing.T) {
+	serverId := "test"
+	srv, err := NewPlatformHealthServer(&serverId, mockConfig{})
+	require.NoError(t, err)
+
+	assert.Nil(t, srv.MetricsHandler())
+	assert.EqualError(t, srv.ServeMetrics(nil), "metrics not enabled")
+}
+
+func TestMetricsFiltered(t *testing.T) {
+	conf := mockConfig{
+		&mock.Mock{Name: "web", Health: ph.Status_HEALTHY, Sleep: 1},
+		&mock.Mock{Name: "db", Health: ph.Status_UNHEALTHY, Sleep: 1},
+	}
+
+	serverId := "test"
+	srv, err := NewPlatformHealthServer(&serverId, conf, WithMetrics(), WithFlapDetection(time.Minute, 2))
+	require.NoError(t, err)
+
+	_, err = srv.Check(context.Background(), &ph.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = srv.Check(context.Background(), &ph.HealthCheckRequest{Component: "web"})
+	require.NoError(t, err)
+
+	// The selected component is recorded, without its status standing in for the platform's
+	body := scrape(t, srv)
+	assert.Contains(t, body, "platform_health_status 2\n")
+	assert.Contains(t, body, `platform_health_component_checks_total{name="web",status="HEALTHY",type="mock"} 2`)
+	assert.Contains(t, body, `platform_health_component_checks_total{name="db",status="UNHEALTHY",type="mock"} 1`)
+	assert.Contains(t, body, `platform_health_component_status{name="db",type="mock"} 2`)
+
+	// Flap history is only kept from checks of every component
+	instance := &scriptedInstance{script: []ph.Status{ph.Status_HEALTHY, ph.Status_UNHEALTHY, ph.Status_HEALTHY}}
+	srv.Config = mockConfig{instance}
+	for range 2 {
+		_, err = srv.Check(context.Background(), &ph.HealthCheckRequest{Component: "app"})
+		require.NoError(t, err)
+	}
+	assert.NotContains(t, srv.flapDetector.history, "scripted/app")
+
+	_, err = srv.Check(context.Background(), &ph.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Contains(t, srv.flapDetector.history, "scripted/app")
+	assert.Empty(t, srv.flapDetector.history["scripted/app"].transitions)
+}
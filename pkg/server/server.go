@@ -2,15 +2,20 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"slices"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	ph "github.com/isometry/platform-health/pkg/platform_health"
 	"github.com/isometry/platform-health/pkg/platform_health/details"
@@ -19,10 +24,17 @@ import (
 
 type PlatformHealthServer struct {
 	ph.UnimplementedHealthServer
-	Config     provider.Config
-	serverId   *string
-	grpcServer *grpc.Server
-	grpcHealth *gRPCHealthServer
+	Config        provider.Config
+	serverId      *string
+	grpcServer    *grpc.Server
+	grpcHealth    *gRPCHealthServer
+	timeoutPolicy provider.TimeoutPolicy
+	flapDetector  *flapDetector
+	metrics       *metrics
+	watchInterval time.Duration
+	validFor      time.Duration
+	deadline      time.Duration
+	metadata      *anypb.Any
 }
 
 type gRPCHealthServer struct {
@@ -63,6 +75,47 @@ func WithHealthService() Option {
 	}
 }
 
+// WithTimeoutPolicy sets how instance timeouts exceeding the request deadline are handled.
+func WithTimeoutPolicy(policy provider.TimeoutPolicy) Option {
+	return func(s *PlatformHealthServer) {
+		s.timeoutPolicy = policy
+	}
+}
+
+// WithFlapDetection annotates components with at least threshold status transitions within window.
+func WithFlapDetection(window time.Duration, threshold int) Option {
+	return func(s *PlatformHealthServer) {
+		s.flapDetector = newFlapDetector(window, threshold)
+	}
+}
+
+// WithValidity annotates responses as valid for the given duration, for the benefit of caching clients.
+func WithValidity(validFor time.Duration) Option {
+	return func(s *PlatformHealthServer) {
+		s.validFor = validFor
+	}
+}
+
+// WithDeadline caps each check at deadline, regardless of instance timeouts,
+// reporting components that have not completed in time as UNKNOWN.
+func WithDeadline(deadline time.Duration) Option {
+	return func(s *PlatformHealthServer) {
+		s.deadline = deadline
+	}
+}
+
+// WithMetadata attaches static metadata, such as the deployed git commit or environment, to every response.
+func WithMetadata(labels map[string]string) Option {
+	return func(s *PlatformHealthServer) {
+		if len(labels) == 0 {
+			return
+		}
+		if detail, err := anypb.New(&details.Detail_Metadata{Labels: labels}); err == nil {
+			s.metadata = detail
+		}
+	}
+}
+
 func NewPlatformHealthServer(serverId *string, conf provider.Config, options ...Option) (*PlatformHealthServer, error) {
 	phs := &PlatformHealthServer{
 		Config:     conf,
@@ -111,19 +164,65 @@ func (s *PlatformHealthServer) Check(ctx context.Context, req *ph.HealthCheckReq
 	// Add this server to the list of visited servers and push to context for consumption by satellite instances
 	hops = append(hops, *s.serverId)
 	ctx = ContextWithHops(ctx, hops)
+	ctx = provider.ContextWithTimeoutPolicy(ctx, s.timeoutPolicy)
 
 	providerServices := s.Config.GetInstances()
+	if component := req.GetComponent(); component != "" {
+		var err error
+		if providerServices, err = provider.Select(providerServices, component); err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+	}
 
 	start := time.Now()
+	if s.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = provider.ContextWithDeadline(ctx, start.Add(s.deadline))
+		defer cancel()
+	}
 	platformServices, health := provider.Check(ctx, providerServices)
 	duration := durationpb.New(time.Since(start))
 
+	// An incomplete check cannot vouch for the platform, however healthy the components that did complete
+	incomplete := provider.Incomplete(platformServices)
+	if incomplete > 0 && health.Number() < ph.Status_UNHEALTHY.Number() {
+		health = ph.Status_UNHEALTHY
+	}
+
+	// Checks restricted to a component see only part of the platform, so must
+	// not record the overall status, nor the absence of the other components
+	filtered := req.GetComponent() != ""
+
+	if s.flapDetector != nil && !filtered {
+		s.flapDetector.observe(platformServices)
+	}
+
+	if s.metrics != nil {
+		if filtered {
+			s.metrics.observeComponents(platformServices)
+		} else {
+			s.metrics.observe(health, platformServices)
+		}
+	}
+
 	component := ph.HealthCheckResponse{
 		Status:     health,
 		Components: platformServices,
 		Duration:   duration,
 	}
 
+	if incomplete > 0 {
+		component.Message = fmt.Sprintf("deadline exceeded: %d of %d components did not complete", incomplete, len(platformServices))
+	}
+
+	if s.metadata != nil {
+		component.Details = append(component.Details, s.metadata)
+	}
+
+	if validFor := s.validity(providerServices, platformServices, start); validFor > 0 {
+		annotateValidity(ctx, &component, start, validFor)
+	}
+
 	// If a loop was detected, expose serverId to assist debugging
 	if health == ph.Status_LOOP_DETECTED {
 		component.ServerId = s.serverId
@@ -132,6 +231,54 @@ func (s *PlatformHealthServer) Check(ctx context.Context, req *ph.HealthCheckReq
 	return &component, nil
 }
 
+// validity returns how long the response to a check of instances started at
+// checked remains valid: until the first of the responses of the components
+// expires, with cached responses expiring with the cache and others after the
+// configured validity, if any.
+func (s *PlatformHealthServer) validity(instances []provider.Instance, components []*ph.HealthCheckResponse, checked time.Time) (validFor time.Duration) {
+	for n, component := range components {
+		expiry := s.validFor
+		if cache := cacheDetail(component); cache != nil {
+			expiry = cache.GetCheckedAt().AsTime().Add(cache.GetTtl().AsDuration()).Sub(checked)
+		} else if ttl := provider.CacheTTL(instances[n]); ttl > 0 {
+			expiry = ttl
+		}
+		if expiry > 0 && (validFor == 0 || expiry < validFor) {
+			validFor = expiry
+		}
+	}
+	if validFor == 0 {
+		validFor = s.validFor
+	}
+	return validFor
+}
+
+// cacheDetail returns the cache annotation of a response served from cache, or nil.
+func cacheDetail(component *ph.HealthCheckResponse) *details.Detail_Cache {
+	for _, detail := range component.GetDetails() {
+		cache := &details.Detail_Cache{}
+		if detail.MessageIs(cache) && detail.UnmarshalTo(cache) == nil {
+			return cache
+		}
+	}
+	return nil
+}
+
+// annotateValidity adds the expiry of the response to its details, and sets
+// the equivalent cache-control header for gateways transcoding to HTTP.
+func annotateValidity(ctx context.Context, component *ph.HealthCheckResponse, checked time.Time, validFor time.Duration) {
+	expiry := &details.Detail_Expiry{
+		ValidFor:  durationpb.New(validFor),
+		ExpiresAt: timestamppb.New(checked.Add(validFor)),
+	}
+	if detail, err := anypb.New(expiry); err == nil {
+		component.Details = append(component.Details, detail)
+	}
+
+	// fails only outside of a gRPC call, e.g. in one-shot mode
+	_ = grpc.SetHeader(ctx, metadata.Pairs("cache-control", fmt.Sprintf("max-age=%d", int(validFor.Seconds()))))
+}
+
 func (s *gRPCHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
 	return &grpc_health_v1.HealthCheckResponse{
 		Status: grpc_health_v1.HealthCheckResponse_SERVING,
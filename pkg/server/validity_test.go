@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+	"github.com/isometry/platform-health/pkg/platform_health/details"
+	"github.com/isometry/platform-health/pkg/provider"
+	"github.com/isometry/platform-health/pkg/provider/mock"
+)
+
+func expiryDetail(t *testing.T, component *ph.HealthCheckResponse) *details.Detail_Expiry {
+	t.Helper()
+
+	for _, detail := range component.GetDetails() {
+		expiry := &details.Detail_Expiry{}
+		if detail.MessageIs(expiry) {
+			require.NoError(t, detail.UnmarshalTo(expiry))
+			return expiry
+		}
+	}
+	return nil
+}
+
+func TestValidity(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		cacheTTL time.Duration
+		uncached bool
+		validFor time.Duration
+	}{
+		{
+			name: "Disabled",
+		},
+		{
+			name:     "Thirty seconds",
+			options:  []Option{WithValidity(30 * time.Second)},
+			validFor: 30 * time.Second,
+		},
+		{
+			name:     "Cached component",
+			cacheTTL: 10 * time.Second,
+			validFor: 10 * time.Second,
+		},
+		{
+			name:     "Cached component overriding validity",
+			options:  []Option{WithValidity(30 * time.Second)},
+			cacheTTL: time.Minute,
+			validFor: time.Minute,
+		},
+		{
+			name:     "Cached component expiring first",
+			options:  []Option{WithValidity(30 * time.Second)},
+			cacheTTL: 10 * time.Second,
+			uncached: true,
+			validFor: 10 * time.Second,
+		},
+		{
+			name:     "Uncached component expiring first",
+			options:  []Option{WithValidity(30 * time.Second)},
+			cacheTTL: time.Minute,
+			uncached: true,
+			validFor: 30 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var instance provider.Instance = &scriptedInstance{script: []ph.Status{ph.Status_HEALTHY}}
+			if tt.cacheTTL > 0 {
+				instance = &provider.Cached{Instance: instance, TTL: tt.cacheTTL}
+			}
+
+			instances := mockConfig{instance}
+			if tt.uncached {
+				instances = append(instances, &mock.Mock{Name: "uncached", Health: ph.Status_HEALTHY})
+			}
+
+			serverId := "test"
+			server, err := NewPlatformHealthServer(&serverId, instances, tt.options...)
+			require.NoError(t, err)
+
+			before := time.Now()
+			response, err := server.Check(context.Background(), &ph.HealthCheckRequest{})
+			require.NoError(t, err)
+
+			expiry := expiryDetail(t, response)
+			if tt.validFor == 0 {
+				assert.Nil(t, expiry)
+				return
+			}
+
+			require.NotNil(t, expiry)
+			assert.Equal(t, tt.validFor, expiry.GetValidFor().AsDuration())
+			assert.WithinRange(t, expiry.GetExpiresAt().AsTime(), before.Add(tt.validFor), time.Now().Add(tt.validFor))
+		})
+	}
+}
+
+func TestValidityCacheHit(t *testing.T) {
+	const ttl = 10 * time.Second
+
+	instance := &provider.Cached{Instance: &scriptedInstance{script: []ph.Status{ph.Status_HEALTHY}}, TTL: ttl}
+
+	serverId := "test"
+	server, err := NewPlatformHealthServer(&serverId, mockConfig{instance}, WithValidity(30*time.Second))
+	require.NoError(t, err)
+
+	first, err := server.Check(context.Background(), &ph.HealthCheckRequest{})
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	second, err := server.Check(context.Background(), &ph.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// the cached response expires with the first, however much later it is served
+	expiry := expiryDetail(t, second)
+	require.NotNil(t, expiry)
+	assert.Less(t, expiry.GetValidFor().AsDuration(), ttl)
+	assert.Equal(t, expiryDetail(t, first).GetExpiresAt().AsTime().Round(10*time.Millisecond), expiry.GetExpiresAt().AsTime().Round(10*time.Millisecond))
+}
+
+func TestValidityHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		expected []string
+	}{
+		{
+			name: "Disabled",
+		},
+		{
+			name:     "Ninety seconds",
+			options:  []Option{WithValidity(90 * time.Second)},
+			expected: []string{"max-age=90"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &scriptedInstance{script: []ph.Status{ph.Status_HEALTHY}}
+
+			serverId := "test"
+			server, err := NewPlatformHealthServer(&serverId, mockConfig{instance}, tt.options...)
+			require.NoError(t, err)
+
+			listener := bufconn.Listen(1 << 20)
+			go server.Serve(listener)
+			t.Cleanup(server.Stop)
+
+			conn, err := grpc.NewClient("passthrough:///bufconn",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			require.NoError(t, err)
+			t.Cleanup(func() { conn.Close() })
+
+			var header metadata.MD
+			_, err = ph.NewHealthClient(conn).Check(context.Background(), &ph.HealthCheckRequest{}, grpc.Header(&header))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, header.Get("cache-control"))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+const defaultWatchInterval = 10 * time.Second
+
+// WithWatchInterval sets the interval at which watched components are rechecked.
+func WithWatchInterval(interval time.Duration) Option {
+	return func(s *PlatformHealthServer) {
+		s.watchInterval = interval
+	}
+}
+
+// Watch checks the requested components immediately and then at every watch
+// interval, streaming the response whenever the status of the platform or of
+// any component has changed since the last response sent. The stream ends
+// when the client cancels it.
+func (s *PlatformHealthServer) Watch(req *ph.HealthCheckRequest, stream grpc.ServerStreamingServer[ph.HealthCheckResponse]) error {
+	interval := s.watchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last string
+	for {
+		response, err := s.Check(ctx, req)
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if current := statusSignature(response); current != last {
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// statusSignature summarizes the status of a response and of its components,
+// such that responses differing only in messages, details or durations have
+// the same signature.
+func statusSignature(response *ph.HealthCheckResponse) string {
+	var b strings.Builder
+	var walk func(prefix string, response *ph.HealthCheckResponse)
+	walk = func(prefix string, response *ph.HealthCheckResponse) {
+		path := prefix + "/" + response.GetType() + ":" + response.GetName()
+		fmt.Fprintf(&b, "%s=%s\n", path, response.GetStatus())
+		for _, component := range response.GetComponents() {
+			walk(path, component)
+		}
+	}
+	walk("", response)
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	ph "github.com/isometry/platform-health/pkg/platform_health"
+)
+
+func TestWatch(t *testing.T) {
+	const (
+		H = ph.Status_HEALTHY
+		U = ph.Status_UNHEALTHY
+	)
+
+	instance := &scriptedInstance{script: []ph.Status{H, H, H, U, U, H}}
+
+	serverId := "test"
+	srv, err := NewPlatformHealthServer(&serverId, mockConfig{instance}, WithWatchInterval(10*time.Millisecond))
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := ph.NewHealthClient(conn).Watch(ctx, &ph.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// Only changes of status are streamed
+	for _, expected := range []ph.Status{H, U, H} {
+		response, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, expected, response.GetStatus())
+	}
+
+	cancel()
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestWatchUnknownComponent(t *testing.T) {
+	serverId := "test"
+	srv, err := NewPlatformHealthServer(&serverId, mockConfig{&scriptedInstance{script: []ph.Status{ph.Status_HEALTHY}}})
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go srv.Serve(listener)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := ph.NewHealthClient(conn).Watch(context.Background(), &ph.HealthCheckRequest{Component: "unknown"})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+var digestAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// Digest computes the digest of content written to it, for comparison with
+// an expected digest.
+type Digest struct {
+	hash.Hash
+	Algorithm string
+	Expected  string
+	Size      int64
+}
+
+// ParseDigest parses an expected digest of the form "algorithm:hex", e.g.
+// "sha256:e3b0c442...", returning a Digest ready to be written to.
+func ParseDigest(spec string) (*Digest, error) {
+	algorithm, expected, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid digest %q: expected algorithm:hex", spec)
+	}
+	algorithm = strings.ToLower(algorithm)
+
+	newHash, ok := digestAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported digest algorithm %q", algorithm)
+	}
+	if _, err := hex.DecodeString(expected); err != nil {
+		return nil, fmt.Errorf("invalid digest %q: %w", spec, err)
+	}
+
+	return &Digest{Hash: newHash(), Algorithm: algorithm, Expected: strings.ToLower(expected)}, nil
+}
+
+func (d *Digest) Write(p []byte) (int, error) {
+	d.Size += int64(len(p))
+	return d.Hash.Write(p)
+}
+
+// Actual returns the hex-encoded digest of the content written so far
+func (d *Digest) Actual() string {
+	return hex.EncodeToString(d.Sum(nil))
+}
+
+// Verify returns an error if the digest of the content written so far does
+// not match the expected digest.
+func (d *Digest) Verify() error {
+	if actual := d.Actual(); actual != d.Expected {
+		return fmt.Errorf("expected %s digest %s; actual %s", d.Algorithm, d.Expected, actual)
+	}
+	return nil
+}
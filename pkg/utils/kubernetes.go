@@ -7,15 +7,33 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// InCluster reports whether the process is running within a Kubernetes pod
+func InCluster() bool {
+	return os.Getenv("KUBERNETES_SERVICE_HOST") != "" && os.Getenv("KUBERNETES_SERVICE_PORT") != ""
+}
+
 func GetKubeConfig() (config *rest.Config, err error) {
-	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" && os.Getenv("KUBERNETES_SERVICE_PORT") != "" {
+	if InCluster() {
 		// in-cluster config
 		return rest.InClusterConfig()
 	} else {
 		// out-of-cluster config
-		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-		configOverrides := &clientcmd.ConfigOverrides{}
-		kubeconfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
-		return kubeconfig.ClientConfig()
+		return clientConfig().ClientConfig()
 	}
 }
+
+// GetKubeNamespace returns the namespace of the current kubeconfig context,
+// or, in-cluster, the namespace of the pod, falling back to "default".
+func GetKubeNamespace() (string, error) {
+	namespace, _, err := clientConfig().Namespace()
+	if clientcmd.IsEmptyConfig(err) {
+		return "default", nil
+	}
+	return namespace, err
+}
+
+func clientConfig() clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	configOverrides := &clientcmd.ConfigOverrides{}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+}